@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS language VARCHAR(2) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS region VARCHAR(2) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE users
+  DROP COLUMN IF EXISTS region,
+  DROP COLUMN IF EXISTS language;
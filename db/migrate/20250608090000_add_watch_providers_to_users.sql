@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS watch_providers INTEGER[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE users
+  DROP COLUMN IF EXISTS watch_providers;
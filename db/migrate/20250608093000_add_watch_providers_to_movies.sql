@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE movies
+  ADD COLUMN IF NOT EXISTS watch_providers INTEGER[] NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS watch_region VARCHAR(2) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS providers_refreshed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS movies_watch_providers_idx ON movies USING GIN (watch_providers);
+CREATE INDEX IF NOT EXISTS movies_providers_refreshed_at_idx ON movies(providers_refreshed_at NULLS FIRST);
+
+-- +goose Down
+DROP INDEX movies_providers_refreshed_at_idx;
+DROP INDEX movies_watch_providers_idx;
+
+ALTER TABLE movies
+  DROP COLUMN IF EXISTS providers_refreshed_at,
+  DROP COLUMN IF EXISTS watch_region,
+  DROP COLUMN IF EXISTS watch_providers;
@@ -0,0 +1,32 @@
+-- +goose Up
+DO $$ BEGIN IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'credit_roles') THEN CREATE TYPE credit_roles AS ENUM ('cast', 'director'); END IF; END $$;
+
+CREATE TABLE IF NOT EXISTS movie_credits (
+  tmdb_id INTEGER NOT NULL,
+  person_id INTEGER NOT NULL,
+  role credit_roles NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  profile_path VARCHAR(255) NOT NULL DEFAULT '',
+  position INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tmdb_id, person_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS movie_credits_person_id_role_idx ON movie_credits(person_id, role);
+
+ALTER TABLE movies
+  ADD COLUMN IF NOT EXISTS credits_cached_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS movies_credits_not_cached_idx ON movies(tmdb_id) WHERE credits_cached_at IS NULL;
+
+-- +goose Down
+DROP INDEX movies_credits_not_cached_idx;
+
+ALTER TABLE movies
+  DROP COLUMN IF EXISTS credits_cached_at;
+
+DROP INDEX movie_credits_person_id_role_idx;
+
+DROP TABLE movie_credits;
+
+DO $$ BEGIN IF EXISTS (SELECT 1 FROM pg_type WHERE typname = 'credit_roles') THEN DROP TYPE credit_roles; END IF; END $$;
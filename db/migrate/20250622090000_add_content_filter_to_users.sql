@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS include_documentaries BOOLEAN,
+  ADD COLUMN IF NOT EXISTS include_without_artwork BOOLEAN,
+  ADD COLUMN IF NOT EXISTS include_adult BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS adult_confirmed_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE users
+  DROP COLUMN IF EXISTS adult_confirmed_at,
+  DROP COLUMN IF EXISTS include_adult,
+  DROP COLUMN IF EXISTS include_without_artwork,
+  DROP COLUMN IF EXISTS include_documentaries;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS titles (
+  tmdb_id INTEGER PRIMARY KEY,
+  metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+  fetched_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS titles_fetched_at_idx ON titles(fetched_at NULLS FIRST);
+
+-- NOTE: seeded from the most recent library copy, fetched_at stays empty so the refresher picks every title up
+INSERT INTO titles (tmdb_id, metadata)
+SELECT DISTINCT ON (tmdb_id)
+  tmdb_id,
+  jsonb_build_object('title', title, 'poster_path', COALESCE(poster_path, ''), 'runtime', runtime)
+FROM movies
+ORDER BY tmdb_id, updated_at DESC
+ON CONFLICT (tmdb_id) DO NOTHING;
+
+-- +goose Down
+DROP INDEX titles_fetched_at_idx;
+
+DROP TABLE titles;
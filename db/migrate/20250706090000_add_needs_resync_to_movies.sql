@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE movies
+  ADD COLUMN IF NOT EXISTS needs_resync BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS movies_needs_resync_idx ON movies(tmdb_id) WHERE needs_resync;
+
+-- +goose Down
+DROP INDEX movies_needs_resync_idx;
+
+ALTER TABLE movies
+  DROP COLUMN IF EXISTS needs_resync;
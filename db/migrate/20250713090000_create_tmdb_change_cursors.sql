@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tmdb_change_cursors (
+  feed VARCHAR(16) PRIMARY KEY,
+  synced_until TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE movies
+  ADD COLUMN IF NOT EXISTS missing_on_tmdb BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE movies
+  DROP COLUMN IF EXISTS missing_on_tmdb;
+
+DROP TABLE tmdb_change_cursors;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS movies_title_trgm_idx ON movies USING gin (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS movies_user_id_state_pinned_title_idx ON movies(user_id, state, pinned DESC, lower(title));
+CREATE INDEX IF NOT EXISTS movies_user_id_state_pinned_runtime_idx ON movies(user_id, state, pinned DESC, runtime);
+CREATE INDEX IF NOT EXISTS movies_user_id_state_pinned_updated_idx ON movies(user_id, state, pinned DESC, updated_at DESC);
+
+-- +goose Down
+DROP INDEX movies_user_id_state_pinned_updated_idx;
+DROP INDEX movies_user_id_state_pinned_runtime_idx;
+DROP INDEX movies_user_id_state_pinned_title_idx;
+DROP INDEX movies_title_trgm_idx;
+
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS position BIGINT NOT NULL DEFAULT 0;
+
+-- NOTE: positions are spread 65536 apart so a move lands between two neighbours and touches one row,
+-- the initial manual order follows the default one with the most recently added movie on top
+UPDATE movies m
+SET position = r.rank * 65536
+FROM (
+  SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at DESC, id DESC) AS rank
+  FROM movies
+) r
+WHERE m.id = r.id;
+
+CREATE INDEX IF NOT EXISTS movies_user_id_state_pinned_position_idx ON movies(user_id, state, pinned DESC, position);
+CREATE INDEX IF NOT EXISTS movies_user_id_position_idx ON movies(user_id, position);
+
+-- +goose Down
+DROP INDEX movies_user_id_position_idx;
+DROP INDEX movies_user_id_state_pinned_position_idx;
+
+ALTER TABLE movies DROP COLUMN IF EXISTS position;
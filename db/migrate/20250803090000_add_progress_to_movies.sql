@@ -0,0 +1,6 @@
+-- +goose Up
+-- NOTE: minutes watched, only kept while a movie is in the watching state
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS progress INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE movies DROP COLUMN IF EXISTS progress;
@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tags (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tags_user_id_name_unique ON tags(user_id, lower(name));
+
+CREATE TABLE IF NOT EXISTS movie_tags (
+  movie_id UUID NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+  tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (movie_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS movie_tags_tag_id_idx ON movie_tags(tag_id);
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE movies DROP COLUMN IF EXISTS notes;
+
+DROP INDEX movie_tags_tag_id_idx;
+
+DROP TABLE movie_tags;
+
+DROP INDEX tags_user_id_name_unique;
+
+DROP TABLE tags;
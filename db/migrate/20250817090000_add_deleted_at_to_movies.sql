@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS movies_user_id_deleted_at_idx ON movies(user_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX movies_user_id_deleted_at_idx;
+
+ALTER TABLE movies DROP COLUMN IF EXISTS deleted_at;
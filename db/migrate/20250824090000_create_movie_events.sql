@@ -0,0 +1,34 @@
+-- +goose Up
+DO $$ BEGIN IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'event_actions') THEN CREATE TYPE event_actions AS ENUM ('created', 'updated', 'deleted', 'restored', 'purged'); END IF; END $$;
+
+DO $$ BEGIN IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'event_sources') THEN CREATE TYPE event_sources AS ENUM ('api', 'import', 'sync'); END IF; END $$;
+
+-- NOTE: append-only, events outlive the movie so there is no reference to it. clock_timestamp() keeps
+-- the events of a single transaction in order
+CREATE TABLE IF NOT EXISTS movie_events (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  tmdb_id INTEGER NOT NULL,
+  action event_actions NOT NULL,
+  old_state state_types,
+  new_state state_types,
+  old_pinned BOOLEAN,
+  new_pinned BOOLEAN,
+  source event_sources NOT NULL DEFAULT 'api',
+  created_at TIMESTAMP NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX IF NOT EXISTS movie_events_user_id_created_at_idx ON movie_events(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS movie_events_user_id_tmdb_id_created_at_idx ON movie_events(user_id, tmdb_id, created_at DESC);
+
+-- +goose Down
+DROP INDEX movie_events_user_id_tmdb_id_created_at_idx;
+
+DROP INDEX movie_events_user_id_created_at_idx;
+
+DROP TABLE movie_events;
+
+DO $$ BEGIN IF EXISTS (SELECT 1 FROM pg_type WHERE typname = 'event_sources') THEN DROP TYPE event_sources; END IF; END $$;
+
+DO $$ BEGIN IF EXISTS (SELECT 1 FROM pg_type WHERE typname = 'event_actions') THEN DROP TYPE event_actions; END IF; END $$;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- NOTE: status is NULL while the first request holding the key is in progress, body is the response replayed to retries
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  key VARCHAR(255) NOT NULL,
+  fingerprint VARCHAR(64) NOT NULL,
+  status INTEGER,
+  content_type VARCHAR(255) NOT NULL DEFAULT '',
+  body BYTEA,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  completed_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idempotency_keys_user_id_key_unique ON idempotency_keys(user_id, key);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_created_at_idx ON idempotency_keys(created_at);
+
+-- +goose Down
+DROP INDEX idempotency_keys_created_at_idx;
+
+DROP INDEX idempotency_keys_user_id_key_unique;
+
+DROP TABLE idempotency_keys;
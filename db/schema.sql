@@ -16,6 +16,20 @@ SET xmloption = content;
 SET client_min_messages = warning;
 SET row_security = off;
 
+--
+-- Name: pg_trgm; Type: EXTENSION; Schema: -; Owner: -
+--
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public;
+
+
+--
+-- Name: EXTENSION pg_trgm; Type: COMMENT; Schema: -; Owner: 
+--
+
+COMMENT ON EXTENSION pg_trgm IS 'text similarity measurement and index searching based on trigrams';
+
+
 --
 -- Name: uuid-ossp; Type: EXTENSION; Schema: -; Owner: -
 --
@@ -43,6 +57,46 @@ CREATE TYPE public.appearance_type AS ENUM (
 
 ALTER TYPE public.appearance_type OWNER TO postgres;
 
+--
+-- Name: credit_roles; Type: TYPE; Schema: public; Owner: postgres
+--
+
+CREATE TYPE public.credit_roles AS ENUM (
+    'cast',
+    'director'
+);
+
+
+ALTER TYPE public.credit_roles OWNER TO postgres;
+
+--
+-- Name: event_actions; Type: TYPE; Schema: public; Owner: postgres
+--
+
+CREATE TYPE public.event_actions AS ENUM (
+    'created',
+    'updated',
+    'deleted',
+    'restored',
+    'purged'
+);
+
+
+ALTER TYPE public.event_actions OWNER TO postgres;
+
+--
+-- Name: event_sources; Type: TYPE; Schema: public; Owner: postgres
+--
+
+CREATE TYPE public.event_sources AS ENUM (
+    'api',
+    'import',
+    'sync'
+);
+
+
+ALTER TYPE public.event_sources OWNER TO postgres;
+
 --
 -- Name: state_types; Type: TYPE; Schema: public; Owner: postgres
 --
@@ -61,6 +115,75 @@ SET default_tablespace = '';
 
 SET default_table_access_method = heap;
 
+--
+-- Name: idempotency_keys; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.idempotency_keys (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    user_id uuid NOT NULL,
+    key character varying(255) NOT NULL,
+    fingerprint character varying(64) NOT NULL,
+    status integer,
+    content_type character varying(255) DEFAULT ''::character varying NOT NULL,
+    body bytea,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_at timestamp without time zone
+);
+
+
+ALTER TABLE public.idempotency_keys OWNER TO postgres;
+
+--
+-- Name: movie_credits; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.movie_credits (
+    tmdb_id integer NOT NULL,
+    person_id integer NOT NULL,
+    role public.credit_roles NOT NULL,
+    name character varying(255) NOT NULL,
+    profile_path character varying(255) DEFAULT ''::character varying NOT NULL,
+    "position" integer DEFAULT 0 NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.movie_credits OWNER TO postgres;
+
+--
+-- Name: movie_events; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.movie_events (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    user_id uuid NOT NULL,
+    tmdb_id integer NOT NULL,
+    action public.event_actions NOT NULL,
+    old_state public.state_types,
+    new_state public.state_types,
+    old_pinned boolean,
+    new_pinned boolean,
+    source public.event_sources DEFAULT 'api'::public.event_sources NOT NULL,
+    created_at timestamp without time zone DEFAULT clock_timestamp() NOT NULL
+);
+
+
+ALTER TABLE public.movie_events OWNER TO postgres;
+
+--
+-- Name: movie_tags; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.movie_tags (
+    movie_id uuid NOT NULL,
+    tag_id uuid NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.movie_tags OWNER TO postgres;
+
 --
 -- Name: movies; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -75,12 +198,65 @@ CREATE TABLE public.movies (
     state public.state_types NOT NULL,
     pinned boolean DEFAULT false NOT NULL,
     created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    watch_providers integer[] DEFAULT '{}'::integer[] NOT NULL,
+    watch_region character varying(2) DEFAULT ''::character varying NOT NULL,
+    providers_refreshed_at timestamp without time zone,
+    credits_cached_at timestamp without time zone,
+    needs_resync boolean DEFAULT false NOT NULL,
+    missing_on_tmdb boolean DEFAULT false NOT NULL,
+    position bigint DEFAULT 0 NOT NULL,
+    progress integer DEFAULT 0 NOT NULL,
+    notes text DEFAULT ''::text NOT NULL,
+    deleted_at timestamp without time zone
 );
 
 
 ALTER TABLE public.movies OWNER TO postgres;
 
+--
+-- Name: tags; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.tags (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    user_id uuid NOT NULL,
+    name character varying(64) NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.tags OWNER TO postgres;
+
+--
+-- Name: titles; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.titles (
+    tmdb_id integer NOT NULL,
+    metadata jsonb DEFAULT '{}'::jsonb NOT NULL,
+    fetched_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.titles OWNER TO postgres;
+
+--
+-- Name: tmdb_change_cursors; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.tmdb_change_cursors (
+    feed character varying(16) NOT NULL,
+    synced_until timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.tmdb_change_cursors OWNER TO postgres;
+
 --
 -- Name: users; Type: TABLE; Schema: public; Owner: postgres
 --
@@ -95,12 +271,51 @@ CREATE TABLE public.users (
     appearance public.appearance_type DEFAULT 'system'::public.appearance_type NOT NULL,
     deleted_at timestamp without time zone,
     created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    language character varying(2) DEFAULT ''::character varying NOT NULL,
+    region character varying(2) DEFAULT ''::character varying NOT NULL,
+    watch_providers integer[] DEFAULT '{}'::integer[] NOT NULL,
+    include_documentaries boolean,
+    include_without_artwork boolean,
+    include_adult boolean DEFAULT false NOT NULL,
+    adult_confirmed_at timestamp without time zone
 );
 
 
 ALTER TABLE public.users OWNER TO postgres;
 
+--
+-- Name: idempotency_keys idempotency_keys_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.idempotency_keys
+    ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: movie_credits movie_credits_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.movie_credits
+    ADD CONSTRAINT movie_credits_pkey PRIMARY KEY (tmdb_id, person_id, role);
+
+
+--
+-- Name: movie_events movie_events_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.movie_events
+    ADD CONSTRAINT movie_events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: movie_tags movie_tags_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.movie_tags
+    ADD CONSTRAINT movie_tags_pkey PRIMARY KEY (movie_id, tag_id);
+
+
 --
 -- Name: movies movies_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -109,6 +324,30 @@ ALTER TABLE ONLY public.movies
     ADD CONSTRAINT movies_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: tags tags_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.tags
+    ADD CONSTRAINT tags_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: titles titles_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.titles
+    ADD CONSTRAINT titles_pkey PRIMARY KEY (tmdb_id);
+
+
+--
+-- Name: tmdb_change_cursors tmdb_change_cursors_pkey; Type: CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.tmdb_change_cursors
+    ADD CONSTRAINT tmdb_change_cursors_pkey PRIMARY KEY (feed);
+
+
 --
 -- Name: users users_email_key; Type: CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -133,6 +372,69 @@ ALTER TABLE ONLY public.users
     ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: idempotency_keys_created_at_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX idempotency_keys_created_at_idx ON public.idempotency_keys USING btree (created_at);
+
+
+--
+-- Name: idempotency_keys_user_id_key_unique; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX idempotency_keys_user_id_key_unique ON public.idempotency_keys USING btree (user_id, key);
+
+
+--
+-- Name: movie_credits_person_id_role_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movie_credits_person_id_role_idx ON public.movie_credits USING btree (person_id, role);
+
+
+--
+-- Name: movie_events_user_id_created_at_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movie_events_user_id_created_at_idx ON public.movie_events USING btree (user_id, created_at DESC);
+
+
+--
+-- Name: movie_events_user_id_tmdb_id_created_at_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movie_events_user_id_tmdb_id_created_at_idx ON public.movie_events USING btree (user_id, tmdb_id, created_at DESC);
+
+
+--
+-- Name: movie_tags_tag_id_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movie_tags_tag_id_idx ON public.movie_tags USING btree (tag_id);
+
+
+--
+-- Name: movies_credits_not_cached_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_credits_not_cached_idx ON public.movies USING btree (tmdb_id) WHERE (credits_cached_at IS NULL);
+
+
+--
+-- Name: movies_providers_refreshed_at_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_providers_refreshed_at_idx ON public.movies USING btree (providers_refreshed_at NULLS FIRST);
+
+
+--
+-- Name: movies_title_trgm_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_title_trgm_idx ON public.movies USING gin (title public.gin_trgm_ops);
+
+
 --
 -- Name: movies_tmdb_id_idx; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -140,6 +442,20 @@ ALTER TABLE ONLY public.users
 CREATE INDEX movies_tmdb_id_idx ON public.movies USING btree (tmdb_id);
 
 
+--
+-- Name: movies_user_id_deleted_at_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_user_id_deleted_at_idx ON public.movies USING btree (user_id, deleted_at DESC) WHERE (deleted_at IS NOT NULL);
+
+
+--
+-- Name: movies_user_id_position_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_user_id_position_idx ON public.movies USING btree (user_id, "position");
+
+
 --
 -- Name: movies_user_id_state_idx; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -154,6 +470,41 @@ CREATE INDEX movies_user_id_state_idx ON public.movies USING btree (user_id, sta
 CREATE INDEX movies_user_id_state_pinned_created_idx ON public.movies USING btree (user_id, state, pinned DESC, created_at DESC);
 
 
+--
+-- Name: movies_user_id_state_pinned_position_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_user_id_state_pinned_position_idx ON public.movies USING btree (user_id, state, pinned DESC, "position");
+
+
+--
+-- Name: movies_user_id_state_pinned_runtime_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_user_id_state_pinned_runtime_idx ON public.movies USING btree (user_id, state, pinned DESC, runtime);
+
+
+--
+-- Name: movies_user_id_state_pinned_title_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_user_id_state_pinned_title_idx ON public.movies USING btree (user_id, state, pinned DESC, lower((title)::text));
+
+
+--
+-- Name: movies_user_id_state_pinned_updated_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_user_id_state_pinned_updated_idx ON public.movies USING btree (user_id, state, pinned DESC, updated_at DESC);
+
+
+--
+-- Name: movies_needs_resync_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_needs_resync_idx ON public.movies USING btree (tmdb_id) WHERE needs_resync;
+
+
 --
 -- Name: movies_user_id_tmdb_id_unique; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -161,6 +512,27 @@ CREATE INDEX movies_user_id_state_pinned_created_idx ON public.movies USING btre
 CREATE UNIQUE INDEX movies_user_id_tmdb_id_unique ON public.movies USING btree (user_id, tmdb_id);
 
 
+--
+-- Name: movies_watch_providers_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX movies_watch_providers_idx ON public.movies USING gin (watch_providers);
+
+
+--
+-- Name: tags_user_id_name_unique; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE UNIQUE INDEX tags_user_id_name_unique ON public.tags USING btree (user_id, lower((name)::text));
+
+
+--
+-- Name: titles_fetched_at_idx; Type: INDEX; Schema: public; Owner: postgres
+--
+
+CREATE INDEX titles_fetched_at_idx ON public.titles USING btree (fetched_at NULLS FIRST);
+
+
 --
 -- Name: users_created_at_not_deleted_idx; Type: INDEX; Schema: public; Owner: postgres
 --
@@ -168,6 +540,38 @@ CREATE UNIQUE INDEX movies_user_id_tmdb_id_unique ON public.movies USING btree (
 CREATE INDEX users_created_at_not_deleted_idx ON public.users USING btree (created_at DESC) WHERE (deleted_at IS NULL);
 
 
+--
+-- Name: idempotency_keys idempotency_keys_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.idempotency_keys
+    ADD CONSTRAINT idempotency_keys_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: movie_events movie_events_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.movie_events
+    ADD CONSTRAINT movie_events_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: movie_tags movie_tags_movie_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.movie_tags
+    ADD CONSTRAINT movie_tags_movie_id_fkey FOREIGN KEY (movie_id) REFERENCES public.movies(id) ON DELETE CASCADE;
+
+
+--
+-- Name: movie_tags movie_tags_tag_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.movie_tags
+    ADD CONSTRAINT movie_tags_tag_id_fkey FOREIGN KEY (tag_id) REFERENCES public.tags(id) ON DELETE CASCADE;
+
+
 --
 -- Name: movies movies_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
 --
@@ -176,6 +580,14 @@ ALTER TABLE ONLY public.movies
     ADD CONSTRAINT movies_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
+--
+-- Name: tags tags_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: postgres
+--
+
+ALTER TABLE ONLY public.tags
+    ADD CONSTRAINT tags_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
 --
 -- PostgreSQL database dump complete
 --
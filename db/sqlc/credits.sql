@@ -0,0 +1,47 @@
+-- name: CreateMovieCredits :exec
+INSERT INTO movie_credits (
+  tmdb_id,
+  person_id,
+  role,
+  name,
+  profile_path,
+  position
+)
+SELECT
+  sqlc.arg(tmdb_id)::integer,
+  unnest(sqlc.arg(person_ids)::integer[]),
+  unnest(sqlc.arg(roles)::credit_roles[]),
+  unnest(sqlc.arg(names)::varchar[]),
+  unnest(sqlc.arg(profile_paths)::varchar[]),
+  unnest(sqlc.arg(positions)::integer[])
+ON CONFLICT (tmdb_id, person_id, role) DO NOTHING;
+
+-- name: MarkMovieCreditsCached :exec
+UPDATE movies
+SET credits_cached_at = NOW()
+WHERE tmdb_id = sqlc.arg(tmdb_id) AND credits_cached_at IS NULL;
+
+-- name: IsMovieCreditsCached :one
+SELECT EXISTS (
+  SELECT 1 FROM movies WHERE tmdb_id = sqlc.arg(tmdb_id) AND credits_cached_at IS NOT NULL
+) AS cached;
+
+-- name: FindMoviesWithoutCredits :many
+SELECT DISTINCT tmdb_id
+FROM movies
+WHERE credits_cached_at IS NULL
+ORDER BY tmdb_id
+LIMIT sqlc.arg(batch_size);
+
+-- name: FindTopPeopleByWatched :many
+SELECT
+  mc.person_id,
+  MAX(mc.name)::varchar AS name,
+  MAX(mc.profile_path)::varchar AS profile_path,
+  COUNT(DISTINCT m.tmdb_id) AS watched_count
+FROM movie_credits mc
+JOIN movies m ON m.tmdb_id = mc.tmdb_id
+WHERE m.user_id = sqlc.arg(user_id) AND m.state = 'watched' AND m.deleted_at IS NULL AND mc.role = sqlc.arg(role)
+GROUP BY mc.person_id
+ORDER BY watched_count DESC, name ASC
+LIMIT sqlc.arg(max_results);
@@ -0,0 +1,51 @@
+-- name: CreateMovieEvent :exec
+INSERT INTO movie_events (
+  user_id,
+  tmdb_id,
+  action,
+  old_state,
+  new_state,
+  old_pinned,
+  new_pinned,
+  source
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: FindMovieEvents :many
+SELECT id, user_id, tmdb_id, action, old_state, new_state, old_pinned, new_pinned, source, created_at
+FROM movie_events
+WHERE user_id = sqlc.arg(user_id) AND tmdb_id = sqlc.arg(tmdb_id)
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg(max_results) OFFSET sqlc.arg(skip_results);
+
+-- name: CountMovieEvents :one
+SELECT COUNT(*)
+FROM movie_events
+WHERE user_id = $1 AND tmdb_id = $2;
+
+-- name: FindUserEvents :many
+SELECT
+  e.id,
+  e.user_id,
+  e.tmdb_id,
+  e.action,
+  e.old_state,
+  e.new_state,
+  e.old_pinned,
+  e.new_pinned,
+  e.source,
+  e.created_at,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title, '')::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path
+FROM movie_events e
+LEFT JOIN titles t ON t.tmdb_id = e.tmdb_id
+LEFT JOIN movies m ON m.user_id = e.user_id AND m.tmdb_id = e.tmdb_id
+WHERE e.user_id = sqlc.arg(user_id)
+ORDER BY e.created_at DESC, e.id
+LIMIT sqlc.arg(max_results) OFFSET sqlc.arg(skip_results);
+
+-- name: CountUserEvents :one
+SELECT COUNT(*)
+FROM movie_events
+WHERE user_id = $1;
@@ -0,0 +1,47 @@
+-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  user_id,
+  key,
+  fingerprint
+) VALUES (
+  sqlc.arg(user_id), sqlc.arg(key), sqlc.arg(fingerprint)
+)
+ON CONFLICT (user_id, key) DO UPDATE
+SET
+  fingerprint = EXCLUDED.fingerprint,
+  status = NULL,
+  content_type = '',
+  body = NULL,
+  created_at = CURRENT_TIMESTAMP,
+  completed_at = NULL
+WHERE idempotency_keys.created_at < sqlc.arg(expired_before)
+  OR (idempotency_keys.status IS NULL AND idempotency_keys.created_at < sqlc.arg(abandoned_before))
+RETURNING id;
+
+-- name: FindIdempotencyKey :one
+SELECT
+  id,
+  user_id,
+  key,
+  fingerprint,
+  status,
+  content_type,
+  body,
+  created_at
+FROM idempotency_keys
+WHERE user_id = $1 AND key = $2;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET
+  status = $2,
+  content_type = $3,
+  body = $4,
+  completed_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys WHERE id = $1;
+
+-- name: PurgeIdempotencyKeys :execrows
+DELETE FROM idempotency_keys WHERE created_at < $1;
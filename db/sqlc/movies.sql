@@ -0,0 +1,356 @@
+-- name: CreateMovie :one
+WITH inserted AS (
+  INSERT INTO movies (
+    user_id,
+    tmdb_id,
+    title,
+    poster_path,
+    runtime,
+    state,
+    needs_resync,
+    progress,
+    position
+  ) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8,
+    (SELECT COALESCE(MIN(position), 0) - 65536 FROM movies WHERE user_id = $1)
+  )
+  RETURNING *
+)
+SELECT
+  i.id,
+  i.user_id,
+  i.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), i.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', i.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, i.runtime)::integer AS runtime,
+  i.pinned,
+  i.state,
+  i.created_at,
+  i.updated_at,
+  i.progress
+FROM inserted i
+LEFT JOIN titles t ON t.tmdb_id = i.tmdb_id;
+
+-- name: UpsertMovie :one
+WITH previous AS (
+  SELECT state, pinned, deleted_at
+  FROM movies
+  WHERE user_id = sqlc.arg(user_id) AND tmdb_id = sqlc.arg(tmdb_id)
+  FOR UPDATE
+), upserted AS (
+  INSERT INTO movies (
+    user_id,
+    tmdb_id,
+    title,
+    poster_path,
+    runtime,
+    state,
+    pinned,
+    needs_resync,
+    progress,
+    position
+  ) VALUES (
+    sqlc.arg(user_id),
+    sqlc.arg(tmdb_id),
+    sqlc.arg(title),
+    sqlc.arg(poster_path),
+    sqlc.arg(runtime),
+    sqlc.arg(state),
+    sqlc.arg(pinned),
+    sqlc.arg(needs_resync),
+    sqlc.arg(progress),
+    (SELECT COALESCE(MIN(position), 0) - 65536 FROM movies WHERE user_id = sqlc.arg(user_id))
+  )
+  ON CONFLICT (user_id, tmdb_id) DO UPDATE
+  SET
+    state = EXCLUDED.state,
+    pinned = EXCLUDED.pinned,
+    progress = CASE
+      WHEN sqlc.arg(keep_progress)::boolean AND movies.state = 'watching' AND EXCLUDED.state = 'watching' THEN movies.progress
+      ELSE EXCLUDED.progress
+    END,
+    deleted_at = NULL,
+    updated_at = NOW()
+  WHERE movies.deleted_at IS NOT NULL
+    OR movies.state <> EXCLUDED.state
+    OR movies.pinned <> EXCLUDED.pinned
+    OR movies.progress <> CASE
+      WHEN sqlc.arg(keep_progress)::boolean AND movies.state = 'watching' AND EXCLUDED.state = 'watching' THEN movies.progress
+      ELSE EXCLUDED.progress
+    END
+  RETURNING *, (xmax = 0) AS inserted
+), saved AS (
+  SELECT id, user_id, tmdb_id, title, poster_path, runtime, pinned, state, created_at, updated_at, progress, inserted, true AS changed
+  FROM upserted
+  UNION ALL
+  SELECT id, user_id, tmdb_id, title, poster_path, runtime, pinned, state, created_at, updated_at, progress, false, false
+  FROM movies
+  WHERE user_id = sqlc.arg(user_id) AND tmdb_id = sqlc.arg(tmdb_id) AND NOT EXISTS (SELECT 1 FROM upserted)
+)
+SELECT
+  u.id,
+  u.user_id,
+  u.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), u.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', u.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, u.runtime)::integer AS runtime,
+  u.pinned,
+  u.state,
+  u.created_at,
+  u.updated_at,
+  u.progress,
+  u.inserted::boolean AS inserted,
+  u.changed::boolean AS changed,
+  p.state AS previous_state,
+  p.pinned AS previous_pinned,
+  p.deleted_at AS previous_deleted_at
+FROM saved u
+LEFT JOIN previous p ON TRUE
+LEFT JOIN titles t ON t.tmdb_id = u.tmdb_id;
+
+-- name: UpdateMovie :one
+UPDATE movies
+SET
+  title = $2,
+  poster_path = $3,
+  runtime = $4,
+  updated_at = NOW()
+WHERE id = $1
+RETURNING
+  id,
+  user_id,
+  tmdb_id,
+  title,
+  poster_path,
+  runtime,
+  pinned,
+  state,
+  created_at,
+  updated_at;
+
+-- name: UpdateMovieByTmdbId :one
+WITH updated AS (
+  UPDATE movies
+  SET
+    state = $3,
+    pinned = $4,
+    progress = $5,
+    updated_at = NOW()
+  WHERE movies.tmdb_id = $1 AND movies.user_id = $2 AND movies.deleted_at IS NULL
+  RETURNING *
+)
+SELECT
+  u.id,
+  u.user_id,
+  u.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), u.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', u.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, u.runtime)::integer AS runtime,
+  u.pinned,
+  u.state,
+  u.created_at,
+  u.updated_at,
+  u.progress
+FROM updated u
+LEFT JOIN titles t ON t.tmdb_id = u.tmdb_id;
+
+-- name: UpdateMovieNotes :execrows
+UPDATE movies
+SET
+  notes = $3,
+  updated_at = NOW()
+WHERE tmdb_id = $1 AND user_id = $2 AND deleted_at IS NULL;
+
+-- name: DeleteMovie :exec
+DELETE FROM movies WHERE id = $1;
+
+-- name: DeleteMovieByTmdbId :exec
+DELETE FROM movies WHERE tmdb_id = $1 AND user_id = $2;
+
+-- name: FindMovieById :one
+SELECT
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.id = $1 AND m.deleted_at IS NULL LIMIT 1;
+
+-- name: FindMovieByTmdbId :one
+SELECT
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at,
+  m.progress,
+  m.notes,
+  ARRAY(
+    SELECT tg.name
+    FROM movie_tags mt
+    JOIN tags tg ON tg.id = mt.tag_id
+    WHERE mt.movie_id = m.id
+    ORDER BY lower(tg.name)
+  )::varchar[] AS tags
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.tmdb_id = $1 AND m.user_id = $2 AND m.deleted_at IS NULL LIMIT 1;
+
+-- name: FindMoviesByTmdbIds :many
+SELECT
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.tmdb_id = ANY(sqlc.arg(tmdb_ids)::integer[]) AND m.user_id = sqlc.arg(user_id) AND m.deleted_at IS NULL;
+
+-- name: FindMoviesWithStaleProviders :many
+SELECT
+  m.id,
+  m.tmdb_id,
+  COALESCE(NULLIF(u.region, ''), sqlc.arg(default_region)::varchar)::varchar AS region
+FROM movies m
+JOIN users u ON u.id = m.user_id
+WHERE m.state = 'want' AND m.deleted_at IS NULL AND u.deleted_at IS NULL AND (
+  m.providers_refreshed_at IS NULL
+  OR m.providers_refreshed_at < sqlc.arg(refreshed_before)
+  OR m.watch_region <> COALESCE(NULLIF(u.region, ''), sqlc.arg(default_region)::varchar)
+)
+ORDER BY m.providers_refreshed_at NULLS FIRST
+LIMIT sqlc.arg(batch_size);
+
+-- name: UpdateMoviesWatchProviders :exec
+UPDATE movies
+SET
+  watch_providers = sqlc.arg(watch_providers),
+  watch_region = sqlc.arg(watch_region),
+  providers_refreshed_at = NOW()
+WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: FindMoviePosition :one
+SELECT
+  id,
+  position
+FROM movies
+WHERE tmdb_id = $1 AND user_id = $2 AND deleted_at IS NULL LIMIT 1
+FOR UPDATE;
+
+-- name: FindPreviousMoviePosition :one
+SELECT position
+FROM movies
+WHERE user_id = sqlc.arg(user_id) AND position < sqlc.arg(position) AND id <> sqlc.arg(exclude_id) AND deleted_at IS NULL
+ORDER BY position DESC LIMIT 1
+FOR UPDATE;
+
+-- name: FindNextMoviePosition :one
+SELECT position
+FROM movies
+WHERE user_id = sqlc.arg(user_id) AND position > sqlc.arg(position) AND id <> sqlc.arg(exclude_id) AND deleted_at IS NULL
+ORDER BY position LIMIT 1
+FOR UPDATE;
+
+-- name: UpdateMoviePosition :exec
+UPDATE movies
+SET position = $2
+WHERE id = $1;
+
+-- name: RebalanceMoviePositions :exec
+UPDATE movies m
+SET position = r.rank * 65536
+FROM (
+  SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS rank
+  FROM movies
+  WHERE user_id = $1
+) r
+WHERE m.id = r.id AND m.position <> r.rank * 65536;
+
+-- name: TrashMovieByTmdbId :execrows
+UPDATE movies
+SET deleted_at = NOW()
+WHERE tmdb_id = $1 AND user_id = $2 AND deleted_at IS NULL;
+
+-- name: RestoreMovieByTmdbId :execrows
+UPDATE movies
+SET deleted_at = NULL
+WHERE tmdb_id = $1 AND user_id = $2 AND deleted_at IS NOT NULL;
+
+-- name: FindTrashedMovieByTmdbId :one
+SELECT
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at,
+  m.progress,
+  m.deleted_at
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.tmdb_id = $1 AND m.user_id = $2 AND m.deleted_at IS NOT NULL LIMIT 1;
+
+-- name: FindTrashedMovies :many
+SELECT
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at,
+  m.progress,
+  m.deleted_at
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.user_id = sqlc.arg(user_id) AND m.deleted_at IS NOT NULL
+ORDER BY m.deleted_at DESC, m.id
+LIMIT sqlc.arg(max_results) OFFSET sqlc.arg(skip_results);
+
+-- name: CountTrashedMovies :one
+SELECT COUNT(*)
+FROM movies
+WHERE user_id = $1 AND deleted_at IS NOT NULL;
+
+-- name: EmptyTrash :execrows
+WITH purged AS (
+  DELETE FROM movies WHERE user_id = sqlc.arg(user_id) AND deleted_at IS NOT NULL
+  RETURNING user_id, tmdb_id, state, pinned
+)
+INSERT INTO movie_events (user_id, tmdb_id, action, old_state, old_pinned, source)
+SELECT user_id, tmdb_id, 'purged', state, pinned, sqlc.arg(source)
+FROM purged;
+
+-- name: PurgeTrashedMovies :execrows
+WITH purged AS (
+  DELETE FROM movies WHERE deleted_at < sqlc.arg(deleted_before)
+  RETURNING user_id, tmdb_id, state, pinned
+)
+INSERT INTO movie_events (user_id, tmdb_id, action, old_state, old_pinned, source)
+SELECT user_id, tmdb_id, 'purged', state, pinned, sqlc.arg(source)
+FROM purged;
@@ -0,0 +1,54 @@
+-- name: ListTags :many
+SELECT
+  tg.id,
+  tg.user_id,
+  tg.name,
+  tg.created_at,
+  tg.updated_at,
+  COUNT(m.id) AS movies_count
+FROM tags tg
+LEFT JOIN movie_tags mt ON mt.tag_id = tg.id
+LEFT JOIN movies m ON m.id = mt.movie_id AND m.deleted_at IS NULL
+WHERE tg.user_id = $1
+GROUP BY tg.id
+ORDER BY lower(tg.name);
+
+-- name: FindTagByName :one
+SELECT id, user_id, name, created_at, updated_at
+FROM tags
+WHERE user_id = sqlc.arg(user_id) AND lower(name) = lower(sqlc.arg(name)) LIMIT 1;
+
+-- name: CreateTag :one
+INSERT INTO tags (
+  user_id,
+  name
+) VALUES (
+  $1, $2
+)
+RETURNING id, user_id, name, created_at, updated_at;
+
+-- name: RenameTag :one
+UPDATE tags
+SET
+  name = $3,
+  updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, name, created_at, updated_at;
+
+-- name: DeleteTag :execrows
+DELETE FROM tags WHERE id = $1 AND user_id = $2;
+
+-- name: EnsureTags :exec
+INSERT INTO tags (user_id, name)
+SELECT sqlc.arg(user_id), unnest(sqlc.arg(names)::varchar[])
+ON CONFLICT (user_id, lower(name)) DO NOTHING;
+
+-- name: ClearMovieTags :exec
+DELETE FROM movie_tags WHERE movie_id = $1;
+
+-- name: TagMovie :exec
+INSERT INTO movie_tags (movie_id, tag_id)
+SELECT sqlc.arg(movie_id), tg.id
+FROM tags tg
+WHERE tg.user_id = sqlc.arg(user_id) AND lower(tg.name) = ANY(sqlc.arg(names)::text[])
+ON CONFLICT (movie_id, tag_id) DO NOTHING;
@@ -0,0 +1,120 @@
+-- name: CreateTitle :exec
+INSERT INTO titles (
+  tmdb_id,
+  metadata
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (tmdb_id) DO NOTHING;
+
+-- name: UpsertTitle :exec
+INSERT INTO titles (
+  tmdb_id,
+  metadata,
+  fetched_at
+) VALUES (
+  $1, $2, NOW()
+)
+ON CONFLICT (tmdb_id) DO UPDATE
+SET
+  metadata = EXCLUDED.metadata,
+  fetched_at = EXCLUDED.fetched_at,
+  updated_at = CASE WHEN titles.metadata = EXCLUDED.metadata THEN titles.updated_at ELSE NOW() END;
+
+-- name: TouchTitle :exec
+UPDATE titles
+SET fetched_at = NOW()
+WHERE tmdb_id = $1;
+
+-- name: FindTitleByTmdbId :one
+SELECT
+  tmdb_id,
+  metadata,
+  fetched_at
+FROM titles
+WHERE tmdb_id = $1 LIMIT 1;
+
+-- name: FindStaleTitles :many
+SELECT t.tmdb_id
+FROM titles t
+WHERE EXISTS (SELECT 1 FROM movies m WHERE m.tmdb_id = t.tmdb_id) AND (
+  t.fetched_at IS NULL
+  OR t.fetched_at < sqlc.arg(fetched_before)
+  OR EXISTS (SELECT 1 FROM movies m WHERE m.tmdb_id = t.tmdb_id AND m.needs_resync)
+)
+ORDER BY t.fetched_at NULLS FIRST
+LIMIT sqlc.arg(batch_size);
+
+-- name: SyncMoviesWithTitle :exec
+UPDATE movies
+SET
+  title = sqlc.arg(title),
+  poster_path = sqlc.arg(poster_path),
+  runtime = sqlc.arg(runtime),
+  needs_resync = false,
+  missing_on_tmdb = false
+WHERE tmdb_id = sqlc.arg(tmdb_id) AND (
+  title <> sqlc.arg(title)
+  OR poster_path IS DISTINCT FROM sqlc.arg(poster_path)
+  OR runtime <> sqlc.arg(runtime)
+  OR needs_resync
+  OR missing_on_tmdb
+);
+
+-- name: InvalidateTitles :execrows
+UPDATE titles t
+SET fetched_at = NULL
+WHERE t.tmdb_id = ANY(sqlc.arg(tmdb_ids)::integer[])
+  AND t.fetched_at IS NOT NULL
+  AND EXISTS (SELECT 1 FROM movies m WHERE m.tmdb_id = t.tmdb_id);
+
+-- name: MarkMoviesMissingOnTmdb :exec
+WITH marked AS (
+  UPDATE movies
+  SET missing_on_tmdb = true
+  WHERE tmdb_id = sqlc.arg(tmdb_id) AND NOT missing_on_tmdb
+  RETURNING user_id, tmdb_id, state, pinned
+)
+INSERT INTO movie_events (user_id, tmdb_id, action, old_state, new_state, old_pinned, new_pinned, source)
+SELECT user_id, tmdb_id, 'updated', state, state, pinned, pinned, sqlc.arg(source)
+FROM marked;
+
+-- name: RepointMovies :execrows
+WITH moved AS (
+  UPDATE movies m
+  SET
+    tmdb_id = sqlc.arg(to_tmdb_id),
+    credits_cached_at = NULL,
+    providers_refreshed_at = NULL
+  WHERE m.tmdb_id = sqlc.arg(from_tmdb_id) AND NOT EXISTS (
+    SELECT 1 FROM movies d WHERE d.user_id = m.user_id AND d.tmdb_id = sqlc.arg(to_tmdb_id)
+  )
+  RETURNING m.user_id, m.tmdb_id, m.state, m.pinned
+)
+INSERT INTO movie_events (user_id, tmdb_id, action, old_state, new_state, old_pinned, new_pinned, source)
+SELECT user_id, tmdb_id, 'updated', state, state, pinned, pinned, sqlc.arg(source)
+FROM moved;
+
+-- name: RepointMovieEvents :exec
+UPDATE movie_events e
+SET tmdb_id = sqlc.arg(to_tmdb_id)
+WHERE e.tmdb_id = sqlc.arg(from_tmdb_id) AND NOT EXISTS (
+  SELECT 1 FROM movies d WHERE d.user_id = e.user_id AND d.tmdb_id = sqlc.arg(to_tmdb_id)
+);
+
+-- name: FindChangesCursor :one
+SELECT synced_until
+FROM tmdb_change_cursors
+WHERE feed = $1 LIMIT 1;
+
+-- name: SaveChangesCursor :exec
+INSERT INTO tmdb_change_cursors (
+  feed,
+  synced_until
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (feed) DO UPDATE
+SET
+  synced_until = EXCLUDED.synced_until,
+  updated_at = NOW();
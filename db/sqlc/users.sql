@@ -8,7 +8,7 @@ INSERT INTO users (
   appearance
 ) VALUES (
   $1, $2, $3, $4, $5, $6
-) RETURNING id, login, email, first_name, last_name, appearance;
+) RETURNING id, login, email, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at;
 
 -- name: UpdateUser :one
 UPDATE users
@@ -16,22 +16,29 @@ SET
   first_name = $2,
   last_name = $3,
   appearance = $4,
+  language = $5,
+  region = $6,
+  watch_providers = $7,
+  include_documentaries = $8,
+  include_without_artwork = $9,
+  include_adult = $10,
+  adult_confirmed_at = $11,
   updated_at = NOW()
 WHERE id = $1
-RETURNING id, login, email, first_name, last_name, appearance;
+RETURNING id, login, email, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at;
 
 -- name: FindUserById :one
-SELECT id, login, email, first_name, last_name, appearance
+SELECT id, login, email, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at
 FROM users
 WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 
 -- name: FindUserByLogin :one
-SELECT id, login, email, encrypted_password, first_name, last_name, appearance
+SELECT id, login, email, encrypted_password, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at
 FROM users
 WHERE login = $1 AND deleted_at IS NULL LIMIT 1;
 
 -- name: FindUserByEmail :one
-SELECT id, login, email, encrypted_password, first_name, last_name, appearance
+SELECT id, login, email, encrypted_password, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at
 FROM users
 WHERE email = $1 AND deleted_at IS NULL LIMIT 1;
 
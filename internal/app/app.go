@@ -8,6 +8,7 @@ import (
 	"go.uber.org/fx"
 
 	"biinge-api/internal/app/controllers"
+	"biinge-api/internal/app/jobs"
 	"biinge-api/internal/app/repositories"
 	"biinge-api/internal/app/services"
 	"biinge-api/internal/config"
@@ -25,6 +26,7 @@ var Module = fx.Options(
 	controllers.Module,
 	repositories.Module,
 	services.Module,
+	jobs.Module,
 
 	middlewares.Module,
 	server.Module,
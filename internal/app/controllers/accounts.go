@@ -3,6 +3,7 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"biinge-api/internal/app/errors"
 	"biinge-api/internal/app/models"
@@ -39,14 +40,7 @@ func (c *accountsController) Me(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := serializers.UserSerializer{
-		ID:         user.ID,
-		Login:      user.Login,
-		Email:      user.Email,
-		FirstName:  user.FirstName,
-		LastName:   user.LastName,
-		Appearance: user.Appearance,
-	}
+	response := userSerializer(user)
 
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
@@ -69,11 +63,61 @@ func (c *accountsController) HandleUpdate(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	language := user.Language
+	if params.Language != nil {
+		language = *params.Language
+	}
+
+	region := user.Region
+	if params.Region != nil {
+		region = *params.Region
+	}
+
+	watchProviders := user.WatchProviders
+	if params.WatchProviders != nil {
+		watchProviders = *params.WatchProviders
+	}
+
+	includeDocumentaries := user.IncludeDocumentaries
+	if params.IncludeDocumentaries != nil {
+		includeDocumentaries = params.IncludeDocumentaries
+	}
+
+	includeWithoutArtwork := user.IncludeWithoutArtwork
+	if params.IncludeWithoutArtwork != nil {
+		includeWithoutArtwork = params.IncludeWithoutArtwork
+	}
+
+	// Adult titles are opt-in and need a one-time age confirmation
+	includeAdult := user.IncludeAdult
+	adultConfirmedAt := user.AdultConfirmedAt
+	if params.IncludeAdult != nil {
+		includeAdult = *params.IncludeAdult
+		if includeAdult && adultConfirmedAt == nil {
+			if !params.ConfirmAdultAge {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrAdultAgeNotConfirmed.Error()})
+				return
+			}
+
+			now := time.Now().UTC()
+			adultConfirmedAt = &now
+		}
+	}
+
 	result, err := c.users.Update(r.Context(), &models.User{
-		ID:         user.ID,
-		FirstName:  params.FirstName,
-		LastName:   params.LastName,
-		Appearance: params.Appearance,
+		ID:             user.ID,
+		FirstName:      params.FirstName,
+		LastName:       params.LastName,
+		Appearance:     params.Appearance,
+		Language:       language,
+		Region:         region,
+		WatchProviders: watchProviders,
+
+		IncludeDocumentaries:  includeDocumentaries,
+		IncludeWithoutArtwork: includeWithoutArtwork,
+		IncludeAdult:          includeAdult,
+		AdultConfirmedAt:      adultConfirmedAt,
 	})
 	if err != nil {
 		c.log.Error().Err(err).Msg("Update failed")
@@ -82,15 +126,27 @@ func (c *accountsController) HandleUpdate(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	response := serializers.UserSerializer{
-		ID:         result.ID,
-		Login:      result.Login,
-		Email:      result.Email,
-		FirstName:  result.FirstName,
-		LastName:   result.LastName,
-		Appearance: result.Appearance,
-	}
+	response := userSerializer(result)
 
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
+
+func userSerializer(user *models.User) serializers.UserSerializer {
+	return serializers.UserSerializer{
+		ID:             user.ID,
+		Login:          user.Login,
+		Email:          user.Email,
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		Appearance:     user.Appearance,
+		Language:       user.Language,
+		Region:         user.Region,
+		WatchProviders: user.WatchProviders,
+
+		IncludeDocumentaries:  user.IncludeDocumentaries,
+		IncludeWithoutArtwork: user.IncludeWithoutArtwork,
+		IncludeAdult:          user.IncludeAdult,
+		AdultConfirmed:        user.AdultConfirmedAt != nil,
+	}
+}
@@ -136,6 +136,8 @@ func Test_AccountsController_Update(t *testing.T) {
 	id, err := uuid.NewRandom()
 	assert.NoError(t, err)
 
+	includeDocumentaries := true
+
 	type result struct {
 		response serializers.UserSerializer
 		error    serializers.ErrorSerializer
@@ -185,6 +187,162 @@ func Test_AccountsController_Update(t *testing.T) {
 				code:   http.StatusOK,
 			},
 		},
+		{
+			name: "Updates watch providers",
+			before: func() {
+				users.EXPECT().Update(gomock.Any(), &models.User{
+					ID:             id,
+					FirstName:      "John",
+					LastName:       "Doe",
+					Appearance:     "dark",
+					WatchProviders: []int32{8, 337},
+				}).Return(&models.User{
+					ID:             id,
+					Login:          "john.doe",
+					Email:          "john.doe@local",
+					FirstName:      "John",
+					LastName:       "Doe",
+					Appearance:     "dark",
+					WatchProviders: []int32{8, 337},
+				}, nil)
+			},
+			currentUser: &models.User{
+				ID:             id,
+				Login:          "john.doe",
+				Email:          "john.doe@local",
+				FirstName:      "John",
+				LastName:       "Doe",
+				Appearance:     "dark",
+				WatchProviders: []int32{119},
+			},
+			body: strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "watch_providers": [8, 337, 8] }`),
+			expected: result{
+				response: serializers.UserSerializer{
+					ID:             id,
+					Login:          "john.doe",
+					Email:          "john.doe@local",
+					FirstName:      "John",
+					LastName:       "Doe",
+					Appearance:     "dark",
+					WatchProviders: []int32{8, 337},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "Keeps locale preferences",
+			before: func() {
+				users.EXPECT().Update(gomock.Any(), &models.User{
+					ID:         id,
+					FirstName:  "Jane",
+					LastName:   "Doe",
+					Appearance: "light",
+					Language:   "et",
+					Region:     "US",
+				}).Return(&models.User{
+					ID:         id,
+					Login:      "john.doe",
+					Email:      "john.doe@local",
+					FirstName:  "Jane",
+					LastName:   "Doe",
+					Appearance: "light",
+					Language:   "et",
+					Region:     "US",
+				}, nil)
+			},
+			currentUser: &models.User{
+				ID:         id,
+				Login:      "john.doe",
+				Email:      "john.doe@local",
+				FirstName:  "John",
+				LastName:   "Doe",
+				Appearance: "dark",
+				Language:   "et",
+				Region:     "EE",
+			},
+			body: strings.NewReader(`{ "first_name": "Jane", "last_name": "Doe", "appearance": "light", "region": "us" }`),
+			expected: result{
+				response: serializers.UserSerializer{
+					ID:         id,
+					Login:      "john.doe",
+					Email:      "john.doe@local",
+					FirstName:  "Jane",
+					LastName:   "Doe",
+					Appearance: "light",
+					Language:   "et",
+					Region:     "US",
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "Updates content filter",
+			before: func() {
+				users.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, params *models.User) (*models.User, error) {
+					assert.Equal(t, true, *params.IncludeDocumentaries)
+					assert.Nil(t, params.IncludeWithoutArtwork)
+					assert.True(t, params.IncludeAdult)
+					assert.NotNil(t, params.AdultConfirmedAt)
+
+					return &models.User{
+						ID:                   id,
+						Login:                "john.doe",
+						Email:                "john.doe@local",
+						FirstName:            "John",
+						LastName:             "Doe",
+						Appearance:           "dark",
+						IncludeDocumentaries: params.IncludeDocumentaries,
+						IncludeAdult:         params.IncludeAdult,
+						AdultConfirmedAt:     params.AdultConfirmedAt,
+					}, nil
+				})
+			},
+			currentUser: &models.User{
+				ID:         id,
+				Login:      "john.doe",
+				Email:      "john.doe@local",
+				FirstName:  "John",
+				LastName:   "Doe",
+				Appearance: "dark",
+			},
+			body: strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "include_documentaries": true, "include_adult": true, "confirm_adult_age": true }`),
+			expected: result{
+				response: serializers.UserSerializer{
+					ID:                   id,
+					Login:                "john.doe",
+					Email:                "john.doe@local",
+					FirstName:            "John",
+					LastName:             "Doe",
+					Appearance:           "dark",
+					IncludeDocumentaries: &includeDocumentaries,
+					IncludeAdult:         true,
+					AdultConfirmed:       true,
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name:   "Validation Error - Adult Age Not Confirmed",
+			before: func() {},
+			currentUser: &models.User{
+				ID:         id,
+				Login:      "john.doe",
+				Email:      "john.doe@local",
+				FirstName:  "John",
+				LastName:   "Doe",
+				Appearance: "dark",
+			},
+			body: strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "include_adult": true }`),
+			expected: result{
+				error:  serializers.ErrorSerializer{Error: "adult content requires age confirmation"},
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
 		{
 			name:        "Unauthorized - No User Context",
 			before:      func() {},
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config/logger"
+	"biinge-api/internal/config/middlewares"
+)
+
+type CollectionsController interface {
+	HandleDetails(w http.ResponseWriter, r *http.Request)
+}
+
+type collectionsController struct {
+	provider services.TmdbProvider
+	log      *logger.Logger
+}
+
+func NewCollectionsController(provider services.TmdbProvider, log *logger.Logger) CollectionsController {
+	return &collectionsController{
+		provider: provider,
+		log:      log.WithComponent("CollectionsController"),
+	}
+}
+
+//nolint:dupl
+func (t *collectionsController) HandleDetails(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tmdb id"})
+		return
+	}
+
+	response, err := t.provider.FetchCollectionDetails(r.Context(), id, user)
+	if err != nil {
+		if renderUpstreamUnavailable(w, err) {
+			return
+		}
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/controllers/collections.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/controllers/collections.go -destination=internal/app/controllers/collections_mock.go -package=controllers
+//
+
+// Package controllers is a generated GoMock package.
+package controllers
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollectionsController is a mock of CollectionsController interface.
+type MockCollectionsController struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionsControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockCollectionsControllerMockRecorder is the mock recorder for MockCollectionsController.
+type MockCollectionsControllerMockRecorder struct {
+	mock *MockCollectionsController
+}
+
+// NewMockCollectionsController creates a new mock instance.
+func NewMockCollectionsController(ctrl *gomock.Controller) *MockCollectionsController {
+	mock := &MockCollectionsController{ctrl: ctrl}
+	mock.recorder = &MockCollectionsControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionsController) EXPECT() *MockCollectionsControllerMockRecorder {
+	return m.recorder
+}
+
+// HandleDetails mocks base method.
+func (m *MockCollectionsController) HandleDetails(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleDetails", w, r)
+}
+
+// HandleDetails indicates an expected call of HandleDetails.
+func (mr *MockCollectionsControllerMockRecorder) HandleDetails(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDetails", reflect.TypeOf((*MockCollectionsController)(nil).HandleDetails), w, r)
+}
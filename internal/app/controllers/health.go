@@ -40,5 +40,8 @@ func (h *healthController) HandleReadiness(w http.ResponseWriter, r *http.Reques
 	}
 
 	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(serializers.HealthSerializer{Result: "ready"})
+	_ = json.NewEncoder(w).Encode(serializers.HealthSerializer{
+		Result:    "ready",
+		Upstreams: h.service.Upstreams(),
+	})
 }
@@ -83,11 +83,15 @@ func Test_HealthController_HandleReadiness(t *testing.T) {
 			name: "Success",
 			before: func() {
 				service.EXPECT().Ping(gomock.Any()).Return(nil)
+				service.EXPECT().Upstreams().Return(map[string]string{"tmdb": "closed"})
 			},
 			expected: result{
-				response: serializers.HealthSerializer{Result: "ready"},
-				code:     http.StatusOK,
-				status:   "200 OK",
+				response: serializers.HealthSerializer{
+					Result:    "ready",
+					Upstreams: map[string]string{"tmdb": "closed"},
+				},
+				code:   http.StatusOK,
+				status: "200 OK",
 			},
 		},
 		{
@@ -125,6 +129,7 @@ func Test_HealthController_HandleReadiness(t *testing.T) {
 				err := json.NewDecoder(resp.Body).Decode(&actual)
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expected.response.Result, actual.Result)
+				assert.Equal(t, tt.expected.response.Upstreams, actual.Upstreams)
 			}
 
 			assert.Equal(t, tt.expected.status, resp.Status)
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config/logger"
+	"biinge-api/internal/config/middlewares"
+	"biinge-api/pkg/tmdb"
+)
+
+type HistoryController interface {
+	HandleList(w http.ResponseWriter, r *http.Request)
+	HandleMovie(w http.ResponseWriter, r *http.Request)
+}
+
+type historyController struct {
+	history services.History
+	images  tmdb.Images
+	log     *logger.Logger
+}
+
+func NewHistoryController(history services.History, images tmdb.Images, log *logger.Logger) HistoryController {
+	return &historyController{
+		history: history,
+		images:  images,
+		log:     log.WithComponent("HistoryController"),
+	}
+}
+
+// HandleList lists the activity of the user across the library, the most recent events first
+func (c *historyController) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	pagination := services.NewPagination(r)
+
+	rows, info, err := c.history.List(r.Context(), user.ID, pagination)
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	c.render(w, r, rows, info, pagination)
+}
+
+// HandleMovie lists the history of a movie, the most recent events first
+func (c *historyController) HandleMovie(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tmdb id"})
+		return
+	}
+
+	pagination := services.NewPagination(r)
+
+	rows, info, err := c.history.ListByTmdbId(r.Context(), id, user.ID, pagination)
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	c.render(w, r, rows, info, pagination)
+}
+
+func (c *historyController) render(w http.ResponseWriter, r *http.Request, rows []models.MovieEvent, info *services.PageInfo, pagination *services.Pagination) {
+	collection := make([]serializers.MovieEventSerializer, 0, len(rows))
+	for _, row := range rows {
+		collection = append(collection, serializers.MovieEventSerializer{
+			Id:         row.ID,
+			TmdbId:     row.TmdbId,
+			Title:      row.Title,
+			PosterPath: row.PosterPath,
+			Images:     serializers.NewImagesSerializer(c.images.Variants(r.Context(), tmdb.ImageKindPoster, row.PosterPath)),
+			Action:     row.Action,
+			OldState:   row.OldState,
+			NewState:   row.NewState,
+			OldPinned:  row.OldPinned,
+			NewPinned:  row.NewPinned,
+			Source:     row.Source,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+
+	response := serializers.PaginationResponse[serializers.MovieEventSerializer]{
+		Data: collection,
+		Meta: serializers.PaginationMeta{
+			Page:  pagination.Page,
+			Per:   pagination.PerPage,
+			Total: info.Total,
+		},
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=history.go -destination=history_mock.go -package=controllers
+//
+
+// Package controllers is a generated GoMock package.
+package controllers
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryController is a mock of HistoryController interface.
+type MockHistoryController struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryControllerMockRecorder is the mock recorder for MockHistoryController.
+type MockHistoryControllerMockRecorder struct {
+	mock *MockHistoryController
+}
+
+// NewMockHistoryController creates a new mock instance.
+func NewMockHistoryController(ctrl *gomock.Controller) *MockHistoryController {
+	mock := &MockHistoryController{ctrl: ctrl}
+	mock.recorder = &MockHistoryControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryController) EXPECT() *MockHistoryControllerMockRecorder {
+	return m.recorder
+}
+
+// HandleList mocks base method.
+func (m *MockHistoryController) HandleList(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleList", w, r)
+}
+
+// HandleList indicates an expected call of HandleList.
+func (mr *MockHistoryControllerMockRecorder) HandleList(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleList", reflect.TypeOf((*MockHistoryController)(nil).HandleList), w, r)
+}
+
+// HandleMovie mocks base method.
+func (m *MockHistoryController) HandleMovie(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleMovie", w, r)
+}
+
+// HandleMovie indicates an expected call of HandleMovie.
+func (mr *MockHistoryControllerMockRecorder) HandleMovie(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleMovie", reflect.TypeOf((*MockHistoryController)(nil).HandleMovie), w, r)
+}
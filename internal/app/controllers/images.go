@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+const (
+	// ImageCacheControl lets browsers keep images for a year, TMDB never changes the file behind a path
+	ImageCacheControl = "public, max-age=31536000, immutable"
+	// ImageContentSecurityPolicy keeps scripts of an SVG image from running when it is opened directly
+	ImageContentSecurityPolicy = "default-src 'none'"
+)
+
+type ImagesController interface {
+	HandleShow(w http.ResponseWriter, r *http.Request)
+}
+
+type imagesController struct {
+	proxy tmdb.ImageProxy
+	log   *logger.Logger
+}
+
+func NewImagesController(proxy tmdb.ImageProxy, log *logger.Logger) ImagesController {
+	return &imagesController{
+		proxy: proxy,
+		log:   log.WithComponent("ImagesController"),
+	}
+}
+
+func (c *imagesController) HandleShow(w http.ResponseWriter, r *http.Request) {
+	size := chi.URLParam(r, "size")
+	path := "/" + chi.URLParam(r, "path")
+
+	image, err := c.proxy.Fetch(r.Context(), size, path)
+	if errors.Is(err, context.Canceled) {
+		// NOTE: the client went away, the download goes on for the cache
+		return
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, tmdb.ErrInvalidImage):
+			status = http.StatusBadRequest
+		case errors.Is(err, tmdb.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, tmdb.ErrUpstreamUnavailable), errors.Is(err, tmdb.ErrUnexpectedResponse):
+			status = http.StatusBadGateway
+		default:
+			c.log.Error().Err(err).Str("size", size).Str("path", path).Msg("Failed to serve image")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", image.ContentType)
+	w.Header().Set("Cache-Control", ImageCacheControl)
+	w.Header().Set("ETag", image.ETag)
+	w.Header().Set("Content-Security-Policy", ImageContentSecurityPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	// NOTE: ServeContent answers If-None-Match with 304 using the ETag header
+	http.ServeContent(w, r, "", image.ModTime, bytes.NewReader(image.Data))
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/controllers/images.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/controllers/images.go -destination=internal/app/controllers/images_mock.go -package=controllers
+//
+
+// Package controllers is a generated GoMock package.
+package controllers
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImagesController is a mock of ImagesController interface.
+type MockImagesController struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagesControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockImagesControllerMockRecorder is the mock recorder for MockImagesController.
+type MockImagesControllerMockRecorder struct {
+	mock *MockImagesController
+}
+
+// NewMockImagesController creates a new mock instance.
+func NewMockImagesController(ctrl *gomock.Controller) *MockImagesController {
+	mock := &MockImagesController{ctrl: ctrl}
+	mock.recorder = &MockImagesControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImagesController) EXPECT() *MockImagesControllerMockRecorder {
+	return m.recorder
+}
+
+// HandleShow mocks base method.
+func (m *MockImagesController) HandleShow(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleShow", w, r)
+}
+
+// HandleShow indicates an expected call of HandleShow.
+func (mr *MockImagesControllerMockRecorder) HandleShow(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleShow", reflect.TypeOf((*MockImagesController)(nil).HandleShow), w, r)
+}
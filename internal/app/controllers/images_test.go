@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_ImagesController_HandleShow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+
+	proxy := tmdb.NewMockImageProxy(ctrl)
+	log := logger.NewLogger(cfg)
+	controller := NewImagesController(proxy, log)
+
+	image := &tmdb.CachedImage{
+		Data:        []byte("image"),
+		ContentType: "image/jpeg",
+		ETag:        `"abc"`,
+		ModTime:     time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name        string
+		before      func()
+		target      string
+		ifNoneMatch string
+		code        int
+		body        string
+	}{
+		{
+			name: "Success",
+			before: func() {
+				proxy.EXPECT().Fetch(gomock.Any(), "w500", "/poster.jpg").Return(image, nil)
+			},
+			target: "/images/w500/poster.jpg",
+			code:   http.StatusOK,
+			body:   "image",
+		},
+		{
+			name: "Not modified",
+			before: func() {
+				proxy.EXPECT().Fetch(gomock.Any(), "w500", "/poster.jpg").Return(image, nil)
+			},
+			target:      "/images/w500/poster.jpg",
+			ifNoneMatch: `"abc"`,
+			code:        http.StatusNotModified,
+		},
+		{
+			name: "Invalid image",
+			before: func() {
+				proxy.EXPECT().Fetch(gomock.Any(), "huge", "/poster.jpg").Return(nil, tmdb.ErrInvalidImage)
+			},
+			target: "/images/huge/poster.jpg",
+			code:   http.StatusBadRequest,
+			body:   `{"error":"invalid image size or path"}` + "\n",
+		},
+		{
+			name: "Not found",
+			before: func() {
+				proxy.EXPECT().Fetch(gomock.Any(), "w500", "/missing.jpg").Return(nil, tmdb.ErrNotFound)
+			},
+			target: "/images/w500/missing.jpg",
+			code:   http.StatusNotFound,
+			body:   `{"error":"not found"}` + "\n",
+		},
+		{
+			name: "Upstream unavailable",
+			before: func() {
+				proxy.EXPECT().Fetch(gomock.Any(), "w500", "/poster.jpg").Return(nil, tmdb.ErrUpstreamUnavailable)
+			},
+			target: "/images/w500/poster.jpg",
+			code:   http.StatusBadGateway,
+			body:   `{"error":"TMDB API is temporarily unavailable"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/images/{size}/{path}", controller.HandleShow)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, tt.body, w.Body.String())
+
+			if tt.code == http.StatusOK || tt.code == http.StatusNotModified {
+				assert.Equal(t, `"abc"`, resp.Header.Get("ETag"))
+				assert.Equal(t, ImageCacheControl, resp.Header.Get("Cache-Control"))
+				assert.Equal(t, ImageContentSecurityPolicy, resp.Header.Get("Content-Security-Policy"))
+				assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+			}
+		})
+	}
+}
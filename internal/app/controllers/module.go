@@ -5,7 +5,14 @@ import "go.uber.org/fx"
 var Module = fx.Options(
 	fx.Provide(NewAuthenticationController),
 	fx.Provide(NewHealthController),
+	fx.Provide(NewHistoryController),
 	fx.Provide(NewAccountsController),
 	fx.Provide(NewMoviesController),
+	fx.Provide(NewTagsController),
+	fx.Provide(NewTrashController),
 	fx.Provide(NewPeopleController),
+	fx.Provide(NewWatchProvidersController),
+	fx.Provide(NewCollectionsController),
+	fx.Provide(NewStatsController),
+	fx.Provide(NewImagesController),
 )
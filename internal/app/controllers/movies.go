@@ -3,7 +3,11 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
 
@@ -13,26 +17,42 @@ import (
 	"biinge-api/internal/app/services"
 	"biinge-api/internal/config/logger"
 	"biinge-api/internal/config/middlewares"
+	"biinge-api/pkg/tmdb"
 )
 
+// MaxMovieQueryLength caps the title search, longer queries are cut rather than rejected
+const MaxMovieQueryLength = 100
+
 type MoviesController interface {
 	HandleList(w http.ResponseWriter, r *http.Request)
+	HandleContinueWatching(w http.ResponseWriter, r *http.Request)
 	HandleDetails(w http.ResponseWriter, r *http.Request)
 	HandleCreate(w http.ResponseWriter, r *http.Request)
 	HandleUpdate(w http.ResponseWriter, r *http.Request)
+	HandleUpsert(w http.ResponseWriter, r *http.Request)
 	HandleDelete(w http.ResponseWriter, r *http.Request)
+	HandleMove(w http.ResponseWriter, r *http.Request)
+	HandleNotes(w http.ResponseWriter, r *http.Request)
+	HandleBulk(w http.ResponseWriter, r *http.Request)
 }
 
 type moviesController struct {
 	movies   services.Movies
 	provider services.TmdbProvider
+	images   tmdb.Images
 	log      *logger.Logger
 }
 
-func NewMoviesController(movies services.Movies, provider services.TmdbProvider, log *logger.Logger) MoviesController {
+func NewMoviesController(
+	movies services.Movies,
+	provider services.TmdbProvider,
+	images tmdb.Images,
+	log *logger.Logger,
+) MoviesController {
 	return &moviesController{
 		movies:   movies,
 		provider: provider,
+		images:   images,
 		log:      log.WithComponent("MoviesController"),
 	}
 }
@@ -47,15 +67,45 @@ func (c *moviesController) HandleList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	listType := models.StateTypeWant
-	if r.URL.Query().Get("type") == models.StateTypeWatched {
-		listType = models.StateTypeWatched
+	filter, err := parseMovieFilter(r, user)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	c.renderList(w, r, user, filter)
+}
+
+// HandleContinueWatching lists the movies in progress, the most recently watched first
+func (c *moviesController) HandleContinueWatching(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
 	}
 
+	c.renderList(w, r, user, &models.MovieFilter{
+		State:     models.StateTypeWatching,
+		Sort:      models.MovieSortUpdated,
+		Direction: models.SortDesc,
+	})
+}
+
+func (c *moviesController) renderList(w http.ResponseWriter, r *http.Request, user *models.User, filter *models.MovieFilter) {
 	pagination := services.NewPagination(r)
 
-	rows, total, err := c.movies.List(r.Context(), user.ID, listType, pagination)
+	rows, info, err := c.movies.List(r.Context(), user.ID, filter, pagination)
 	if err != nil {
+		if errors.Is(err, errors.ErrInvalidCursor) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+			return
+		}
+
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
 		return
@@ -64,20 +114,25 @@ func (c *moviesController) HandleList(w http.ResponseWriter, r *http.Request) {
 	collection := make([]serializers.MovieSerializer, 0, len(rows))
 	for _, row := range rows {
 		collection = append(collection, serializers.MovieSerializer{
-			Id:         row.TmdbId,
-			Title:      row.Title,
-			PosterPath: row.PosterPath,
-			Pinned:     row.Pinned,
-			State:      row.State,
+			Id:            row.TmdbId,
+			Title:         row.Title,
+			PosterPath:    row.PosterPath,
+			Images:        serializers.NewImagesSerializer(c.images.Variants(r.Context(), tmdb.ImageKindPoster, row.PosterPath)),
+			Runtime:       row.Runtime,
+			Pinned:        row.Pinned,
+			State:         row.State,
+			Progress:      row.Progress,
+			MissingOnTmdb: row.MissingOnTmdb,
 		})
 	}
 
 	response := serializers.PaginationResponse[serializers.MovieSerializer]{
 		Data: collection,
 		Meta: serializers.PaginationMeta{
-			Page:  pagination.Page,
-			Per:   pagination.PerPage,
-			Total: total,
+			Page:       pagination.Page,
+			Per:        pagination.PerPage,
+			Total:      info.Total,
+			NextCursor: info.NextCursor,
 		},
 	}
 
@@ -103,8 +158,12 @@ func (c *moviesController) HandleDetails(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response, err := c.provider.FetchMovieDetails(r.Context(), id, user.ID)
+	response, err := c.provider.FetchMovieDetails(r.Context(), id, user)
 	if err != nil {
+		if renderUpstreamUnavailable(w, err) {
+			return
+		}
+
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
 		return
@@ -138,17 +197,50 @@ func (c *moviesController) HandleCreate(w http.ResponseWriter, r *http.Request)
 		PosterPath: params.PosterPath,
 		Runtime:    params.Runtime,
 		State:      params.State,
+		Trashed:    params.Trashed,
+
+		ReportedProgress: params.WatchProgress(),
+		IncludeAdult:     user.AdultConfirmed(),
 	})
 	if err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		if renderUpstreamUnavailable(w, err) {
+			return
+		}
+
+		var trashed *services.TrashedMovieError
+		if errors.As(err, &trashed) {
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(serializers.MovieInTrashSerializer{
+				Error: err.Error(),
+				Movie: c.trashedMovie(r, trashed.Movie),
+			})
+			return
+		}
+
+		status := http.StatusUnprocessableEntity
+		switch {
+		case errors.Is(err, errors.ErrMovieNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, errors.ErrInvalidProgress):
+			status = http.StatusBadRequest
+		case errors.Is(err, errors.ErrMovieAlreadyExists):
+			status = http.StatusConflict
+		}
+
+		w.WriteHeader(status)
 		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
 		return
 	}
 
 	response := serializers.MovieDetailsSerializer{
-		Id:     row.TmdbId,
-		Pinned: row.Pinned,
-		State:  row.State,
+		Id:         row.TmdbId,
+		Title:      row.Title,
+		PosterPath: row.PosterPath,
+		Images:     serializers.NewImagesSerializer(c.images.Variants(r.Context(), tmdb.ImageKindPoster, row.PosterPath)),
+		Runtime:    int(row.Runtime),
+		Pinned:     row.Pinned,
+		State:      row.State,
+		Progress:   row.Progress,
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -184,23 +276,110 @@ func (c *moviesController) HandleUpdate(w http.ResponseWriter, r *http.Request)
 		UserId: user.ID,
 		State:  params.State,
 		Pinned: params.Pinned,
+
+		ReportedProgress: params.WatchProgress(),
 	})
 	if err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		switch {
+		case errors.Is(err, errors.ErrInvalidProgress):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, errors.ErrMovieNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
 		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
 		return
 	}
 
 	response := serializers.MovieDetailsSerializer{
-		Id:     row.TmdbId,
-		Pinned: row.Pinned,
-		State:  row.State,
+		Id:       row.TmdbId,
+		Pinned:   row.Pinned,
+		State:    row.State,
+		Progress: row.Progress,
 	}
 
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
 
+// HandleUpsert adds a title to the library or sets the one the user holds, restoring it from the trash,
+// so a client retrying on a flaky connection ends up with the same library. Adding it responds with 201
+func (c *moviesController) HandleUpsert(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tmdb id"})
+		return
+	}
+
+	var params serializers.UpsertMovieRequestSerializer
+	if err = params.Validate(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	upsert, err := c.movies.Upsert(r.Context(), &models.Movie{
+		UserId:     user.ID,
+		TmdbId:     id,
+		Title:      params.Title,
+		PosterPath: params.PosterPath,
+		Runtime:    params.Runtime,
+		State:      params.State,
+		Pinned:     params.Pinned,
+
+		ReportedProgress: params.WatchProgress(),
+		IncludeAdult:     user.AdultConfirmed(),
+	})
+	if err != nil {
+		if renderUpstreamUnavailable(w, err) {
+			return
+		}
+
+		status := http.StatusUnprocessableEntity
+		switch {
+		case errors.Is(err, errors.ErrMovieNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, errors.ErrInvalidProgress):
+			status = http.StatusBadRequest
+		}
+
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	row := upsert.Movie
+	status := http.StatusOK
+	if upsert.Inserted {
+		status = http.StatusCreated
+	}
+
+	response := serializers.MovieDetailsSerializer{
+		Id:         row.TmdbId,
+		Title:      row.Title,
+		PosterPath: row.PosterPath,
+		Images:     serializers.NewImagesSerializer(c.images.Variants(r.Context(), tmdb.ImageKindPoster, row.PosterPath)),
+		Runtime:    int(row.Runtime),
+		Pinned:     row.Pinned,
+		State:      row.State,
+		Progress:   row.Progress,
+	}
+
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
 func (c *moviesController) HandleDelete(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -220,10 +399,315 @@ func (c *moviesController) HandleDelete(w http.ResponseWriter, r *http.Request)
 
 	err = c.movies.DeleteByTmdbId(r.Context(), id, user.ID)
 	if err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
+		if errors.Is(err, errors.ErrMovieNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
 		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (c *moviesController) trashedMovie(r *http.Request, row *models.Movie) serializers.TrashedMovieSerializer {
+	return newTrashedMovieSerializer(row, c.images.Variants(r.Context(), tmdb.ImageKindPoster, row.PosterPath))
+}
+
+func (c *moviesController) HandleMove(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tmdb id"})
+		return
+	}
+
+	var params serializers.MoveMovieRequestSerializer
+	if err = params.Validate(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	anchor, after := params.Before, false
+	if params.After != 0 {
+		anchor, after = params.After, true
+	}
+
+	row, err := c.movies.Move(r.Context(), user.ID, id, anchor, after)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalidMove):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, errors.ErrMovieNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	response := serializers.MovieDetailsSerializer{
+		Id:       row.TmdbId,
+		Pinned:   row.Pinned,
+		State:    row.State,
+		Progress: row.Progress,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleNotes replaces the private notes of a movie of the library
+func (c *moviesController) HandleNotes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tmdb id"})
+		return
+	}
+
+	var params serializers.MovieNotesRequestSerializer
+	if err = params.Validate(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	row, err := c.movies.UpdateNotes(r.Context(), id, user.ID, params.Notes)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalidNotes):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, errors.ErrMovieNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	response := serializers.MovieDetailsSerializer{
+		Id:       row.TmdbId,
+		Pinned:   row.Pinned,
+		State:    row.State,
+		Progress: row.Progress,
+		Tags:     row.Tags,
+		Notes:    row.Notes,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleBulk applies a batch of operations to the library and reports the outcome of each. An atomic batch
+// is answered with 422 and the results once an operation fails, none of them being kept, or with 503 when
+// TMDB is unavailable to resolve the titles to add
+func (c *moviesController) HandleBulk(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	var params serializers.BulkMoviesRequestSerializer
+	if err := params.Validate(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	results, err := c.movies.Bulk(r.Context(), user, params.BulkOperations(), params.IsAtomic())
+	if renderUpstreamUnavailable(w, err) {
+		return
+	}
+	if err != nil && !errors.Is(err, errors.ErrBulkAborted) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	response := serializers.BulkMoviesResponseSerializer{
+		Atomic:  params.IsAtomic(),
+		Results: make([]serializers.BulkResultSerializer, 0, len(results)),
+	}
+	for _, result := range results {
+		item := serializers.BulkResultSerializer{Id: result.TmdbId, Op: result.Op, Status: result.Status}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+		}
+		response.Results = append(response.Results, item)
+	}
+
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// parseMovieFilter reads the list filters and ordering from the query string, title and manual lists
+// default to ascending order while every other sort shows the most recent or longest first
+func parseMovieFilter(r *http.Request, user *models.User) (*models.MovieFilter, error) {
+	query := r.URL.Query()
+
+	filter := &models.MovieFilter{State: models.StateTypeWant}
+	switch listType := query.Get("type"); listType {
+	case models.StateTypeWatching, models.StateTypeWatched:
+		filter.State = listType
+	}
+
+	switch query.Get("available_on") {
+	case "":
+	case models.AvailableOnMine:
+		// NOTE: a user without subscriptions gets an empty list rather than an unfiltered one
+		filter.AvailableOn = make([]int32, 0, len(user.WatchProviders))
+		filter.AvailableOn = append(filter.AvailableOn, user.WatchProviders...)
+	default:
+		return nil, errors.ErrInvalidAvailableOn
+	}
+
+	if param := query.Get("pinned"); param != "" {
+		pinned, err := strconv.ParseBool(param)
+		if err != nil {
+			return nil, errors.ErrInvalidPinned
+		}
+		filter.Pinned = &pinned
+	}
+
+	var err error
+	if filter.AddedAfter, err = parseDateParam(query.Get("added_after")); err != nil {
+		return nil, errors.ErrInvalidAddedRange
+	}
+	if filter.AddedBefore, err = parseDateParam(query.Get("added_before")); err != nil {
+		return nil, errors.ErrInvalidAddedRange
+	}
+	if filter.AddedAfter != nil && filter.AddedBefore != nil && !filter.AddedAfter.Before(*filter.AddedBefore) {
+		return nil, errors.ErrInvalidAddedRange
+	}
+
+	if filter.RuntimeMin, err = parseUintParam(query.Get("runtime_min")); err != nil {
+		return nil, errors.ErrInvalidRuntime
+	}
+	if filter.RuntimeMax, err = parseUintParam(query.Get("runtime_max")); err != nil {
+		return nil, errors.ErrInvalidRuntime
+	}
+	if filter.RuntimeMin != nil && filter.RuntimeMax != nil && *filter.RuntimeMin > *filter.RuntimeMax {
+		return nil, errors.ErrInvalidRuntime
+	}
+
+	if filter.Tags, err = parseTagsParam(query["tag"]); err != nil {
+		return nil, err
+	}
+
+	if q := strings.TrimSpace(query.Get("q")); q != "" {
+		if len([]rune(q)) > MaxMovieQueryLength {
+			q = string([]rune(q)[:MaxMovieQueryLength])
+		}
+		filter.Query = q
+	}
+
+	filter.Sort = strings.ToLower(query.Get("sort"))
+	switch filter.Sort {
+	case "":
+		filter.Sort = models.MovieSortAdded
+	case models.MovieSortAdded, models.MovieSortUpdated, models.MovieSortTitle, models.MovieSortRuntime, models.MovieSortManual:
+	default:
+		return nil, errors.ErrInvalidSort
+	}
+
+	filter.Direction = strings.ToLower(query.Get("order"))
+	switch filter.Direction {
+	case "":
+		filter.Direction = models.SortDesc
+		if filter.Sort == models.MovieSortTitle || filter.Sort == models.MovieSortManual {
+			filter.Direction = models.SortAsc
+		}
+	case models.SortAsc, models.SortDesc:
+	default:
+		return nil, errors.ErrInvalidOrder
+	}
+
+	return filter, nil
+}
+
+// parseTagsParam returns the distinct lowercased names of repeated tag params, nil when none are given
+func parseTagsParam(params []string) ([]string, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]string, 0, len(params))
+	for _, param := range params {
+		name := strings.ToLower(strings.TrimSpace(param))
+		if name == "" || utf8.RuneCountInString(name) > models.MaxTagNameLength {
+			return nil, errors.ErrInvalidTag
+		}
+
+		if !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+
+	if len(tags) > models.MaxMovieTags {
+		return nil, errors.ErrInvalidTag
+	}
+
+	return tags, nil
+}
+
+// parseDateParam accepts an RFC 3339 timestamp or a plain date, which stands for its midnight in UTC
+func parseDateParam(param string) (*time.Time, error) {
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		value, err = time.Parse(time.DateOnly, param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value = value.UTC()
+	return &value, nil
+}
+
+func parseUintParam(param string) (*uint64, error) {
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/controllers/movies.go
+// Source: movies.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/app/controllers/movies.go -destination=internal/app/controllers/movies_mock.go -package=controllers
+//	mockgen -source=movies.go -destination=movies_mock.go -package=controllers
 //
 
 // Package controllers is a generated GoMock package.
@@ -40,6 +40,30 @@ func (m *MockMoviesController) EXPECT() *MockMoviesControllerMockRecorder {
 	return m.recorder
 }
 
+// HandleBulk mocks base method.
+func (m *MockMoviesController) HandleBulk(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleBulk", w, r)
+}
+
+// HandleBulk indicates an expected call of HandleBulk.
+func (mr *MockMoviesControllerMockRecorder) HandleBulk(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBulk", reflect.TypeOf((*MockMoviesController)(nil).HandleBulk), w, r)
+}
+
+// HandleContinueWatching mocks base method.
+func (m *MockMoviesController) HandleContinueWatching(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleContinueWatching", w, r)
+}
+
+// HandleContinueWatching indicates an expected call of HandleContinueWatching.
+func (mr *MockMoviesControllerMockRecorder) HandleContinueWatching(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleContinueWatching", reflect.TypeOf((*MockMoviesController)(nil).HandleContinueWatching), w, r)
+}
+
 // HandleCreate mocks base method.
 func (m *MockMoviesController) HandleCreate(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -88,6 +112,30 @@ func (mr *MockMoviesControllerMockRecorder) HandleList(w, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleList", reflect.TypeOf((*MockMoviesController)(nil).HandleList), w, r)
 }
 
+// HandleMove mocks base method.
+func (m *MockMoviesController) HandleMove(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleMove", w, r)
+}
+
+// HandleMove indicates an expected call of HandleMove.
+func (mr *MockMoviesControllerMockRecorder) HandleMove(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleMove", reflect.TypeOf((*MockMoviesController)(nil).HandleMove), w, r)
+}
+
+// HandleNotes mocks base method.
+func (m *MockMoviesController) HandleNotes(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleNotes", w, r)
+}
+
+// HandleNotes indicates an expected call of HandleNotes.
+func (mr *MockMoviesControllerMockRecorder) HandleNotes(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNotes", reflect.TypeOf((*MockMoviesController)(nil).HandleNotes), w, r)
+}
+
 // HandleUpdate mocks base method.
 func (m *MockMoviesController) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -99,3 +147,15 @@ func (mr *MockMoviesControllerMockRecorder) HandleUpdate(w, r any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdate", reflect.TypeOf((*MockMoviesController)(nil).HandleUpdate), w, r)
 }
+
+// HandleUpsert mocks base method.
+func (m *MockMoviesController) HandleUpsert(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleUpsert", w, r)
+}
+
+// HandleUpsert indicates an expected call of HandleUpsert.
+func (mr *MockMoviesControllerMockRecorder) HandleUpsert(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpsert", reflect.TypeOf((*MockMoviesController)(nil).HandleUpsert), w, r)
+}
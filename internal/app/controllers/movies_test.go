@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+)
+
+func Test_ParseMovieFilter(t *testing.T) {
+	user := &models.User{WatchProviders: []int32{8, 337}}
+
+	pinned := true
+	addedAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	addedBefore := time.Date(2025, 2, 1, 12, 30, 0, 0, time.UTC)
+	runtimeMin := uint64(90)
+	runtimeMax := uint64(120)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected *models.MovieFilter
+		err      error
+	}{
+		{
+			name:  "Defaults",
+			query: "",
+			expected: &models.MovieFilter{
+				State:     models.StateTypeWant,
+				Sort:      models.MovieSortAdded,
+				Direction: models.SortDesc,
+			},
+		},
+		{
+			name:  "All filters",
+			query: "?type=watched&available_on=mine&pinned=true&added_after=2025-01-01&added_before=2025-02-01T14:30:00%2B02:00&runtime_min=90&runtime_max=120&q=%20dune%20&sort=runtime&order=asc",
+			expected: &models.MovieFilter{
+				State:       models.StateTypeWatched,
+				AvailableOn: []int32{8, 337},
+				Pinned:      &pinned,
+				AddedAfter:  &addedAfter,
+				AddedBefore: &addedBefore,
+				RuntimeMin:  &runtimeMin,
+				RuntimeMax:  &runtimeMax,
+				Query:       "dune",
+				Sort:        models.MovieSortRuntime,
+				Direction:   models.SortAsc,
+			},
+		},
+		{
+			name:  "Title defaults to ascending",
+			query: "?sort=TITLE",
+			expected: &models.MovieFilter{
+				State:     models.StateTypeWant,
+				Sort:      models.MovieSortTitle,
+				Direction: models.SortAsc,
+			},
+		},
+		{
+			name:  "Watching list",
+			query: "?type=watching",
+			expected: &models.MovieFilter{
+				State:     models.StateTypeWatching,
+				Sort:      models.MovieSortAdded,
+				Direction: models.SortDesc,
+			},
+		},
+		{
+			name:  "Manual defaults to ascending",
+			query: "?sort=manual",
+			expected: &models.MovieFilter{
+				State:     models.StateTypeWant,
+				Sort:      models.MovieSortManual,
+				Direction: models.SortAsc,
+			},
+		},
+		{
+			name:  "Tags",
+			query: "?tag=Cozy&tag=%20rewatch%20&tag=cozy",
+			expected: &models.MovieFilter{
+				State:     models.StateTypeWant,
+				Tags:      []string{"cozy", "rewatch"},
+				Sort:      models.MovieSortAdded,
+				Direction: models.SortDesc,
+			},
+		},
+		{
+			name:  "Invalid tag",
+			query: "?tag=",
+			err:   errors.ErrInvalidTag,
+		},
+		{
+			name:  "Invalid available on",
+			query: "?available_on=all",
+			err:   errors.ErrInvalidAvailableOn,
+		},
+		{
+			name:  "Invalid pinned",
+			query: "?pinned=maybe",
+			err:   errors.ErrInvalidPinned,
+		},
+		{
+			name:  "Invalid added date",
+			query: "?added_after=yesterday",
+			err:   errors.ErrInvalidAddedRange,
+		},
+		{
+			name:  "Empty added range",
+			query: "?added_after=2025-02-01&added_before=2025-01-01",
+			err:   errors.ErrInvalidAddedRange,
+		},
+		{
+			name:  "Invalid runtime",
+			query: "?runtime_min=-5",
+			err:   errors.ErrInvalidRuntime,
+		},
+		{
+			name:  "Empty runtime range",
+			query: "?runtime_min=120&runtime_max=90",
+			err:   errors.ErrInvalidRuntime,
+		},
+		{
+			name:  "Invalid sort",
+			query: "?sort=rating",
+			err:   errors.ErrInvalidSort,
+		},
+		{
+			name:  "Invalid order",
+			query: "?order=up",
+			err:   errors.ErrInvalidOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/movies"+tt.query, nil)
+
+			filter, err := parseMovieFilter(r, user)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expected, filter)
+		})
+	}
+}
+
+func Test_ParseMovieFilter_LongQuery(t *testing.T) {
+	long := make([]rune, MaxMovieQueryLength+20)
+	for i := range long {
+		long[i] = 'é'
+	}
+
+	r := httptest.NewRequest("GET", "/api/v1/movies?q="+string(long), nil)
+
+	filter, err := parseMovieFilter(r, &models.User{})
+
+	assert.NoError(t, err)
+	assert.Len(t, []rune(filter.Query), MaxMovieQueryLength)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
@@ -12,6 +13,7 @@ import (
 	"biinge-api/internal/app/services"
 	"biinge-api/internal/config/logger"
 	"biinge-api/internal/config/middlewares"
+	"biinge-api/pkg/tmdb"
 )
 
 type PeopleController interface {
@@ -48,8 +50,19 @@ func (t *peopleController) HandleDetails(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response, err := t.provider.FetchPersonDetails(r.Context(), id, user.ID)
+	creditType := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("credit_type")))
+	if !tmdb.IsValidCreditType(creditType) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidCreditType.Error()})
+		return
+	}
+
+	response, err := t.provider.FetchPersonDetails(r.Context(), id, user, creditType)
 	if err != nil {
+		if renderUpstreamUnavailable(w, err) {
+			return
+		}
+
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
 		return
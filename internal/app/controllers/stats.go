@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config/logger"
+	"biinge-api/internal/config/middlewares"
+	"biinge-api/pkg/tmdb"
+)
+
+const (
+	DefaultStatsLimit uint64 = 10
+	MaxStatsLimit     uint64 = 50
+)
+
+type StatsController interface {
+	HandlePeople(w http.ResponseWriter, r *http.Request)
+}
+
+type statsController struct {
+	credits services.Credits
+	images  tmdb.Images
+	log     *logger.Logger
+}
+
+func NewStatsController(credits services.Credits, images tmdb.Images, log *logger.Logger) StatsController {
+	return &statsController{
+		credits: credits,
+		images:  images,
+		log:     log.WithComponent("StatsController"),
+	}
+}
+
+func (c *statsController) HandlePeople(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	limit := DefaultStatsLimit
+	if param := r.URL.Query().Get("limit"); param != "" {
+		value, err := strconv.ParseUint(param, 10, 64)
+		if err == nil && value > 0 {
+			limit = min(value, MaxStatsLimit)
+		}
+	}
+
+	directors, actors, err := c.credits.TopPeople(r.Context(), user.ID, limit)
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	response := serializers.PeopleStatsSerializer{
+		Directors: c.serializePersonStats(r.Context(), directors),
+		Actors:    c.serializePersonStats(r.Context(), actors),
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (c *statsController) serializePersonStats(ctx context.Context, rows []models.PersonStat) []serializers.PersonStatSerializer {
+	collection := make([]serializers.PersonStatSerializer, 0, len(rows))
+	for _, row := range rows {
+		collection = append(collection, serializers.PersonStatSerializer{
+			Id:           row.PersonId,
+			Name:         row.Name,
+			ProfilePath:  row.ProfilePath,
+			Images:       serializers.NewImagesSerializer(c.images.Variants(ctx, tmdb.ImageKindProfile, row.ProfilePath)),
+			WatchedCount: row.WatchedCount,
+		})
+	}
+
+	return collection
+}
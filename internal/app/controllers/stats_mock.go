@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/controllers/stats.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/controllers/stats.go -destination=internal/app/controllers/stats_mock.go -package=controllers
+//
+
+// Package controllers is a generated GoMock package.
+package controllers
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatsController is a mock of StatsController interface.
+type MockStatsController struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsControllerMockRecorder is the mock recorder for MockStatsController.
+type MockStatsControllerMockRecorder struct {
+	mock *MockStatsController
+}
+
+// NewMockStatsController creates a new mock instance.
+func NewMockStatsController(ctrl *gomock.Controller) *MockStatsController {
+	mock := &MockStatsController{ctrl: ctrl}
+	mock.recorder = &MockStatsControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsController) EXPECT() *MockStatsControllerMockRecorder {
+	return m.recorder
+}
+
+// HandlePeople mocks base method.
+func (m *MockStatsController) HandlePeople(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandlePeople", w, r)
+}
+
+// HandlePeople indicates an expected call of HandlePeople.
+func (mr *MockStatsControllerMockRecorder) HandlePeople(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePeople", reflect.TypeOf((*MockStatsController)(nil).HandlePeople), w, r)
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/internal/config/middlewares"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_StatsController_People(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+
+	credits := services.NewMockCredits(ctrl)
+	images := tmdb.NewMockImages(ctrl)
+	images.EXPECT().Variants(gomock.Any(), tmdb.ImageKindProfile, gomock.Any()).Return(nil).AnyTimes()
+	log := logger.NewLogger(cfg)
+	controller := NewStatsController(credits, images, log)
+
+	currentUser := &models.User{ID: uuid.New()}
+
+	type result struct {
+		response serializers.PeopleStatsSerializer
+		error    serializers.ErrorSerializer
+		code     int
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		currentUser *models.User
+		before      func()
+		expected    result
+	}{
+		{
+			name:        "Success",
+			query:       "",
+			currentUser: currentUser,
+			before: func() {
+				credits.EXPECT().TopPeople(gomock.Any(), currentUser.ID, DefaultStatsLimit).Return(
+					[]models.PersonStat{{PersonId: 137427, Name: "Denis Villeneuve", ProfilePath: "/denis.jpg", WatchedCount: 12}},
+					[]models.PersonStat{},
+					nil,
+				)
+			},
+			expected: result{
+				response: serializers.PeopleStatsSerializer{
+					Directors: []serializers.PersonStatSerializer{
+						{Id: 137427, Name: "Denis Villeneuve", ProfilePath: "/denis.jpg", WatchedCount: 12},
+					},
+					Actors: []serializers.PersonStatSerializer{},
+				},
+				code: http.StatusOK,
+			},
+		},
+		{
+			name:        "Caps the limit",
+			query:       "?limit=1000",
+			currentUser: currentUser,
+			before: func() {
+				credits.EXPECT().TopPeople(gomock.Any(), currentUser.ID, MaxStatsLimit).Return(nil, nil, nil)
+			},
+			expected: result{
+				response: serializers.PeopleStatsSerializer{
+					Directors: []serializers.PersonStatSerializer{},
+					Actors:    []serializers.PersonStatSerializer{},
+				},
+				code: http.StatusOK,
+			},
+		},
+		{
+			name:        "Failure",
+			query:       "?limit=5",
+			currentUser: currentUser,
+			before: func() {
+				credits.EXPECT().TopPeople(gomock.Any(), currentUser.ID, uint64(5)).Return(nil, nil, errors.ErrFailedToFetchStats)
+			},
+			expected: result{
+				error: serializers.ErrorSerializer{Error: errors.ErrFailedToFetchStats.Error()},
+				code:  http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name:        "Unauthorized",
+			currentUser: nil,
+			before:      func() {},
+			expected: result{
+				error: serializers.ErrorSerializer{Error: "unauthorized"},
+				code:  http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/stats/people"+tt.query, nil)
+			if tt.currentUser != nil {
+				ctx := context.WithValue(req.Context(), middlewares.CurrentUser{}, tt.currentUser)
+				req = req.WithContext(ctx)
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/api/stats/people", controller.HandlePeople)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.expected.code == http.StatusOK {
+				var response serializers.PeopleStatsSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.response, response)
+			} else {
+				var response serializers.ErrorSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.error, response)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+		})
+	}
+}
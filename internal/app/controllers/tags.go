@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config/logger"
+	"biinge-api/internal/config/middlewares"
+)
+
+type TagsController interface {
+	HandleList(w http.ResponseWriter, r *http.Request)
+	HandleCreate(w http.ResponseWriter, r *http.Request)
+	HandleUpdate(w http.ResponseWriter, r *http.Request)
+	HandleDelete(w http.ResponseWriter, r *http.Request)
+	HandleSetMovieTags(w http.ResponseWriter, r *http.Request)
+}
+
+type tagsController struct {
+	tags   services.Tags
+	movies services.Movies
+	log    *logger.Logger
+}
+
+func NewTagsController(tags services.Tags, movies services.Movies, log *logger.Logger) TagsController {
+	return &tagsController{
+		tags:   tags,
+		movies: movies,
+		log:    log.WithComponent("TagsController"),
+	}
+}
+
+func (c *tagsController) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	rows, err := c.tags.List(r.Context(), user.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	collection := make([]serializers.TagSerializer, 0, len(rows))
+	for _, row := range rows {
+		collection = append(collection, serializeTag(&row))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(collection)
+}
+
+func (c *tagsController) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	var params serializers.TagRequestSerializer
+	if err := params.Validate(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	row, err := c.tags.Create(r.Context(), user.ID, params.Name)
+	if err != nil {
+		if errors.Is(err, errors.ErrTagAlreadyExists) {
+			w.WriteHeader(http.StatusConflict)
+		} else {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(serializeTag(row))
+}
+
+func (c *tagsController) HandleUpdate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tag id"})
+		return
+	}
+
+	var params serializers.TagRequestSerializer
+	if err = params.Validate(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	row, err := c.tags.Rename(r.Context(), id, user.ID, params.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrTagAlreadyExists):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Is(err, errors.ErrTagNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(serializeTag(row))
+}
+
+// HandleDelete removes a tag, the movies it labelled stay in the library
+func (c *tagsController) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tag id"})
+		return
+	}
+
+	err = c.tags.Delete(r.Context(), id, user.ID)
+	if err != nil {
+		if errors.Is(err, errors.ErrTagNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleSetMovieTags replaces the tags of a movie of the library, an empty list removes them all
+func (c *tagsController) HandleSetMovieTags(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tmdb id"})
+		return
+	}
+
+	var params serializers.MovieTagsRequestSerializer
+	if err = params.Validate(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	err = c.tags.SetMovieTags(r.Context(), user.ID, id, params.Tags)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrTooManyTags):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, errors.ErrMovieNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	row, err := c.movies.FindByTmdbId(r.Context(), id, user.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	response := serializers.MovieDetailsSerializer{
+		Id:       row.TmdbId,
+		Pinned:   row.Pinned,
+		State:    row.State,
+		Progress: row.Progress,
+		Tags:     row.Tags,
+		Notes:    row.Notes,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func serializeTag(row *models.Tag) serializers.TagSerializer {
+	return serializers.TagSerializer{
+		Id:          row.ID,
+		Name:        row.Name,
+		MoviesCount: row.MoviesCount,
+	}
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tags.go
+//
+// Generated by this command:
+//
+//	mockgen -source=tags.go -destination=tags_mock.go -package=controllers
+//
+
+// Package controllers is a generated GoMock package.
+package controllers
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagsController is a mock of TagsController interface.
+type MockTagsController struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockTagsControllerMockRecorder is the mock recorder for MockTagsController.
+type MockTagsControllerMockRecorder struct {
+	mock *MockTagsController
+}
+
+// NewMockTagsController creates a new mock instance.
+func NewMockTagsController(ctrl *gomock.Controller) *MockTagsController {
+	mock := &MockTagsController{ctrl: ctrl}
+	mock.recorder = &MockTagsControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagsController) EXPECT() *MockTagsControllerMockRecorder {
+	return m.recorder
+}
+
+// HandleCreate mocks base method.
+func (m *MockTagsController) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleCreate", w, r)
+}
+
+// HandleCreate indicates an expected call of HandleCreate.
+func (mr *MockTagsControllerMockRecorder) HandleCreate(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCreate", reflect.TypeOf((*MockTagsController)(nil).HandleCreate), w, r)
+}
+
+// HandleDelete mocks base method.
+func (m *MockTagsController) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleDelete", w, r)
+}
+
+// HandleDelete indicates an expected call of HandleDelete.
+func (mr *MockTagsControllerMockRecorder) HandleDelete(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDelete", reflect.TypeOf((*MockTagsController)(nil).HandleDelete), w, r)
+}
+
+// HandleList mocks base method.
+func (m *MockTagsController) HandleList(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleList", w, r)
+}
+
+// HandleList indicates an expected call of HandleList.
+func (mr *MockTagsControllerMockRecorder) HandleList(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleList", reflect.TypeOf((*MockTagsController)(nil).HandleList), w, r)
+}
+
+// HandleSetMovieTags mocks base method.
+func (m *MockTagsController) HandleSetMovieTags(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleSetMovieTags", w, r)
+}
+
+// HandleSetMovieTags indicates an expected call of HandleSetMovieTags.
+func (mr *MockTagsControllerMockRecorder) HandleSetMovieTags(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSetMovieTags", reflect.TypeOf((*MockTagsController)(nil).HandleSetMovieTags), w, r)
+}
+
+// HandleUpdate mocks base method.
+func (m *MockTagsController) HandleUpdate(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleUpdate", w, r)
+}
+
+// HandleUpdate indicates an expected call of HandleUpdate.
+func (mr *MockTagsControllerMockRecorder) HandleUpdate(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdate", reflect.TypeOf((*MockTagsController)(nil).HandleUpdate), w, r)
+}
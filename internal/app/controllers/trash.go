@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config/logger"
+	"biinge-api/internal/config/middlewares"
+	"biinge-api/pkg/tmdb"
+)
+
+type TrashController interface {
+	HandleList(w http.ResponseWriter, r *http.Request)
+	HandleRestore(w http.ResponseWriter, r *http.Request)
+	HandleEmpty(w http.ResponseWriter, r *http.Request)
+}
+
+type trashController struct {
+	movies services.Movies
+	images tmdb.Images
+	log    *logger.Logger
+}
+
+func NewTrashController(movies services.Movies, images tmdb.Images, log *logger.Logger) TrashController {
+	return &trashController{
+		movies: movies,
+		images: images,
+		log:    log.WithComponent("TrashController"),
+	}
+}
+
+// HandleList lists the movies waiting in the trash, the most recently deleted first
+func (c *trashController) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	pagination := services.NewPagination(r)
+
+	rows, info, err := c.movies.ListTrash(r.Context(), user.ID, pagination)
+	if err != nil {
+		if errors.Is(err, errors.ErrCursorNotSupported) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	collection := make([]serializers.TrashedMovieSerializer, 0, len(rows))
+	for _, row := range rows {
+		collection = append(collection, newTrashedMovieSerializer(&row, c.images.Variants(r.Context(), tmdb.ImageKindPoster, row.PosterPath)))
+	}
+
+	response := serializers.PaginationResponse[serializers.TrashedMovieSerializer]{
+		Data: collection,
+		Meta: serializers.PaginationMeta{
+			Page:  pagination.Page,
+			Per:   pagination.PerPage,
+			Total: info.Total,
+		},
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleRestore brings a movie back to the library with the pin, state and progress it had
+func (c *trashController) HandleRestore(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "invalid tmdb id"})
+		return
+	}
+
+	row, err := c.movies.Restore(r.Context(), id, user.ID)
+	if err != nil {
+		if errors.Is(err, errors.ErrMovieNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	response := serializers.MovieDetailsSerializer{
+		Id:         row.TmdbId,
+		Title:      row.Title,
+		PosterPath: row.PosterPath,
+		Images:     serializers.NewImagesSerializer(c.images.Variants(r.Context(), tmdb.ImageKindPoster, row.PosterPath)),
+		Runtime:    int(row.Runtime),
+		Pinned:     row.Pinned,
+		State:      row.State,
+		Progress:   row.Progress,
+		Tags:       row.Tags,
+		Notes:      row.Notes,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleEmpty purges every movie in the trash right away
+func (c *trashController) HandleEmpty(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	if _, err := c.movies.EmptyTrash(r.Context(), user.ID); err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newTrashedMovieSerializer(row *models.Movie, images *tmdb.ImageSet) serializers.TrashedMovieSerializer {
+	response := serializers.TrashedMovieSerializer{
+		MovieSerializer: serializers.MovieSerializer{
+			Id:         row.TmdbId,
+			Title:      row.Title,
+			PosterPath: row.PosterPath,
+			Images:     serializers.NewImagesSerializer(images),
+			Runtime:    row.Runtime,
+			Pinned:     row.Pinned,
+			State:      row.State,
+			Progress:   row.Progress,
+		},
+		PurgeAt: row.PurgeAt,
+	}
+	if row.DeletedAt != nil {
+		response.DeletedAt = *row.DeletedAt
+	}
+
+	return response
+}
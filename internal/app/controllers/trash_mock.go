@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: trash.go
+//
+// Generated by this command:
+//
+//	mockgen -source=trash.go -destination=trash_mock.go -package=controllers
+//
+
+// Package controllers is a generated GoMock package.
+package controllers
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrashController is a mock of TrashController interface.
+type MockTrashController struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockTrashControllerMockRecorder is the mock recorder for MockTrashController.
+type MockTrashControllerMockRecorder struct {
+	mock *MockTrashController
+}
+
+// NewMockTrashController creates a new mock instance.
+func NewMockTrashController(ctrl *gomock.Controller) *MockTrashController {
+	mock := &MockTrashController{ctrl: ctrl}
+	mock.recorder = &MockTrashControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashController) EXPECT() *MockTrashControllerMockRecorder {
+	return m.recorder
+}
+
+// HandleEmpty mocks base method.
+func (m *MockTrashController) HandleEmpty(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleEmpty", w, r)
+}
+
+// HandleEmpty indicates an expected call of HandleEmpty.
+func (mr *MockTrashControllerMockRecorder) HandleEmpty(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEmpty", reflect.TypeOf((*MockTrashController)(nil).HandleEmpty), w, r)
+}
+
+// HandleList mocks base method.
+func (m *MockTrashController) HandleList(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleList", w, r)
+}
+
+// HandleList indicates an expected call of HandleList.
+func (mr *MockTrashControllerMockRecorder) HandleList(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleList", reflect.TypeOf((*MockTrashController)(nil).HandleList), w, r)
+}
+
+// HandleRestore mocks base method.
+func (m *MockTrashController) HandleRestore(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleRestore", w, r)
+}
+
+// HandleRestore indicates an expected call of HandleRestore.
+func (mr *MockTrashControllerMockRecorder) HandleRestore(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRestore", reflect.TypeOf((*MockTrashController)(nil).HandleRestore), w, r)
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/pkg/tmdb"
+)
+
+// renderUpstreamUnavailable responds with 503 when TMDB is unavailable, along Retry-After when the circuit
+// breaker tells how long it stays open
+func renderUpstreamUnavailable(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, tmdb.ErrUpstreamUnavailable) {
+		return false
+	}
+
+	var unavailable *tmdb.UnavailableError
+	if errors.As(err, &unavailable) && unavailable.RetryAfter > 0 {
+		retryAfter := int(math.Ceil(unavailable.RetryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: tmdb.ErrUpstreamUnavailable.Error()})
+	return true
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_renderUpstreamUnavailable(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		rendered   bool
+		retryAfter string
+	}{
+		{
+			name:       "Circuit breaker open",
+			err:        &tmdb.UnavailableError{RetryAfter: 1500 * time.Millisecond},
+			rendered:   true,
+			retryAfter: "2",
+		},
+		{
+			name:     "Unavailable without a known duration",
+			err:      tmdb.ErrUpstreamUnavailable,
+			rendered: true,
+		},
+		{
+			name:     "Wrapped",
+			err:      fmt.Errorf("fetch movie: %w", tmdb.ErrUpstreamUnavailable),
+			rendered: true,
+		},
+		{
+			name: "Other error",
+			err:  tmdb.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			rendered := renderUpstreamUnavailable(w, tt.err)
+
+			assert.Equal(t, tt.rendered, rendered)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+			if tt.rendered {
+				assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+				assert.Equal(t, `{"error":"TMDB API is temporarily unavailable"}`+"\n", w.Body.String())
+			} else {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
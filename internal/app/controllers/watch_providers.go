@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config/logger"
+	"biinge-api/internal/config/middlewares"
+	"biinge-api/pkg/tmdb"
+)
+
+type WatchProvidersController interface {
+	HandleList(w http.ResponseWriter, r *http.Request)
+}
+
+type watchProvidersController struct {
+	provider services.TmdbProvider
+	log      *logger.Logger
+}
+
+func NewWatchProvidersController(provider services.TmdbProvider, log *logger.Logger) WatchProvidersController {
+	return &watchProvidersController{
+		provider: provider,
+		log:      log.WithComponent("WatchProvidersController"),
+	}
+}
+
+func (c *watchProvidersController) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := middlewares.CurrentUserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+		return
+	}
+
+	region := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("region")))
+	if region != "" && !tmdb.IsValidRegion(region) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidRegion.Error()})
+		return
+	}
+
+	response, err := c.provider.FetchWatchProviders(r.Context(), region, user)
+	if err != nil {
+		if renderUpstreamUnavailable(w, err) {
+			return
+		}
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
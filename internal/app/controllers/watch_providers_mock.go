@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/controllers/watch_providers.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/controllers/watch_providers.go -destination=internal/app/controllers/watch_providers_mock.go -package=controllers
+//
+
+// Package controllers is a generated GoMock package.
+package controllers
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWatchProvidersController is a mock of WatchProvidersController interface.
+type MockWatchProvidersController struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchProvidersControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockWatchProvidersControllerMockRecorder is the mock recorder for MockWatchProvidersController.
+type MockWatchProvidersControllerMockRecorder struct {
+	mock *MockWatchProvidersController
+}
+
+// NewMockWatchProvidersController creates a new mock instance.
+func NewMockWatchProvidersController(ctrl *gomock.Controller) *MockWatchProvidersController {
+	mock := &MockWatchProvidersController{ctrl: ctrl}
+	mock.recorder = &MockWatchProvidersControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatchProvidersController) EXPECT() *MockWatchProvidersControllerMockRecorder {
+	return m.recorder
+}
+
+// HandleList mocks base method.
+func (m *MockWatchProvidersController) HandleList(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleList", w, r)
+}
+
+// HandleList indicates an expected call of HandleList.
+func (mr *MockWatchProvidersControllerMockRecorder) HandleList(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleList", reflect.TypeOf((*MockWatchProvidersController)(nil).HandleList), w, r)
+}
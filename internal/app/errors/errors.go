@@ -9,14 +9,49 @@ var (
 	ErrEmptyFirstName  = errors.New("empty first name")
 	ErrEmptyLastName   = errors.New("empty last name")
 	ErrEmptyAppearance = errors.New("empty appearance")
+	ErrInvalidLanguage = errors.New("invalid language")
+	ErrInvalidRegion   = errors.New("invalid region")
 
-	ErrEmptyTitle   = errors.New("empty title")
-	ErrEmptyPoster  = errors.New("empty poster")
-	ErrEmptyState   = errors.New("empty state")
-	ErrInvalidState = errors.New("invalid state")
+	ErrInvalidWatchProvider = errors.New("invalid watch provider")
+	ErrAdultAgeNotConfirmed = errors.New("adult content requires age confirmation")
+
+	ErrInvalidTmdbId   = errors.New("invalid tmdb id")
+	ErrEmptyState      = errors.New("empty state")
+	ErrInvalidState    = errors.New("invalid state")
+	ErrInvalidMove     = errors.New("either before or after another movie is required")
+	ErrInvalidProgress = errors.New("invalid progress")
+	ErrInvalidTrashed  = errors.New("trashed must be restore or discard")
+	ErrInvalidNotes    = errors.New("notes are too long")
+
+	ErrInvalidIdempotencyKey    = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key already used for another request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is in progress")
+
+	ErrEmptyTagName   = errors.New("empty tag name")
+	ErrInvalidTagName = errors.New("tag name is too long")
+	ErrTooManyTags    = errors.New("too many tags")
+	ErrInvalidTag     = errors.New("invalid tag filter")
+
+	ErrEmptyBulk         = errors.New("at least one operation is required")
+	ErrTooManyOperations = errors.New("too many operations")
+	ErrInvalidOperation  = errors.New("invalid operation")
+	ErrBulkAborted       = errors.New("bulk operations rolled back after a failure")
+
+	ErrInvalidAvailableOn = errors.New("invalid available_on filter")
+	ErrInvalidCreditType  = errors.New("invalid credit_type filter")
+	ErrInvalidPinned      = errors.New("invalid pinned filter")
+	ErrInvalidAddedRange  = errors.New("invalid added date range")
+	ErrInvalidRuntime     = errors.New("invalid runtime range")
+	ErrInvalidSort        = errors.New("invalid sort")
+	ErrInvalidOrder       = errors.New("invalid order")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrCursorNotSupported = errors.New("cursor pagination is not supported")
 
 	ErrLoginAlreadyExists = errors.New("login already exists")
 	ErrEmailAlreadyExists = errors.New("email already exists")
+	ErrTagAlreadyExists   = errors.New("tag already exists")
+	ErrMovieAlreadyExists = errors.New("movie already exists")
+	ErrMovieInTrash       = errors.New("movie is in the trash")
 
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidPassword    = errors.New("invalid password")
@@ -32,9 +67,28 @@ var (
 	ErrFailedToFetchMovie   = errors.New("failed to fetch movie")
 	ErrFailedToCreateMovie  = errors.New("failed to create movie")
 	ErrFailedToUpdateMovie  = errors.New("failed to update movie")
+	ErrFailedToSaveMovie    = errors.New("failed to save movie")
 	ErrFailedToDeleteMovie  = errors.New("failed to delete movie")
+	ErrFailedToMoveMovie    = errors.New("failed to move movie")
+	ErrFailedToCacheCredits = errors.New("failed to cache movie credits")
+	ErrFailedToFetchStats   = errors.New("failed to fetch stats")
+	ErrFailedToFetchTitles  = errors.New("failed to fetch titles")
+	ErrFailedToRefreshTitle = errors.New("failed to refresh title")
+	ErrFailedToFetchTags    = errors.New("failed to fetch tags")
+	ErrFailedToSaveTag      = errors.New("failed to save tag")
+	ErrFailedToDeleteTag    = errors.New("failed to delete tag")
+	ErrFailedToTagMovie     = errors.New("failed to tag movie")
+	ErrFailedToBulkUpdate   = errors.New("failed to apply bulk operations")
+	ErrFailedToFetchTrash   = errors.New("failed to fetch trash")
+	ErrFailedToRestoreMovie = errors.New("failed to restore movie")
+	ErrFailedToEmptyTrash   = errors.New("failed to empty trash")
+	ErrFailedToRecordEvent  = errors.New("failed to record movie history")
+	ErrFailedToFetchHistory = errors.New("failed to fetch history")
+
+	ErrFailedToSaveIdempotencyKey = errors.New("failed to save idempotency key")
 
 	ErrMovieNotFound   = errors.New("movie not found")
+	ErrTagNotFound     = errors.New("tag not found")
 	ErrSeriesNotFound  = errors.New("series not found")
 	ErrSeasonNotFound  = errors.New("season not found")
 	ErrEpisodeNotFound = errors.New("episode not found")
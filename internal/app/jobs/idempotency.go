@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+const DefaultIdempotencyPurgeInterval = time.Hour
+
+type idempotencyPurger struct {
+	idempotency services.Idempotency
+	interval    time.Duration
+	ttl         time.Duration
+	now         func() time.Time
+	log         *logger.Logger
+}
+
+// NewIdempotencyPurger creates a job removing the idempotency keys past their replay window
+func NewIdempotencyPurger(cfg *config.Config, idempotency services.Idempotency, log *logger.Logger) Job {
+	interval := cfg.JobsConfig.IdempotencyPurgeInterval
+	if interval <= 0 {
+		interval = DefaultIdempotencyPurgeInterval
+	}
+
+	ttl := cfg.IdempotencyConfig.KeyTTL
+	if ttl <= 0 {
+		ttl = services.DefaultIdempotencyKeyTTL
+	}
+
+	return &idempotencyPurger{
+		idempotency: idempotency,
+		interval:    interval,
+		ttl:         ttl,
+		now:         time.Now,
+		log:         log.WithComponent("IdempotencyPurger"),
+	}
+}
+
+func (j *idempotencyPurger) Name() string {
+	return "idempotency_purge"
+}
+
+func (j *idempotencyPurger) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *idempotencyPurger) Run(ctx context.Context) error {
+	purged, err := j.idempotency.Purge(ctx, j.now().Add(-j.ttl))
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		j.log.Info().
+			Uint64("purged", purged).
+			Msg("Purged idempotency keys")
+	}
+
+	return nil
+}
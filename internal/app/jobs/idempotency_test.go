@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_IdempotencyPurger_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+		IdempotencyConfig: config.IdempotencyConfig{
+			KeyTTL: 2 * time.Hour,
+		},
+	}
+
+	idempotency := services.NewMockIdempotency(ctrl)
+	job := NewIdempotencyPurger(cfg, idempotency, logger.NewLogger(cfg)).(*idempotencyPurger)
+
+	now := time.Date(2025, 8, 31, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	idempotency.EXPECT().Purge(gomock.Any(), now.Add(-2*time.Hour)).Return(uint64(12), nil)
+
+	assert.Equal(t, DefaultIdempotencyPurgeInterval, job.Interval())
+	assert.NoError(t, job.Run(context.Background()))
+}
+
+func Test_IdempotencyPurger_Run_Failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	idempotency := services.NewMockIdempotency(ctrl)
+	job := NewIdempotencyPurger(cfg, idempotency, logger.NewLogger(cfg)).(*idempotencyPurger)
+
+	now := time.Date(2025, 8, 31, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	idempotency.EXPECT().
+		Purge(gomock.Any(), now.Add(-services.DefaultIdempotencyKeyTTL)).
+		Return(uint64(0), errors.ErrFailedToSaveIdempotencyKey)
+
+	assert.ErrorIs(t, job.Run(context.Background()), errors.ErrFailedToSaveIdempotencyKey)
+}
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Options(
+	fx.Provide(
+		fx.Annotate(NewWatchProvidersRefresher, fx.ResultTags(`group:"jobs"`)),
+		fx.Annotate(NewMovieCreditsCacher, fx.ResultTags(`group:"jobs"`)),
+		fx.Annotate(NewTitlesRefresher, fx.ResultTags(`group:"jobs"`)),
+		fx.Annotate(NewTitleChangesSyncer, fx.ResultTags(`group:"jobs"`)),
+		fx.Annotate(NewTrashPurger, fx.ResultTags(`group:"jobs"`)),
+		fx.Annotate(NewIdempotencyPurger, fx.ResultTags(`group:"jobs"`)),
+	),
+	fx.Provide(
+		fx.Annotate(NewScheduler, fx.ParamTags(`group:"jobs"`)),
+	),
+	fx.Invoke(registerHooks),
+)
+
+func registerHooks(lifecycle fx.Lifecycle, scheduler Scheduler) {
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			scheduler.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return scheduler.Stop(ctx)
+		},
+	})
+}
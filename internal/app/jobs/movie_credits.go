@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+const (
+	DefaultMovieCreditsInterval = 15 * time.Minute
+	DefaultMovieCreditsBatch    = 50
+)
+
+type movieCreditsCacher struct {
+	credits  services.Credits
+	interval time.Duration
+	batch    uint64
+	log      *logger.Logger
+}
+
+// NewMovieCreditsCacher creates a job caching the credits of library movies, those added
+// since its last run and those whose caching failed
+func NewMovieCreditsCacher(cfg *config.Config, credits services.Credits, log *logger.Logger) Job {
+	interval := cfg.JobsConfig.MovieCreditsInterval
+	if interval <= 0 {
+		interval = DefaultMovieCreditsInterval
+	}
+
+	batch := cfg.JobsConfig.MovieCreditsBatch
+	if batch <= 0 {
+		batch = DefaultMovieCreditsBatch
+	}
+
+	return &movieCreditsCacher{
+		credits:  credits,
+		interval: interval,
+		batch:    uint64(batch),
+		log:      log.WithComponent("MovieCreditsCacher"),
+	}
+}
+
+func (j *movieCreditsCacher) Name() string {
+	return "movie_credits"
+}
+
+func (j *movieCreditsCacher) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *movieCreditsCacher) Run(ctx context.Context) error {
+	ids, err := j.credits.FindUncachedMovieIds(ctx, j.batch)
+	if err != nil {
+		return err
+	}
+
+	cached := 0
+	for _, tmdbId := range ids {
+		err := j.credits.CacheMovieCredits(ctx, tmdbId)
+		switch {
+		case errors.Is(err, tmdb.ErrUpstreamUnavailable), errors.Is(err, context.Canceled):
+			return err
+		case err != nil:
+			continue
+		}
+		cached++
+	}
+
+	if len(ids) > 0 {
+		j.log.Info().
+			Int("movies", cached).
+			Int("pending", len(ids)-cached).
+			Msg("Cached movie credits")
+	}
+
+	return nil
+}
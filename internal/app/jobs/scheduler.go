@@ -0,0 +1,102 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"biinge-api/internal/config/logger"
+)
+
+// Job is a unit of background work run periodically by the Scheduler
+type Job interface {
+	Name() string
+	Interval() time.Duration
+	Run(ctx context.Context) error
+}
+
+type Scheduler interface {
+	Start()
+	Stop(ctx context.Context) error
+}
+
+type scheduler struct {
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    *logger.Logger
+}
+
+func NewScheduler(jobs []Job, log *logger.Logger) Scheduler {
+	return &scheduler{
+		jobs: jobs,
+		log:  log.WithComponent("JobsScheduler"),
+	}
+}
+
+// Start runs every job once and then on each tick of its interval until Stop is called
+func (s *scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return or ctx to be done
+func (s *scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
+func (s *scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *scheduler) run(ctx context.Context, job Job) {
+	started := time.Now()
+
+	if err := job.Run(ctx); err != nil {
+		s.log.Error().
+			Err(err).
+			Str("job", job.Name()).
+			Msg("Job failed")
+		return
+	}
+
+	s.log.Debug().
+		Str("job", job.Name()).
+		Dur("duration", time.Since(started)).
+		Msg("Job finished")
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+type countingJob struct {
+	runs atomic.Int32
+}
+
+func (j *countingJob) Name() string {
+	return "counting"
+}
+
+func (j *countingJob) Interval() time.Duration {
+	return 10 * time.Millisecond
+}
+
+func (j *countingJob) Run(ctx context.Context) error {
+	j.runs.Add(1)
+	return nil
+}
+
+func Test_Scheduler_StartAndStop(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	job := &countingJob{}
+	scheduler := NewScheduler([]Job{job}, logger.NewLogger(cfg))
+
+	scheduler.Start()
+	assert.Eventually(t, func() bool {
+		return job.runs.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, scheduler.Stop(ctx))
+
+	runs := job.runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, runs, job.runs.Load())
+}
@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+const (
+	DefaultTitleChangesInterval = time.Hour
+
+	// TitleChangesFeedMovie names the cursor of TMDB's movie changes feed
+	TitleChangesFeedMovie = "movie"
+)
+
+type titleChangesSyncer struct {
+	titles   services.Titles
+	client   tmdb.Client
+	interval time.Duration
+	now      func() time.Time
+	log      *logger.Logger
+}
+
+// NewTitleChangesSyncer creates a job polling TMDB's movie changes feed and invalidating the changed
+// titles held in libraries, the titles refresher then fetches only those
+func NewTitleChangesSyncer(cfg *config.Config, titles services.Titles, client tmdb.Client, log *logger.Logger) Job {
+	interval := cfg.JobsConfig.TitleChangesInterval
+	if interval <= 0 {
+		interval = DefaultTitleChangesInterval
+	}
+
+	return &titleChangesSyncer{
+		titles:   titles,
+		client:   client,
+		interval: interval,
+		now:      time.Now,
+		log:      log.WithComponent("TitleChangesSyncer"),
+	}
+}
+
+func (j *titleChangesSyncer) Name() string {
+	return "title_changes"
+}
+
+func (j *titleChangesSyncer) Interval() time.Duration {
+	return j.interval
+}
+
+// Run polls the feed from the last synced day up to now in windows TMDB accepts. The feed is by day,
+// so the last day is polled again on the next run and invalidating a title twice is harmless
+func (j *titleChangesSyncer) Run(ctx context.Context) error {
+	now := j.now().UTC()
+
+	since, err := j.titles.ChangesCursor(ctx, TitleChangesFeedMovie)
+	if err != nil {
+		return err
+	}
+	if since.IsZero() || since.After(now) {
+		since = now.Add(-j.interval)
+	}
+
+	changed, invalidated := 0, uint64(0)
+	for start := since; start.Before(now); {
+		end := start.Add(tmdb.MaxChangesWindow)
+		if end.After(now) {
+			end = now
+		}
+
+		ids, err := j.fetchChanges(ctx, start, end)
+		if err != nil {
+			return err
+		}
+
+		count, err := j.titles.Invalidate(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		if err := j.titles.SaveChangesCursor(ctx, TitleChangesFeedMovie, end); err != nil {
+			return err
+		}
+
+		changed += len(ids)
+		invalidated += count
+		start = end
+	}
+
+	if invalidated > 0 {
+		j.log.Info().
+			Int("changed", changed).
+			Uint64("invalidated", invalidated).
+			Msg("Invalidated changed titles")
+	}
+
+	return nil
+}
+
+func (j *titleChangesSyncer) fetchChanges(ctx context.Context, start time.Time, end time.Time) ([]uint64, error) {
+	ids := make([]uint64, 0)
+
+	for page := 1; ; page++ {
+		response, err := j.client.FetchMovieChanges(ctx, start, end, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range response.Results {
+			ids = append(ids, item.Id)
+		}
+
+		if page >= response.TotalPages {
+			return ids, nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_TitleChangesSyncer_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	titles := services.NewMockTitles(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	job := NewTitleChangesSyncer(cfg, titles, client, logger.NewLogger(cfg)).(*titleChangesSyncer)
+
+	now := time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	since := now.Add(-20 * 24 * time.Hour)
+	middle := since.Add(tmdb.MaxChangesWindow)
+
+	gomock.InOrder(
+		titles.EXPECT().ChangesCursor(gomock.Any(), TitleChangesFeedMovie).Return(since, nil),
+		client.EXPECT().FetchMovieChanges(gomock.Any(), since, middle, 1).Return(&tmdb.Changes{
+			Page:       1,
+			TotalPages: 2,
+			Results:    []tmdb.ChangedItem{{Id: 27205}, {Id: 1}},
+		}, nil),
+		client.EXPECT().FetchMovieChanges(gomock.Any(), since, middle, 2).Return(&tmdb.Changes{
+			Page:       2,
+			TotalPages: 2,
+			Results:    []tmdb.ChangedItem{{Id: 438631}},
+		}, nil),
+		titles.EXPECT().Invalidate(gomock.Any(), []uint64{27205, 1, 438631}).Return(uint64(2), nil),
+		titles.EXPECT().SaveChangesCursor(gomock.Any(), TitleChangesFeedMovie, middle).Return(nil),
+		client.EXPECT().FetchMovieChanges(gomock.Any(), middle, now, 1).Return(&tmdb.Changes{Page: 1, TotalPages: 0}, nil),
+		titles.EXPECT().Invalidate(gomock.Any(), []uint64{}).Return(uint64(0), nil),
+		titles.EXPECT().SaveChangesCursor(gomock.Any(), TitleChangesFeedMovie, now).Return(nil),
+	)
+
+	assert.NoError(t, job.Run(context.Background()))
+}
+
+func Test_TitleChangesSyncer_Run_FirstSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	titles := services.NewMockTitles(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	job := NewTitleChangesSyncer(cfg, titles, client, logger.NewLogger(cfg)).(*titleChangesSyncer)
+
+	now := time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	titles.EXPECT().ChangesCursor(gomock.Any(), TitleChangesFeedMovie).Return(time.Time{}, nil)
+	client.EXPECT().
+		FetchMovieChanges(gomock.Any(), now.Add(-DefaultTitleChangesInterval), now, 1).
+		Return(&tmdb.Changes{Page: 1, TotalPages: 1, Results: []tmdb.ChangedItem{{Id: 27205}}}, nil)
+	titles.EXPECT().Invalidate(gomock.Any(), []uint64{27205}).Return(uint64(1), nil)
+	titles.EXPECT().SaveChangesCursor(gomock.Any(), TitleChangesFeedMovie, now).Return(nil)
+
+	assert.NoError(t, job.Run(context.Background()))
+}
+
+func Test_TitleChangesSyncer_Run_Unavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	titles := services.NewMockTitles(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	job := NewTitleChangesSyncer(cfg, titles, client, logger.NewLogger(cfg))
+
+	titles.EXPECT().ChangesCursor(gomock.Any(), TitleChangesFeedMovie).Return(time.Time{}, nil)
+	client.EXPECT().
+		FetchMovieChanges(gomock.Any(), gomock.Any(), gomock.Any(), 1).
+		Return(nil, &tmdb.UnavailableError{RetryAfter: time.Second})
+
+	err := job.Run(context.Background())
+	assert.ErrorIs(t, err, tmdb.ErrUpstreamUnavailable)
+}
@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+const (
+	DefaultTitlesInterval = time.Hour
+	DefaultTitlesMaxAge   = services.DefaultTitlesMaxAge
+	DefaultTitlesBatch    = 50
+)
+
+type titlesRefresher struct {
+	titles   services.Titles
+	interval time.Duration
+	maxAge   time.Duration
+	batch    uint64
+	now      func() time.Time
+	log      *logger.Logger
+}
+
+// NewTitlesRefresher creates a job re-fetching catalog titles older than the max age,
+// changed metadata is copied to the library rows of the title
+func NewTitlesRefresher(cfg *config.Config, titles services.Titles, log *logger.Logger) Job {
+	interval := cfg.JobsConfig.TitlesInterval
+	if interval <= 0 {
+		interval = DefaultTitlesInterval
+	}
+
+	maxAge := cfg.JobsConfig.TitlesMaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultTitlesMaxAge
+	}
+
+	batch := cfg.JobsConfig.TitlesBatch
+	if batch <= 0 {
+		batch = DefaultTitlesBatch
+	}
+
+	return &titlesRefresher{
+		titles:   titles,
+		interval: interval,
+		maxAge:   maxAge,
+		batch:    uint64(batch),
+		now:      time.Now,
+		log:      log.WithComponent("TitlesRefresher"),
+	}
+}
+
+func (j *titlesRefresher) Name() string {
+	return "titles"
+}
+
+func (j *titlesRefresher) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *titlesRefresher) Run(ctx context.Context) error {
+	ctx = services.WithEventSource(ctx, models.EventSourceSync)
+
+	ids, err := j.titles.FindStaleIds(ctx, j.now().Add(-j.maxAge), j.batch)
+	if err != nil {
+		return err
+	}
+
+	refreshed := 0
+	for _, tmdbId := range ids {
+		err := j.titles.Refresh(ctx, tmdbId)
+		switch {
+		case errors.Is(err, tmdb.ErrUpstreamUnavailable), errors.Is(err, context.Canceled):
+			return err
+		case err != nil:
+			continue
+		}
+		refreshed++
+	}
+
+	if len(ids) > 0 {
+		j.log.Info().
+			Int("titles", refreshed).
+			Int("pending", len(ids)-refreshed).
+			Msg("Refreshed titles")
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_TitlesRefresher_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	titles := services.NewMockTitles(ctrl)
+	job := NewTitlesRefresher(cfg, titles, logger.NewLogger(cfg)).(*titlesRefresher)
+
+	now := time.Date(2025, 6, 29, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	titles.EXPECT().
+		FindStaleIds(gomock.Any(), now.Add(-DefaultTitlesMaxAge), uint64(DefaultTitlesBatch)).
+		Return([]uint64{27205, 1, 438631}, nil)
+	titles.EXPECT().Refresh(gomock.Any(), uint64(27205)).Return(nil)
+	titles.EXPECT().Refresh(gomock.Any(), uint64(1)).Return(errors.ErrFailedToRefreshTitle)
+	titles.EXPECT().Refresh(gomock.Any(), uint64(438631)).Return(nil)
+
+	assert.NoError(t, job.Run(context.Background()))
+}
+
+func Test_TitlesRefresher_Run_Unavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	titles := services.NewMockTitles(ctrl)
+	job := NewTitlesRefresher(cfg, titles, logger.NewLogger(cfg))
+
+	titles.EXPECT().FindStaleIds(gomock.Any(), gomock.Any(), gomock.Any()).Return([]uint64{27205, 438631}, nil)
+	titles.EXPECT().Refresh(gomock.Any(), uint64(27205)).Return(tmdb.ErrUpstreamUnavailable)
+
+	err := job.Run(context.Background())
+	assert.ErrorIs(t, err, tmdb.ErrUpstreamUnavailable)
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+const DefaultTrashPurgeInterval = time.Hour
+
+type trashPurger struct {
+	movies    services.Movies
+	interval  time.Duration
+	retention time.Duration
+	now       func() time.Time
+	log       *logger.Logger
+}
+
+// NewTrashPurger creates a job removing for good the movies kept in the trash past the retention
+func NewTrashPurger(cfg *config.Config, movies services.Movies, log *logger.Logger) Job {
+	interval := cfg.JobsConfig.TrashPurgeInterval
+	if interval <= 0 {
+		interval = DefaultTrashPurgeInterval
+	}
+
+	retention := cfg.LibraryConfig.TrashRetention
+	if retention <= 0 {
+		retention = services.DefaultTrashRetention
+	}
+
+	return &trashPurger{
+		movies:    movies,
+		interval:  interval,
+		retention: retention,
+		now:       time.Now,
+		log:       log.WithComponent("TrashPurger"),
+	}
+}
+
+func (j *trashPurger) Name() string {
+	return "trash_purge"
+}
+
+func (j *trashPurger) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *trashPurger) Run(ctx context.Context) error {
+	ctx = services.WithEventSource(ctx, models.EventSourceSync)
+
+	purged, err := j.movies.PurgeTrash(ctx, j.now().Add(-j.retention))
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		j.log.Info().
+			Uint64("purged", purged).
+			Msg("Purged trashed movies")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_TrashPurger_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+		LibraryConfig: config.LibraryConfig{
+			TrashRetention: 7 * 24 * time.Hour,
+		},
+	}
+
+	movies := services.NewMockMovies(ctrl)
+	job := NewTrashPurger(cfg, movies, logger.NewLogger(cfg)).(*trashPurger)
+
+	now := time.Date(2025, 8, 17, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	movies.EXPECT().PurgeTrash(gomock.Any(), now.Add(-7*24*time.Hour)).DoAndReturn(
+		func(ctx context.Context, _ time.Time) (uint64, error) {
+			assert.Equal(t, models.EventSourceSync, services.EventSourceFromContext(ctx))
+			return 3, nil
+		},
+	)
+
+	assert.Equal(t, DefaultTrashPurgeInterval, job.Interval())
+	assert.NoError(t, job.Run(context.Background()))
+}
+
+func Test_TrashPurger_Run_Failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	movies := services.NewMockMovies(ctrl)
+	job := NewTrashPurger(cfg, movies, logger.NewLogger(cfg)).(*trashPurger)
+
+	now := time.Date(2025, 8, 17, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	movies.EXPECT().
+		PurgeTrash(gomock.Any(), now.Add(-services.DefaultTrashRetention)).
+		Return(uint64(0), errors.ErrFailedToEmptyTrash)
+
+	assert.ErrorIs(t, job.Run(context.Background()), errors.ErrFailedToEmptyTrash)
+}
@@ -0,0 +1,136 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+const (
+	DefaultWatchProvidersInterval = time.Hour
+	DefaultWatchProvidersMaxAge   = 24 * time.Hour
+	DefaultWatchProvidersBatch    = 100
+)
+
+type watchProvidersRefresher struct {
+	movies   services.Movies
+	client   tmdb.Client
+	interval time.Duration
+	maxAge   time.Duration
+	batch    uint64
+	now      func() time.Time
+	log      *logger.Logger
+}
+
+// NewWatchProvidersRefresher creates a job storing the streaming services watchlist movies
+// are available on in the region of their owner
+func NewWatchProvidersRefresher(cfg *config.Config, movies services.Movies, client tmdb.Client, log *logger.Logger) Job {
+	interval := cfg.JobsConfig.WatchProvidersInterval
+	if interval <= 0 {
+		interval = DefaultWatchProvidersInterval
+	}
+
+	maxAge := cfg.JobsConfig.WatchProvidersMaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultWatchProvidersMaxAge
+	}
+
+	batch := cfg.JobsConfig.WatchProvidersBatch
+	if batch <= 0 {
+		batch = DefaultWatchProvidersBatch
+	}
+
+	return &watchProvidersRefresher{
+		movies:   movies,
+		client:   client,
+		interval: interval,
+		maxAge:   maxAge,
+		batch:    uint64(batch),
+		now:      time.Now,
+		log:      log.WithComponent("WatchProvidersRefresher"),
+	}
+}
+
+func (j *watchProvidersRefresher) Name() string {
+	return "watch_providers"
+}
+
+func (j *watchProvidersRefresher) Interval() time.Duration {
+	return j.interval
+}
+
+// Run refreshes a batch of stale movies, one TMDB request covers every row of the same title
+func (j *watchProvidersRefresher) Run(ctx context.Context) error {
+	stale, err := j.movies.FindWithStaleWatchProviders(ctx, j.client.Locale(ctx).Region, j.now().Add(-j.maxAge), j.batch)
+	if err != nil {
+		return err
+	}
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	// NOTE: tmdb id -> region -> movie row ids
+	groups := make(map[uint64]map[string][]uuid.UUID)
+	order := make([]uint64, 0)
+	for _, movie := range stale {
+		if _, exists := groups[movie.TmdbId]; !exists {
+			groups[movie.TmdbId] = make(map[string][]uuid.UUID)
+			order = append(order, movie.TmdbId)
+		}
+		groups[movie.TmdbId][movie.WatchRegion] = append(groups[movie.TmdbId][movie.WatchRegion], movie.ID)
+	}
+
+	refreshed := 0
+	for _, tmdbId := range order {
+		response, err := j.client.FetchMovieWatchProviders(ctx, tmdbId)
+		switch {
+		case errors.Is(err, tmdb.ErrUpstreamUnavailable), errors.Is(err, context.Canceled):
+			return err
+		case errors.Is(err, tmdb.ErrNotFound):
+			response = &tmdb.WatchProviders{}
+		case err != nil:
+			j.log.Warn().
+				Err(err).
+				Uint64("TmdbId", tmdbId).
+				Msg("Failed to fetch movie watch providers")
+			continue
+		}
+
+		for region, ids := range groups[tmdbId] {
+			providers := flatrateProviderIds(response, region)
+			if err := j.movies.UpdateWatchProviders(ctx, ids, region, providers); err != nil {
+				return err
+			}
+			refreshed += len(ids)
+		}
+	}
+
+	j.log.Info().
+		Int("movies", refreshed).
+		Int("titles", len(order)).
+		Msg("Refreshed movie watch providers")
+
+	return nil
+}
+
+func flatrateProviderIds(providers *tmdb.WatchProviders, region string) []int32 {
+	available, ok := providers.Results[region]
+	if !ok {
+		return []int32{}
+	}
+
+	ids := make([]int32, 0, len(available.Flatrate))
+	for _, provider := range available.Flatrate {
+		ids = append(ids, int32(provider.ProviderId))
+	}
+
+	return ids
+}
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_WatchProvidersRefresher_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	movies := services.NewMockMovies(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	job := NewWatchProvidersRefresher(cfg, movies, client, logger.NewLogger(cfg)).(*watchProvidersRefresher)
+
+	now := time.Date(2025, 6, 8, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	first, second, third, missing := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	client.EXPECT().Locale(gomock.Any()).Return(tmdb.Locale{Language: "en", Region: "US"})
+	movies.EXPECT().
+		FindWithStaleWatchProviders(gomock.Any(), "US", now.Add(-DefaultWatchProvidersMaxAge), uint64(DefaultWatchProvidersBatch)).
+		Return([]models.Movie{
+			{ID: first, TmdbId: 438631, WatchRegion: "US"},
+			{ID: second, TmdbId: 438631, WatchRegion: "US"},
+			{ID: third, TmdbId: 438631, WatchRegion: "EE"},
+			{ID: missing, TmdbId: 1, WatchRegion: "US"},
+		}, nil)
+
+	client.EXPECT().FetchMovieWatchProviders(gomock.Any(), uint64(438631)).Return(&tmdb.WatchProviders{
+		Results: map[string]tmdb.RegionWatchProviders{
+			"US": {
+				Flatrate: []tmdb.WatchProvider{{ProviderId: 1899}, {ProviderId: 8}},
+				Rent:     []tmdb.WatchProvider{{ProviderId: 2}},
+			},
+		},
+	}, nil)
+	client.EXPECT().FetchMovieWatchProviders(gomock.Any(), uint64(1)).Return(nil, tmdb.ErrNotFound)
+
+	movies.EXPECT().UpdateWatchProviders(gomock.Any(), []uuid.UUID{first, second}, "US", []int32{1899, 8}).Return(nil)
+	movies.EXPECT().UpdateWatchProviders(gomock.Any(), []uuid.UUID{third}, "EE", []int32{}).Return(nil)
+	movies.EXPECT().UpdateWatchProviders(gomock.Any(), []uuid.UUID{missing}, "US", []int32{}).Return(nil)
+
+	assert.NoError(t, job.Run(context.Background()))
+}
+
+func Test_WatchProvidersRefresher_Run_Unavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+
+	movies := services.NewMockMovies(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	job := NewWatchProvidersRefresher(cfg, movies, client, logger.NewLogger(cfg))
+
+	client.EXPECT().Locale(gomock.Any()).Return(tmdb.Locale{Language: "en", Region: "US"})
+	movies.EXPECT().
+		FindWithStaleWatchProviders(gomock.Any(), "US", gomock.Any(), gomock.Any()).
+		Return([]models.Movie{
+			{ID: uuid.New(), TmdbId: 438631, WatchRegion: "US"},
+			{ID: uuid.New(), TmdbId: 693134, WatchRegion: "US"},
+		}, nil)
+	client.EXPECT().
+		FetchMovieWatchProviders(gomock.Any(), uint64(438631)).
+		Return(nil, &tmdb.UnavailableError{RetryAfter: time.Second})
+
+	err := job.Run(context.Background())
+	assert.ErrorIs(t, err, tmdb.ErrUpstreamUnavailable)
+}
@@ -0,0 +1,35 @@
+package models
+
+const (
+	BulkOpAdd    = "add"
+	BulkOpState  = "state"
+	BulkOpPin    = "pin"
+	BulkOpUnpin  = "unpin"
+	BulkOpDelete = "delete"
+	BulkOpTag    = "tag"
+
+	BulkStatusApplied    = "applied"
+	BulkStatusFailed     = "failed"
+	BulkStatusSkipped    = "skipped"
+	BulkStatusRolledBack = "rolled_back"
+
+	MaxBulkOperations = 100
+)
+
+// BulkOperation is a single change of a bulk request. Err holds the validation failure of the operation,
+// which is then reported in its result without being applied
+type BulkOperation struct {
+	Op     string
+	TmdbId uint64
+	State  string
+	Tags   []string
+	Err    error
+}
+
+// BulkResult is the outcome of a bulk operation, Err is set once the operation failed
+type BulkResult struct {
+	Op     string
+	TmdbId uint64
+	Status string
+	Err    error
+}
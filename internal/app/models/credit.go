@@ -0,0 +1,23 @@
+package models
+
+const (
+	CreditRoleCast     = "cast"
+	CreditRoleDirector = "director"
+)
+
+// Credit is a person credited on a movie, cached when the movie is added to a library
+type Credit struct {
+	PersonId    int
+	Role        string
+	Name        string
+	ProfilePath string
+	Position    int
+}
+
+// PersonStat counts the watched movies a person is credited on
+type PersonStat struct {
+	PersonId     int
+	Name         string
+	ProfilePath  string
+	WatchedCount uint64
+}
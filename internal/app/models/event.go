@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MovieEventCreated  = "created"
+	MovieEventUpdated  = "updated"
+	MovieEventDeleted  = "deleted"
+	MovieEventRestored = "restored"
+	MovieEventPurged   = "purged"
+
+	// EventSourceApi marks changes made by the user through the API and EventSourceSync the ones of
+	// background jobs
+	EventSourceApi  = "api"
+	EventSourceSync = "sync"
+)
+
+// MovieEvent is an entry of the append-only history of a library movie. States are empty and pins nil
+// on the side of the change where the movie was not in the library, before a creation or after a purge.
+// Title and PosterPath are only loaded along the activity of a user
+type MovieEvent struct {
+	ID         uuid.UUID
+	UserId     uuid.UUID
+	TmdbId     uint64
+	Action     string
+	OldState   string
+	NewState   string
+	OldPinned  *bool
+	NewPinned  *bool
+	Source     string
+	Title      string
+	PosterPath string
+	CreatedAt  time.Time
+}
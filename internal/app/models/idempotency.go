@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxIdempotencyKeyLength caps the Idempotency-Key header, matching the column storing it
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey is a key a client sent along a mutating request so retries are answered with the response
+// of the first request instead of being applied again. Fingerprint identifies the request the key was used
+// for, Status is zero while that request is in progress
+type IdempotencyKey struct {
+	ID          uuid.UUID
+	UserId      uuid.UUID
+	Key         string
+	Fingerprint string
+	CreatedAt   time.Time
+
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// Completed tells whether the response of the request holding the key is stored and can be replayed
+func (k *IdempotencyKey) Completed() bool {
+	return k.Status != 0
+}
@@ -11,6 +11,22 @@ const (
 	StateTypeWatched  = "watched"
 	StateTypeWatching = "watching"
 	StateTypeNone     = "none"
+
+	AvailableOnMine = "mine"
+
+	MovieSortAdded   = "added"
+	MovieSortUpdated = "updated"
+	MovieSortTitle   = "title"
+	MovieSortRuntime = "runtime"
+	MovieSortManual  = "manual"
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	// TrashedRestore brings back a trashed title with its previous data when it is added again,
+	// TrashedDiscard purges it and adds the title afresh
+	TrashedRestore = "restore"
+	TrashedDiscard = "discard"
 )
 
 type Movie struct {
@@ -24,4 +40,72 @@ type Movie struct {
 	Pinned     bool
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+
+	// Progress is the number of minutes watched, kept while the movie is in the watching state
+	Progress uint64
+	// ReportedProgress is set by clients reporting progress on create and update, see WatchProgress
+	ReportedProgress *WatchProgress
+
+	// Notes and Tags are private to the owner, only loaded along a single movie
+	Notes string
+	Tags  []string
+
+	// DeletedAt is set on movies in the trash, which are purged for good at PurgeAt
+	DeletedAt *time.Time
+	PurgeAt   *time.Time
+	// Trashed tells Create what to do with the same title waiting in the trash, see TrashedRestore
+	Trashed string
+	// IncludeAdult lets Create and Upsert add adult titles, set for users who confirmed them
+	IncludeAdult bool
+
+	WatchProviders []int32
+	WatchRegion    string
+
+	// NeedsResync marks a movie stored with client supplied metadata while TMDB was unavailable
+	NeedsResync bool
+	// MissingOnTmdb marks a movie deleted on TMDB, or merged into one the user already holds
+	MissingOnTmdb bool
+}
+
+// WatchProgress is how far into a movie the user got, either in minutes or as a percentage of its runtime
+type WatchProgress struct {
+	Minutes *uint64
+	Percent *uint64
+}
+
+// MovieUpsert is the outcome of upserting a movie, Previous holds the state, pin and deletion date the
+// movie had before and is nil when it was inserted. Changed is false when the movie already was as asked
+type MovieUpsert struct {
+	Movie    *Movie
+	Previous *Movie
+	Inserted bool
+	Changed  bool
+}
+
+// MovieFilter narrows down and orders a movies list, AvailableOn keeps movies streamable on any of
+// the given providers, Tags keeps movies holding all of the given lowercased tags and Query matches
+// titles case-insensitively. Nil bounds are not applied
+type MovieFilter struct {
+	State       string
+	AvailableOn []int32
+	Pinned      *bool
+	AddedAfter  *time.Time
+	AddedBefore *time.Time
+	RuntimeMin  *uint64
+	RuntimeMax  *uint64
+	Tags        []string
+	Query       string
+
+	Sort      string
+	Direction string
+}
+
+// MovieCursor points past the last movie of a keyset page. Key holds the sort column of that movie
+// as text, so a cursor only continues the listing it was issued for
+type MovieCursor struct {
+	Sort      string
+	Direction string
+	Pinned    bool
+	Key       string
+	Id        uuid.UUID
 }
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxTagNameLength = 64
+	MaxMovieTags     = 20
+	MaxNotesLength   = 10000
+)
+
+// Tag labels movies of a library, names are unique per user regardless of case
+type Tag struct {
+	ID          uuid.UUID
+	UserId      uuid.UUID
+	Name        string
+	MoviesCount uint64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
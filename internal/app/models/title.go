@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Title is the shared catalog entry of a TMDB movie, library rows read their metadata from it
+type Title struct {
+	TmdbId        uint64
+	Title         string
+	OriginalTitle string
+	PosterPath    string
+	BackdropPath  string
+	ReleaseDate   string
+	Runtime       uint64
+	GenreIds      []int
+	Adult         bool
+	FetchedAt     *time.Time
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -18,4 +20,19 @@ type User struct {
 	FirstName         string
 	LastName          string
 	Appearance        string
+	Language          string
+	Region            string
+	WatchProviders    []int32
+
+	// Content filter overrides, nil means the server default applies
+	IncludeDocumentaries  *bool
+	IncludeWithoutArtwork *bool
+	IncludeAdult          bool
+	AdultConfirmedAt      *time.Time
+}
+
+// AdultConfirmed tells whether the user opted in to adult titles and confirmed their age,
+// the server still has to allow them
+func (u *User) AdultConfirmed() bool {
+	return u.IncludeAdult && u.AdultConfirmedAt != nil
 }
@@ -26,6 +26,7 @@ func TestMain(m *testing.M) {
 
 	tables := []string{
 		"users",
+		"titles",
 	}
 
 	err := spec.TruncateTables(ctx, cfg.DatabaseDSN, tables)
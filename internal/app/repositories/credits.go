@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories/db"
+	"biinge-api/internal/app/repositories/postgres"
+)
+
+type CreditRepository interface {
+	CacheMovieCredits(ctx context.Context, tmdbId uint64, credits []models.Credit) error
+	IsMovieCached(ctx context.Context, tmdbId uint64) (bool, error)
+	FindUncachedMovieIds(ctx context.Context, limit uint64) ([]uint64, error)
+	TopPeople(ctx context.Context, userId uuid.UUID, role string, limit uint64) ([]models.PersonStat, error)
+}
+
+type credit struct {
+	client postgres.Postgres
+}
+
+func NewCreditRepository(client postgres.Postgres) CreditRepository {
+	return &credit{client: client}
+}
+
+// CacheMovieCredits stores the credits of a movie and marks every library row of it as cached
+func (c *credit) CacheMovieCredits(ctx context.Context, tmdbId uint64, credits []models.Credit) error {
+	if len(credits) > 0 {
+		params := db.CreateMovieCreditsParams{
+			TmdbID:       tmdbId,
+			PersonIds:    make([]int32, 0, len(credits)),
+			Roles:        make([]db.CreditRoles, 0, len(credits)),
+			Names:        make([]string, 0, len(credits)),
+			ProfilePaths: make([]string, 0, len(credits)),
+			Positions:    make([]int32, 0, len(credits)),
+		}
+
+		for _, item := range credits {
+			params.PersonIds = append(params.PersonIds, int32(item.PersonId))
+			params.Roles = append(params.Roles, db.CreditRoles(item.Role))
+			params.Names = append(params.Names, item.Name)
+			params.ProfilePaths = append(params.ProfilePaths, item.ProfilePath)
+			params.Positions = append(params.Positions, int32(item.Position))
+		}
+
+		if err := c.client.Queries().CreateMovieCredits(ctx, params); err != nil {
+			return err
+		}
+	}
+
+	return c.client.Queries().MarkMovieCreditsCached(ctx, tmdbId)
+}
+
+func (c *credit) IsMovieCached(ctx context.Context, tmdbId uint64) (bool, error) {
+	return c.client.Queries().IsMovieCreditsCached(ctx, tmdbId)
+}
+
+func (c *credit) FindUncachedMovieIds(ctx context.Context, limit uint64) ([]uint64, error) {
+	return c.client.Queries().FindMoviesWithoutCredits(ctx, limit)
+}
+
+// TopPeople ranks the people credited with role by the number of movies the user watched
+func (c *credit) TopPeople(ctx context.Context, userId uuid.UUID, role string, limit uint64) ([]models.PersonStat, error) {
+	rows, err := c.client.Queries().FindTopPeopleByWatched(ctx, db.FindTopPeopleByWatchedParams{
+		UserID:     userId,
+		Role:       db.CreditRoles(role),
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	people := make([]models.PersonStat, 0, len(rows))
+	for _, row := range rows {
+		people = append(people, models.PersonStat{
+			PersonId:     int(row.PersonID),
+			Name:         row.Name,
+			ProfilePath:  row.ProfilePath,
+			WatchedCount: uint64(row.WatchedCount),
+		})
+	}
+
+	return people, nil
+}
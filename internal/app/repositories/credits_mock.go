@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/repositories/credits.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/repositories/credits.go -destination=internal/app/repositories/credits_mock.go -package=repositories
+//
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	models "biinge-api/internal/app/models"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCreditRepository is a mock of CreditRepository interface.
+type MockCreditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCreditRepositoryMockRecorder is the mock recorder for MockCreditRepository.
+type MockCreditRepositoryMockRecorder struct {
+	mock *MockCreditRepository
+}
+
+// NewMockCreditRepository creates a new mock instance.
+func NewMockCreditRepository(ctrl *gomock.Controller) *MockCreditRepository {
+	mock := &MockCreditRepository{ctrl: ctrl}
+	mock.recorder = &MockCreditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditRepository) EXPECT() *MockCreditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CacheMovieCredits mocks base method.
+func (m *MockCreditRepository) CacheMovieCredits(ctx context.Context, tmdbId uint64, credits []models.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheMovieCredits", ctx, tmdbId, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CacheMovieCredits indicates an expected call of CacheMovieCredits.
+func (mr *MockCreditRepositoryMockRecorder) CacheMovieCredits(ctx, tmdbId, credits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheMovieCredits", reflect.TypeOf((*MockCreditRepository)(nil).CacheMovieCredits), ctx, tmdbId, credits)
+}
+
+// FindUncachedMovieIds mocks base method.
+func (m *MockCreditRepository) FindUncachedMovieIds(ctx context.Context, limit uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUncachedMovieIds", ctx, limit)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUncachedMovieIds indicates an expected call of FindUncachedMovieIds.
+func (mr *MockCreditRepositoryMockRecorder) FindUncachedMovieIds(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUncachedMovieIds", reflect.TypeOf((*MockCreditRepository)(nil).FindUncachedMovieIds), ctx, limit)
+}
+
+// IsMovieCached mocks base method.
+func (m *MockCreditRepository) IsMovieCached(ctx context.Context, tmdbId uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMovieCached", ctx, tmdbId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMovieCached indicates an expected call of IsMovieCached.
+func (mr *MockCreditRepositoryMockRecorder) IsMovieCached(ctx, tmdbId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMovieCached", reflect.TypeOf((*MockCreditRepository)(nil).IsMovieCached), ctx, tmdbId)
+}
+
+// TopPeople mocks base method.
+func (m *MockCreditRepository) TopPeople(ctx context.Context, userId uuid.UUID, role string, limit uint64) ([]models.PersonStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopPeople", ctx, userId, role, limit)
+	ret0, _ := ret[0].([]models.PersonStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopPeople indicates an expected call of TopPeople.
+func (mr *MockCreditRepositoryMockRecorder) TopPeople(ctx, userId, role, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopPeople", reflect.TypeOf((*MockCreditRepository)(nil).TopPeople), ctx, userId, role, limit)
+}
@@ -55,6 +55,136 @@ func (ns NullAppearanceType) Value() (driver.Value, error) {
 	return string(ns.AppearanceType), nil
 }
 
+type CreditRoles string
+
+const (
+	CreditRolesCast     CreditRoles = "cast"
+	CreditRolesDirector CreditRoles = "director"
+)
+
+func (e *CreditRoles) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CreditRoles(s)
+	case string:
+		*e = CreditRoles(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CreditRoles: %T", src)
+	}
+	return nil
+}
+
+type NullCreditRoles struct {
+	CreditRoles CreditRoles
+	Valid       bool // Valid is true if CreditRoles is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCreditRoles) Scan(value interface{}) error {
+	if value == nil {
+		ns.CreditRoles, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CreditRoles.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCreditRoles) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CreditRoles), nil
+}
+
+type EventActions string
+
+const (
+	EventActionsCreated  EventActions = "created"
+	EventActionsUpdated  EventActions = "updated"
+	EventActionsDeleted  EventActions = "deleted"
+	EventActionsRestored EventActions = "restored"
+	EventActionsPurged   EventActions = "purged"
+)
+
+func (e *EventActions) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EventActions(s)
+	case string:
+		*e = EventActions(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EventActions: %T", src)
+	}
+	return nil
+}
+
+type NullEventActions struct {
+	EventActions EventActions
+	Valid        bool // Valid is true if EventActions is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEventActions) Scan(value interface{}) error {
+	if value == nil {
+		ns.EventActions, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EventActions.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEventActions) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EventActions), nil
+}
+
+type EventSources string
+
+const (
+	EventSourcesApi    EventSources = "api"
+	EventSourcesImport EventSources = "import"
+	EventSourcesSync   EventSources = "sync"
+)
+
+func (e *EventSources) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EventSources(s)
+	case string:
+		*e = EventSources(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EventSources: %T", src)
+	}
+	return nil
+}
+
+type NullEventSources struct {
+	EventSources EventSources
+	Valid        bool // Valid is true if EventSources is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEventSources) Scan(value interface{}) error {
+	if value == nil {
+		ns.EventSources, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EventSources.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEventSources) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EventSources), nil
+}
+
 type StateTypes string
 
 const (
@@ -99,28 +229,108 @@ func (ns NullStateTypes) Value() (driver.Value, error) {
 	return string(ns.StateTypes), nil
 }
 
+type IdempotencyKey struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Key         string
+	Fingerprint string
+	Status      pgtype.Int4
+	ContentType string
+	Body        []byte
+	CreatedAt   pgtype.Timestamp
+	CompletedAt pgtype.Timestamp
+}
+
 type Movie struct {
-	ID         uuid.UUID
-	UserID     uuid.UUID
-	TmdbID     uint64
-	Title      string
-	PosterPath string
-	Runtime    uint64
-	State      StateTypes
-	Pinned     bool
-	CreatedAt  pgtype.Timestamp
-	UpdatedAt  pgtype.Timestamp
+	ID                   uuid.UUID
+	UserID               uuid.UUID
+	TmdbID               uint64
+	Title                string
+	PosterPath           string
+	Runtime              uint64
+	State                StateTypes
+	Pinned               bool
+	CreatedAt            pgtype.Timestamp
+	UpdatedAt            pgtype.Timestamp
+	WatchProviders       []int32
+	WatchRegion          string
+	ProvidersRefreshedAt pgtype.Timestamp
+	CreditsCachedAt      pgtype.Timestamp
+	NeedsResync          bool
+	MissingOnTmdb        bool
+	Position             int64
+	Progress             uint64
+	Notes                string
+	DeletedAt            pgtype.Timestamp
+}
+
+type MovieTag struct {
+	MovieID   uuid.UUID
+	TagID     uuid.UUID
+	CreatedAt pgtype.Timestamp
+}
+
+type MovieCredit struct {
+	TmdbID      uint64
+	PersonID    int32
+	Role        CreditRoles
+	Name        string
+	ProfilePath string
+	Position    int32
+	CreatedAt   pgtype.Timestamp
+}
+
+type MovieEvent struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TmdbID    uint64
+	Action    EventActions
+	OldState  NullStateTypes
+	NewState  NullStateTypes
+	OldPinned pgtype.Bool
+	NewPinned pgtype.Bool
+	Source    EventSources
+	CreatedAt pgtype.Timestamp
+}
+
+type Tag struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type Title struct {
+	TmdbID    uint64
+	Metadata  []byte
+	FetchedAt pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type TmdbChangeCursor struct {
+	Feed        string
+	SyncedUntil pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
 }
 
 type User struct {
-	ID                uuid.UUID
-	Login             string
-	Email             string
-	EncryptedPassword string
-	FirstName         string
-	LastName          string
-	Appearance        AppearanceType
-	DeletedAt         pgtype.Timestamp
-	CreatedAt         pgtype.Timestamp
-	UpdatedAt         pgtype.Timestamp
+	ID                    uuid.UUID
+	Login                 string
+	Email                 string
+	EncryptedPassword     string
+	FirstName             string
+	LastName              string
+	Appearance            AppearanceType
+	DeletedAt             pgtype.Timestamp
+	CreatedAt             pgtype.Timestamp
+	UpdatedAt             pgtype.Timestamp
+	Language              string
+	Region                string
+	WatchProviders        []int32
+	IncludeDocumentaries  pgtype.Bool
+	IncludeWithoutArtwork pgtype.Bool
+	IncludeAdult          bool
+	AdultConfirmedAt      pgtype.Timestamp
 }
@@ -12,37 +12,62 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTrashedMovies = `-- name: CountTrashedMovies :one
+SELECT COUNT(*)
+FROM movies
+WHERE user_id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) CountTrashedMovies(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countTrashedMovies, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createMovie = `-- name: CreateMovie :one
-INSERT INTO movies (
-  user_id,
-  tmdb_id,
-  title,
-  poster_path,
-  runtime,
-  state
-) VALUES (
-  $1, $2, $3, $4, $5, $6
+WITH inserted AS (
+  INSERT INTO movies (
+    user_id,
+    tmdb_id,
+    title,
+    poster_path,
+    runtime,
+    state,
+    needs_resync,
+    progress,
+    position
+  ) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8,
+    (SELECT COALESCE(MIN(position), 0) - 65536 FROM movies WHERE user_id = $1)
+  )
+  RETURNING *
 )
-RETURNING
-  id,
-  user_id,
-  tmdb_id,
-  title,
-  poster_path,
-  pinned,
-  runtime,
-  state,
-  created_at,
-  updated_at
+SELECT
+  i.id,
+  i.user_id,
+  i.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), i.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', i.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, i.runtime)::integer AS runtime,
+  i.pinned,
+  i.state,
+  i.created_at,
+  i.updated_at,
+  i.progress
+FROM inserted i
+LEFT JOIN titles t ON t.tmdb_id = i.tmdb_id
 `
 
 type CreateMovieParams struct {
-	UserID     uuid.UUID
-	TmdbID     uint64
-	Title      string
-	PosterPath string
-	Runtime    uint64
-	State      StateTypes
+	UserID      uuid.UUID
+	TmdbID      uint64
+	Title       string
+	PosterPath  string
+	Runtime     uint64
+	State       StateTypes
+	NeedsResync bool
+	Progress    uint64
 }
 
 type CreateMovieRow struct {
@@ -51,11 +76,12 @@ type CreateMovieRow struct {
 	TmdbID     uint64
 	Title      string
 	PosterPath string
+	Runtime    int32
 	Pinned     bool
-	Runtime    uint64
 	State      StateTypes
 	CreatedAt  pgtype.Timestamp
 	UpdatedAt  pgtype.Timestamp
+	Progress   uint64
 }
 
 func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (CreateMovieRow, error) {
@@ -66,6 +92,8 @@ func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (Creat
 		arg.PosterPath,
 		arg.Runtime,
 		arg.State,
+		arg.NeedsResync,
+		arg.Progress,
 	)
 	var i CreateMovieRow
 	err := row.Scan(
@@ -74,11 +102,12 @@ func (q *Queries) CreateMovie(ctx context.Context, arg CreateMovieParams) (Creat
 		&i.TmdbID,
 		&i.Title,
 		&i.PosterPath,
-		&i.Pinned,
 		&i.Runtime,
+		&i.Pinned,
 		&i.State,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Progress,
 	)
 	return i, err
 }
@@ -106,20 +135,44 @@ func (q *Queries) DeleteMovieByTmdbId(ctx context.Context, arg DeleteMovieByTmdb
 	return err
 }
 
+const emptyTrash = `-- name: EmptyTrash :execrows
+WITH purged AS (
+  DELETE FROM movies WHERE user_id = $1 AND deleted_at IS NOT NULL
+  RETURNING user_id, tmdb_id, state, pinned
+)
+INSERT INTO movie_events (user_id, tmdb_id, action, old_state, old_pinned, source)
+SELECT user_id, tmdb_id, 'purged', state, pinned, $2
+FROM purged
+`
+
+type EmptyTrashParams struct {
+	UserID uuid.UUID
+	Source EventSources
+}
+
+func (q *Queries) EmptyTrash(ctx context.Context, arg EmptyTrashParams) (int64, error) {
+	result, err := q.db.Exec(ctx, emptyTrash, arg.UserID, arg.Source)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const findMovieById = `-- name: FindMovieById :one
 SELECT
-  id,
-  user_id,
-  tmdb_id,
-  title,
-  poster_path,
-  runtime,
-  pinned,
-  state,
-  created_at,
-  updated_at
-FROM movies
-WHERE id = $1 LIMIT 1
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.id = $1 AND m.deleted_at IS NULL LIMIT 1
 `
 
 type FindMovieByIdRow struct {
@@ -128,7 +181,7 @@ type FindMovieByIdRow struct {
 	TmdbID     uint64
 	Title      string
 	PosterPath string
-	Runtime    uint64
+	Runtime    int32
 	Pinned     bool
 	State      StateTypes
 	CreatedAt  pgtype.Timestamp
@@ -155,18 +208,28 @@ func (q *Queries) FindMovieById(ctx context.Context, id uuid.UUID) (FindMovieByI
 
 const findMovieByTmdbId = `-- name: FindMovieByTmdbId :one
 SELECT
-  id,
-  user_id,
-  tmdb_id,
-  title,
-  poster_path,
-  runtime,
-  pinned,
-  state,
-  created_at,
-  updated_at
-FROM movies
-WHERE tmdb_id = $1 AND user_id = $2 LIMIT 1
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at,
+  m.progress,
+  m.notes,
+  ARRAY(
+    SELECT tg.name
+    FROM movie_tags mt
+    JOIN tags tg ON tg.id = mt.tag_id
+    WHERE mt.movie_id = m.id
+    ORDER BY lower(tg.name)
+  )::varchar[] AS tags
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.tmdb_id = $1 AND m.user_id = $2 AND m.deleted_at IS NULL LIMIT 1
 `
 
 type FindMovieByTmdbIdParams struct {
@@ -180,11 +243,14 @@ type FindMovieByTmdbIdRow struct {
 	TmdbID     uint64
 	Title      string
 	PosterPath string
-	Runtime    uint64
+	Runtime    int32
 	Pinned     bool
 	State      StateTypes
 	CreatedAt  pgtype.Timestamp
 	UpdatedAt  pgtype.Timestamp
+	Progress   uint64
+	Notes      string
+	Tags       []string
 }
 
 func (q *Queries) FindMovieByTmdbId(ctx context.Context, arg FindMovieByTmdbIdParams) (FindMovieByTmdbIdRow, error) {
@@ -201,68 +267,83 @@ func (q *Queries) FindMovieByTmdbId(ctx context.Context, arg FindMovieByTmdbIdPa
 		&i.State,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Progress,
+		&i.Notes,
+		&i.Tags,
 	)
 	return i, err
 }
 
-const findMoviesByState = `-- name: FindMoviesByState :many
-WITH counter AS (
-  SELECT COUNT(*) AS total
-  FROM movies
-  WHERE user_id = $1 AND state = $2
-)
+const findMoviePosition = `-- name: FindMoviePosition :one
+SELECT
+  id,
+  position
+FROM movies
+WHERE tmdb_id = $1 AND user_id = $2 AND deleted_at IS NULL LIMIT 1
+FOR UPDATE
+`
+
+type FindMoviePositionParams struct {
+	TmdbID uint64
+	UserID uuid.UUID
+}
+
+type FindMoviePositionRow struct {
+	ID       uuid.UUID
+	Position int64
+}
+
+func (q *Queries) FindMoviePosition(ctx context.Context, arg FindMoviePositionParams) (FindMoviePositionRow, error) {
+	row := q.db.QueryRow(ctx, findMoviePosition, arg.TmdbID, arg.UserID)
+	var i FindMoviePositionRow
+	err := row.Scan(&i.ID, &i.Position)
+	return i, err
+}
+
+const findMoviesByTmdbIds = `-- name: FindMoviesByTmdbIds :many
 SELECT
   m.id,
   m.user_id,
   m.tmdb_id,
-  m.title,
-  m.poster_path,
-  m.runtime,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
   m.pinned,
   m.state,
   m.created_at,
-  m.updated_at,
-  counter.total
-FROM movies m CROSS JOIN counter
-WHERE m.user_id = $1 AND m.state = $2
-ORDER BY m.pinned DESC, m.created_at DESC LIMIT $3 OFFSET $4
+  m.updated_at
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.tmdb_id = ANY($1::integer[]) AND m.user_id = $2 AND m.deleted_at IS NULL
 `
 
-type FindMoviesByStateParams struct {
-	UserID uuid.UUID
-	State  StateTypes
-	Limit  uint64
-	Offset uint64
+type FindMoviesByTmdbIdsParams struct {
+	TmdbIds []uint64
+	UserID  uuid.UUID
 }
 
-type FindMoviesByStateRow struct {
+type FindMoviesByTmdbIdsRow struct {
 	ID         uuid.UUID
 	UserID     uuid.UUID
 	TmdbID     uint64
 	Title      string
 	PosterPath string
-	Runtime    uint64
+	Runtime    int32
 	Pinned     bool
 	State      StateTypes
 	CreatedAt  pgtype.Timestamp
 	UpdatedAt  pgtype.Timestamp
-	Total      uint64
 }
 
-func (q *Queries) FindMoviesByState(ctx context.Context, arg FindMoviesByStateParams) ([]FindMoviesByStateRow, error) {
-	rows, err := q.db.Query(ctx, findMoviesByState,
-		arg.UserID,
-		arg.State,
-		arg.Limit,
-		arg.Offset,
-	)
+func (q *Queries) FindMoviesByTmdbIds(ctx context.Context, arg FindMoviesByTmdbIdsParams) ([]FindMoviesByTmdbIdsRow, error) {
+	rows, err := q.db.Query(ctx, findMoviesByTmdbIds, arg.TmdbIds, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FindMoviesByStateRow
+	var items []FindMoviesByTmdbIdsRow
 	for rows.Next() {
-		var i FindMoviesByStateRow
+		var i FindMoviesByTmdbIdsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -274,7 +355,6 @@ func (q *Queries) FindMoviesByState(ctx context.Context, arg FindMoviesByStatePa
 			&i.State,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.Total,
 		); err != nil {
 			return nil, err
 		}
@@ -286,49 +366,206 @@ func (q *Queries) FindMoviesByState(ctx context.Context, arg FindMoviesByStatePa
 	return items, nil
 }
 
-const findMoviesByTmdbIds = `-- name: FindMoviesByTmdbIds :many
+const findMoviesWithStaleProviders = `-- name: FindMoviesWithStaleProviders :many
 SELECT
-  id,
-  user_id,
-  tmdb_id,
-  title,
-  poster_path,
-  runtime,
-  pinned,
-  state,
-  created_at,
-  updated_at
+  m.id,
+  m.tmdb_id,
+  COALESCE(NULLIF(u.region, ''), $1::varchar)::varchar AS region
+FROM movies m
+JOIN users u ON u.id = m.user_id
+WHERE m.state = 'want' AND m.deleted_at IS NULL AND u.deleted_at IS NULL AND (
+  m.providers_refreshed_at IS NULL
+  OR m.providers_refreshed_at < $2
+  OR m.watch_region <> COALESCE(NULLIF(u.region, ''), $1::varchar)
+)
+ORDER BY m.providers_refreshed_at NULLS FIRST
+LIMIT $3
+`
+
+type FindMoviesWithStaleProvidersParams struct {
+	DefaultRegion   string
+	RefreshedBefore pgtype.Timestamp
+	BatchSize       uint64
+}
+
+type FindMoviesWithStaleProvidersRow struct {
+	ID     uuid.UUID
+	TmdbID uint64
+	Region string
+}
+
+func (q *Queries) FindMoviesWithStaleProviders(ctx context.Context, arg FindMoviesWithStaleProvidersParams) ([]FindMoviesWithStaleProvidersRow, error) {
+	rows, err := q.db.Query(ctx, findMoviesWithStaleProviders, arg.DefaultRegion, arg.RefreshedBefore, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindMoviesWithStaleProvidersRow
+	for rows.Next() {
+		var i FindMoviesWithStaleProvidersRow
+		if err := rows.Scan(&i.ID, &i.TmdbID, &i.Region); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findNextMoviePosition = `-- name: FindNextMoviePosition :one
+SELECT position
 FROM movies
-WHERE tmdb_id = ANY($1::integer[]) AND user_id = $2
+WHERE user_id = $1 AND position > $2 AND id <> $3 AND deleted_at IS NULL
+ORDER BY position LIMIT 1
+FOR UPDATE
 `
 
-type FindMoviesByTmdbIdsParams struct {
-	TmdbIds []uint64
-	UserID  uuid.UUID
+type FindNextMoviePositionParams struct {
+	UserID    uuid.UUID
+	Position  int64
+	ExcludeID uuid.UUID
 }
 
-type FindMoviesByTmdbIdsRow struct {
+func (q *Queries) FindNextMoviePosition(ctx context.Context, arg FindNextMoviePositionParams) (int64, error) {
+	row := q.db.QueryRow(ctx, findNextMoviePosition, arg.UserID, arg.Position, arg.ExcludeID)
+	var position int64
+	err := row.Scan(&position)
+	return position, err
+}
+
+const findPreviousMoviePosition = `-- name: FindPreviousMoviePosition :one
+SELECT position
+FROM movies
+WHERE user_id = $1 AND position < $2 AND id <> $3 AND deleted_at IS NULL
+ORDER BY position DESC LIMIT 1
+FOR UPDATE
+`
+
+type FindPreviousMoviePositionParams struct {
+	UserID    uuid.UUID
+	Position  int64
+	ExcludeID uuid.UUID
+}
+
+func (q *Queries) FindPreviousMoviePosition(ctx context.Context, arg FindPreviousMoviePositionParams) (int64, error) {
+	row := q.db.QueryRow(ctx, findPreviousMoviePosition, arg.UserID, arg.Position, arg.ExcludeID)
+	var position int64
+	err := row.Scan(&position)
+	return position, err
+}
+
+const findTrashedMovieByTmdbId = `-- name: FindTrashedMovieByTmdbId :one
+SELECT
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at,
+  m.progress,
+  m.deleted_at
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.tmdb_id = $1 AND m.user_id = $2 AND m.deleted_at IS NOT NULL LIMIT 1
+`
+
+type FindTrashedMovieByTmdbIdParams struct {
+	TmdbID uint64
+	UserID uuid.UUID
+}
+
+type FindTrashedMovieByTmdbIdRow struct {
 	ID         uuid.UUID
 	UserID     uuid.UUID
 	TmdbID     uint64
 	Title      string
 	PosterPath string
-	Runtime    uint64
+	Runtime    int32
 	Pinned     bool
 	State      StateTypes
 	CreatedAt  pgtype.Timestamp
 	UpdatedAt  pgtype.Timestamp
+	Progress   uint64
+	DeletedAt  pgtype.Timestamp
 }
 
-func (q *Queries) FindMoviesByTmdbIds(ctx context.Context, arg FindMoviesByTmdbIdsParams) ([]FindMoviesByTmdbIdsRow, error) {
-	rows, err := q.db.Query(ctx, findMoviesByTmdbIds, arg.TmdbIds, arg.UserID)
+func (q *Queries) FindTrashedMovieByTmdbId(ctx context.Context, arg FindTrashedMovieByTmdbIdParams) (FindTrashedMovieByTmdbIdRow, error) {
+	row := q.db.QueryRow(ctx, findTrashedMovieByTmdbId, arg.TmdbID, arg.UserID)
+	var i FindTrashedMovieByTmdbIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TmdbID,
+		&i.Title,
+		&i.PosterPath,
+		&i.Runtime,
+		&i.Pinned,
+		&i.State,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Progress,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const findTrashedMovies = `-- name: FindTrashedMovies :many
+SELECT
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), m.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', m.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, m.runtime)::integer AS runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at,
+  m.progress,
+  m.deleted_at
+FROM movies m
+LEFT JOIN titles t ON t.tmdb_id = m.tmdb_id
+WHERE m.user_id = $1 AND m.deleted_at IS NOT NULL
+ORDER BY m.deleted_at DESC, m.id
+LIMIT $2 OFFSET $3
+`
+
+type FindTrashedMoviesParams struct {
+	UserID      uuid.UUID
+	MaxResults  uint64
+	SkipResults uint64
+}
+
+type FindTrashedMoviesRow struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	TmdbID     uint64
+	Title      string
+	PosterPath string
+	Runtime    int32
+	Pinned     bool
+	State      StateTypes
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+	Progress   uint64
+	DeletedAt  pgtype.Timestamp
+}
+
+func (q *Queries) FindTrashedMovies(ctx context.Context, arg FindTrashedMoviesParams) ([]FindTrashedMoviesRow, error) {
+	rows, err := q.db.Query(ctx, findTrashedMovies, arg.UserID, arg.MaxResults, arg.SkipResults)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FindMoviesByTmdbIdsRow
+	var items []FindTrashedMoviesRow
 	for rows.Next() {
-		var i FindMoviesByTmdbIdsRow
+		var i FindTrashedMoviesRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -340,6 +577,8 @@ func (q *Queries) FindMoviesByTmdbIds(ctx context.Context, arg FindMoviesByTmdbI
 			&i.State,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Progress,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -351,6 +590,83 @@ func (q *Queries) FindMoviesByTmdbIds(ctx context.Context, arg FindMoviesByTmdbI
 	return items, nil
 }
 
+const purgeTrashedMovies = `-- name: PurgeTrashedMovies :execrows
+WITH purged AS (
+  DELETE FROM movies WHERE deleted_at < $1
+  RETURNING user_id, tmdb_id, state, pinned
+)
+INSERT INTO movie_events (user_id, tmdb_id, action, old_state, old_pinned, source)
+SELECT user_id, tmdb_id, 'purged', state, pinned, $2
+FROM purged
+`
+
+type PurgeTrashedMoviesParams struct {
+	DeletedBefore pgtype.Timestamp
+	Source        EventSources
+}
+
+func (q *Queries) PurgeTrashedMovies(ctx context.Context, arg PurgeTrashedMoviesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeTrashedMovies, arg.DeletedBefore, arg.Source)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const rebalanceMoviePositions = `-- name: RebalanceMoviePositions :exec
+UPDATE movies m
+SET position = r.rank * 65536
+FROM (
+  SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS rank
+  FROM movies
+  WHERE user_id = $1
+) r
+WHERE m.id = r.id AND m.position <> r.rank * 65536
+`
+
+func (q *Queries) RebalanceMoviePositions(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, rebalanceMoviePositions, userID)
+	return err
+}
+
+const restoreMovieByTmdbId = `-- name: RestoreMovieByTmdbId :execrows
+UPDATE movies
+SET deleted_at = NULL
+WHERE tmdb_id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+`
+
+type RestoreMovieByTmdbIdParams struct {
+	TmdbID uint64
+	UserID uuid.UUID
+}
+
+func (q *Queries) RestoreMovieByTmdbId(ctx context.Context, arg RestoreMovieByTmdbIdParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreMovieByTmdbId, arg.TmdbID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const trashMovieByTmdbId = `-- name: TrashMovieByTmdbId :execrows
+UPDATE movies
+SET deleted_at = NOW()
+WHERE tmdb_id = $1 AND user_id = $2 AND deleted_at IS NULL
+`
+
+type TrashMovieByTmdbIdParams struct {
+	TmdbID uint64
+	UserID uuid.UUID
+}
+
+func (q *Queries) TrashMovieByTmdbId(ctx context.Context, arg TrashMovieByTmdbIdParams) (int64, error) {
+	result, err := q.db.Exec(ctx, trashMovieByTmdbId, arg.TmdbID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateMovie = `-- name: UpdateMovie :one
 UPDATE movies
 SET
@@ -416,30 +732,38 @@ func (q *Queries) UpdateMovie(ctx context.Context, arg UpdateMovieParams) (Updat
 }
 
 const updateMovieByTmdbId = `-- name: UpdateMovieByTmdbId :one
-UPDATE movies
-SET
-  state = $3,
-  pinned = $4,
-  updated_at = NOW()
-WHERE tmdb_id = $1 AND user_id = $2
-RETURNING
-  id,
-  user_id,
-  tmdb_id,
-  title,
-  poster_path,
-  runtime,
-  pinned,
-  state,
-  created_at,
-  updated_at
+WITH updated AS (
+  UPDATE movies
+  SET
+    state = $3,
+    pinned = $4,
+    progress = $5,
+    updated_at = NOW()
+  WHERE movies.tmdb_id = $1 AND movies.user_id = $2 AND movies.deleted_at IS NULL
+  RETURNING *
+)
+SELECT
+  u.id,
+  u.user_id,
+  u.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), u.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', u.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, u.runtime)::integer AS runtime,
+  u.pinned,
+  u.state,
+  u.created_at,
+  u.updated_at,
+  u.progress
+FROM updated u
+LEFT JOIN titles t ON t.tmdb_id = u.tmdb_id
 `
 
 type UpdateMovieByTmdbIdParams struct {
-	TmdbID uint64
-	UserID uuid.UUID
-	State  StateTypes
-	Pinned bool
+	TmdbID   uint64
+	UserID   uuid.UUID
+	State    StateTypes
+	Pinned   bool
+	Progress uint64
 }
 
 type UpdateMovieByTmdbIdRow struct {
@@ -448,11 +772,12 @@ type UpdateMovieByTmdbIdRow struct {
 	TmdbID     uint64
 	Title      string
 	PosterPath string
-	Runtime    uint64
+	Runtime    int32
 	Pinned     bool
 	State      StateTypes
 	CreatedAt  pgtype.Timestamp
 	UpdatedAt  pgtype.Timestamp
+	Progress   uint64
 }
 
 func (q *Queries) UpdateMovieByTmdbId(ctx context.Context, arg UpdateMovieByTmdbIdParams) (UpdateMovieByTmdbIdRow, error) {
@@ -461,6 +786,7 @@ func (q *Queries) UpdateMovieByTmdbId(ctx context.Context, arg UpdateMovieByTmdb
 		arg.UserID,
 		arg.State,
 		arg.Pinned,
+		arg.Progress,
 	)
 	var i UpdateMovieByTmdbIdRow
 	err := row.Scan(
@@ -474,6 +800,210 @@ func (q *Queries) UpdateMovieByTmdbId(ctx context.Context, arg UpdateMovieByTmdb
 		&i.State,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Progress,
+	)
+	return i, err
+}
+
+const updateMovieNotes = `-- name: UpdateMovieNotes :execrows
+UPDATE movies
+SET
+  notes = $3,
+  updated_at = NOW()
+WHERE tmdb_id = $1 AND user_id = $2 AND deleted_at IS NULL
+`
+
+type UpdateMovieNotesParams struct {
+	TmdbID uint64
+	UserID uuid.UUID
+	Notes  string
+}
+
+func (q *Queries) UpdateMovieNotes(ctx context.Context, arg UpdateMovieNotesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateMovieNotes, arg.TmdbID, arg.UserID, arg.Notes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateMoviePosition = `-- name: UpdateMoviePosition :exec
+UPDATE movies
+SET position = $2
+WHERE id = $1
+`
+
+type UpdateMoviePositionParams struct {
+	ID       uuid.UUID
+	Position int64
+}
+
+func (q *Queries) UpdateMoviePosition(ctx context.Context, arg UpdateMoviePositionParams) error {
+	_, err := q.db.Exec(ctx, updateMoviePosition, arg.ID, arg.Position)
+	return err
+}
+
+const updateMoviesWatchProviders = `-- name: UpdateMoviesWatchProviders :exec
+UPDATE movies
+SET
+  watch_providers = $1,
+  watch_region = $2,
+  providers_refreshed_at = NOW()
+WHERE id = ANY($3::uuid[])
+`
+
+type UpdateMoviesWatchProvidersParams struct {
+	WatchProviders []int32
+	WatchRegion    string
+	Ids            []uuid.UUID
+}
+
+func (q *Queries) UpdateMoviesWatchProviders(ctx context.Context, arg UpdateMoviesWatchProvidersParams) error {
+	_, err := q.db.Exec(ctx, updateMoviesWatchProviders, arg.WatchProviders, arg.WatchRegion, arg.Ids)
+	return err
+}
+
+const upsertMovie = `-- name: UpsertMovie :one
+WITH previous AS (
+  SELECT state, pinned, deleted_at
+  FROM movies
+  WHERE user_id = $1 AND tmdb_id = $2
+  FOR UPDATE
+), upserted AS (
+  INSERT INTO movies (
+    user_id,
+    tmdb_id,
+    title,
+    poster_path,
+    runtime,
+    state,
+    pinned,
+    needs_resync,
+    progress,
+    position
+  ) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    (SELECT COALESCE(MIN(position), 0) - 65536 FROM movies WHERE user_id = $1)
+  )
+  ON CONFLICT (user_id, tmdb_id) DO UPDATE
+  SET
+    state = EXCLUDED.state,
+    pinned = EXCLUDED.pinned,
+    progress = CASE
+      WHEN $10::boolean AND movies.state = 'watching' AND EXCLUDED.state = 'watching' THEN movies.progress
+      ELSE EXCLUDED.progress
+    END,
+    deleted_at = NULL,
+    updated_at = NOW()
+  WHERE movies.deleted_at IS NOT NULL
+    OR movies.state <> EXCLUDED.state
+    OR movies.pinned <> EXCLUDED.pinned
+    OR movies.progress <> CASE
+      WHEN $10::boolean AND movies.state = 'watching' AND EXCLUDED.state = 'watching' THEN movies.progress
+      ELSE EXCLUDED.progress
+    END
+  RETURNING *, (xmax = 0) AS inserted
+), saved AS (
+  SELECT id, user_id, tmdb_id, title, poster_path, runtime, pinned, state, created_at, updated_at, progress, inserted, true AS changed
+  FROM upserted
+  UNION ALL
+  SELECT id, user_id, tmdb_id, title, poster_path, runtime, pinned, state, created_at, updated_at, progress, false, false
+  FROM movies
+  WHERE user_id = $1 AND tmdb_id = $2 AND NOT EXISTS (SELECT 1 FROM upserted)
+)
+SELECT
+  u.id,
+  u.user_id,
+  u.tmdb_id,
+  COALESCE(NULLIF(t.metadata->>'title', ''), u.title)::varchar AS title,
+  COALESCE(t.metadata->>'poster_path', u.poster_path, '')::varchar AS poster_path,
+  COALESCE((t.metadata->>'runtime')::integer, u.runtime)::integer AS runtime,
+  u.pinned,
+  u.state,
+  u.created_at,
+  u.updated_at,
+  u.progress,
+  u.inserted::boolean AS inserted,
+  u.changed::boolean AS changed,
+  p.state AS previous_state,
+  p.pinned AS previous_pinned,
+  p.deleted_at AS previous_deleted_at
+FROM saved u
+LEFT JOIN previous p ON TRUE
+LEFT JOIN titles t ON t.tmdb_id = u.tmdb_id
+`
+
+type UpsertMovieParams struct {
+	UserID       uuid.UUID
+	TmdbID       uint64
+	Title        string
+	PosterPath   string
+	Runtime      uint64
+	State        StateTypes
+	Pinned       bool
+	NeedsResync  bool
+	Progress     uint64
+	KeepProgress bool
+}
+
+type UpsertMovieRow struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	TmdbID            uint64
+	Title             string
+	PosterPath        string
+	Runtime           int32
+	Pinned            bool
+	State             StateTypes
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
+	Progress          uint64
+	Inserted          bool
+	Changed           bool
+	PreviousState     NullStateTypes
+	PreviousPinned    pgtype.Bool
+	PreviousDeletedAt pgtype.Timestamp
+}
+
+func (q *Queries) UpsertMovie(ctx context.Context, arg UpsertMovieParams) (UpsertMovieRow, error) {
+	row := q.db.QueryRow(ctx, upsertMovie,
+		arg.UserID,
+		arg.TmdbID,
+		arg.Title,
+		arg.PosterPath,
+		arg.Runtime,
+		arg.State,
+		arg.Pinned,
+		arg.NeedsResync,
+		arg.Progress,
+		arg.KeepProgress,
+	)
+	var i UpsertMovieRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TmdbID,
+		&i.Title,
+		&i.PosterPath,
+		&i.Runtime,
+		&i.Pinned,
+		&i.State,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Progress,
+		&i.Inserted,
+		&i.Changed,
+		&i.PreviousState,
+		&i.PreviousPinned,
+		&i.PreviousDeletedAt,
 	)
 	return i, err
 }
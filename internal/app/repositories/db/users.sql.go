@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createUser = `-- name: CreateUser :one
@@ -21,7 +22,7 @@ INSERT INTO users (
   appearance
 ) VALUES (
   $1, $2, $3, $4, $5, $6
-) RETURNING id, login, email, first_name, last_name, appearance
+) RETURNING id, login, email, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at
 `
 
 type CreateUserParams struct {
@@ -34,12 +35,19 @@ type CreateUserParams struct {
 }
 
 type CreateUserRow struct {
-	ID         uuid.UUID
-	Login      string
-	Email      string
-	FirstName  string
-	LastName   string
-	Appearance AppearanceType
+	ID                    uuid.UUID
+	Login                 string
+	Email                 string
+	FirstName             string
+	LastName              string
+	Appearance            AppearanceType
+	Language              string
+	Region                string
+	WatchProviders        []int32
+	IncludeDocumentaries  pgtype.Bool
+	IncludeWithoutArtwork pgtype.Bool
+	IncludeAdult          bool
+	AdultConfirmedAt      pgtype.Timestamp
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
@@ -59,24 +67,38 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		&i.FirstName,
 		&i.LastName,
 		&i.Appearance,
+		&i.Language,
+		&i.Region,
+		&i.WatchProviders,
+		&i.IncludeDocumentaries,
+		&i.IncludeWithoutArtwork,
+		&i.IncludeAdult,
+		&i.AdultConfirmedAt,
 	)
 	return i, err
 }
 
 const findUserByEmail = `-- name: FindUserByEmail :one
-SELECT id, login, email, encrypted_password, first_name, last_name, appearance
+SELECT id, login, email, encrypted_password, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at
 FROM users
 WHERE email = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 type FindUserByEmailRow struct {
-	ID                uuid.UUID
-	Login             string
-	Email             string
-	EncryptedPassword string
-	FirstName         string
-	LastName          string
-	Appearance        AppearanceType
+	ID                    uuid.UUID
+	Login                 string
+	Email                 string
+	EncryptedPassword     string
+	FirstName             string
+	LastName              string
+	Appearance            AppearanceType
+	Language              string
+	Region                string
+	WatchProviders        []int32
+	IncludeDocumentaries  pgtype.Bool
+	IncludeWithoutArtwork pgtype.Bool
+	IncludeAdult          bool
+	AdultConfirmedAt      pgtype.Timestamp
 }
 
 func (q *Queries) FindUserByEmail(ctx context.Context, email string) (FindUserByEmailRow, error) {
@@ -90,23 +112,37 @@ func (q *Queries) FindUserByEmail(ctx context.Context, email string) (FindUserBy
 		&i.FirstName,
 		&i.LastName,
 		&i.Appearance,
+		&i.Language,
+		&i.Region,
+		&i.WatchProviders,
+		&i.IncludeDocumentaries,
+		&i.IncludeWithoutArtwork,
+		&i.IncludeAdult,
+		&i.AdultConfirmedAt,
 	)
 	return i, err
 }
 
 const findUserById = `-- name: FindUserById :one
-SELECT id, login, email, first_name, last_name, appearance
+SELECT id, login, email, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at
 FROM users
 WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 type FindUserByIdRow struct {
-	ID         uuid.UUID
-	Login      string
-	Email      string
-	FirstName  string
-	LastName   string
-	Appearance AppearanceType
+	ID                    uuid.UUID
+	Login                 string
+	Email                 string
+	FirstName             string
+	LastName              string
+	Appearance            AppearanceType
+	Language              string
+	Region                string
+	WatchProviders        []int32
+	IncludeDocumentaries  pgtype.Bool
+	IncludeWithoutArtwork pgtype.Bool
+	IncludeAdult          bool
+	AdultConfirmedAt      pgtype.Timestamp
 }
 
 func (q *Queries) FindUserById(ctx context.Context, id uuid.UUID) (FindUserByIdRow, error) {
@@ -119,24 +155,38 @@ func (q *Queries) FindUserById(ctx context.Context, id uuid.UUID) (FindUserByIdR
 		&i.FirstName,
 		&i.LastName,
 		&i.Appearance,
+		&i.Language,
+		&i.Region,
+		&i.WatchProviders,
+		&i.IncludeDocumentaries,
+		&i.IncludeWithoutArtwork,
+		&i.IncludeAdult,
+		&i.AdultConfirmedAt,
 	)
 	return i, err
 }
 
 const findUserByLogin = `-- name: FindUserByLogin :one
-SELECT id, login, email, encrypted_password, first_name, last_name, appearance
+SELECT id, login, email, encrypted_password, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at
 FROM users
 WHERE login = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 type FindUserByLoginRow struct {
-	ID                uuid.UUID
-	Login             string
-	Email             string
-	EncryptedPassword string
-	FirstName         string
-	LastName          string
-	Appearance        AppearanceType
+	ID                    uuid.UUID
+	Login                 string
+	Email                 string
+	EncryptedPassword     string
+	FirstName             string
+	LastName              string
+	Appearance            AppearanceType
+	Language              string
+	Region                string
+	WatchProviders        []int32
+	IncludeDocumentaries  pgtype.Bool
+	IncludeWithoutArtwork pgtype.Bool
+	IncludeAdult          bool
+	AdultConfirmedAt      pgtype.Timestamp
 }
 
 func (q *Queries) FindUserByLogin(ctx context.Context, login string) (FindUserByLoginRow, error) {
@@ -150,6 +200,13 @@ func (q *Queries) FindUserByLogin(ctx context.Context, login string) (FindUserBy
 		&i.FirstName,
 		&i.LastName,
 		&i.Appearance,
+		&i.Language,
+		&i.Region,
+		&i.WatchProviders,
+		&i.IncludeDocumentaries,
+		&i.IncludeWithoutArtwork,
+		&i.IncludeAdult,
+		&i.AdultConfirmedAt,
 	)
 	return i, err
 }
@@ -160,25 +217,46 @@ SET
   first_name = $2,
   last_name = $3,
   appearance = $4,
+  language = $5,
+  region = $6,
+  watch_providers = $7,
+  include_documentaries = $8,
+  include_without_artwork = $9,
+  include_adult = $10,
+  adult_confirmed_at = $11,
   updated_at = NOW()
 WHERE id = $1
-RETURNING id, login, email, first_name, last_name, appearance
+RETURNING id, login, email, first_name, last_name, appearance, language, region, watch_providers, include_documentaries, include_without_artwork, include_adult, adult_confirmed_at
 `
 
 type UpdateUserParams struct {
-	ID         uuid.UUID
-	FirstName  string
-	LastName   string
-	Appearance AppearanceType
+	ID                    uuid.UUID
+	FirstName             string
+	LastName              string
+	Appearance            AppearanceType
+	Language              string
+	Region                string
+	WatchProviders        []int32
+	IncludeDocumentaries  pgtype.Bool
+	IncludeWithoutArtwork pgtype.Bool
+	IncludeAdult          bool
+	AdultConfirmedAt      pgtype.Timestamp
 }
 
 type UpdateUserRow struct {
-	ID         uuid.UUID
-	Login      string
-	Email      string
-	FirstName  string
-	LastName   string
-	Appearance AppearanceType
+	ID                    uuid.UUID
+	Login                 string
+	Email                 string
+	FirstName             string
+	LastName              string
+	Appearance            AppearanceType
+	Language              string
+	Region                string
+	WatchProviders        []int32
+	IncludeDocumentaries  pgtype.Bool
+	IncludeWithoutArtwork pgtype.Bool
+	IncludeAdult          bool
+	AdultConfirmedAt      pgtype.Timestamp
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error) {
@@ -187,6 +265,13 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 		arg.FirstName,
 		arg.LastName,
 		arg.Appearance,
+		arg.Language,
+		arg.Region,
+		arg.WatchProviders,
+		arg.IncludeDocumentaries,
+		arg.IncludeWithoutArtwork,
+		arg.IncludeAdult,
+		arg.AdultConfirmedAt,
 	)
 	var i UpdateUserRow
 	err := row.Scan(
@@ -196,6 +281,13 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 		&i.FirstName,
 		&i.LastName,
 		&i.Appearance,
+		&i.Language,
+		&i.Region,
+		&i.WatchProviders,
+		&i.IncludeDocumentaries,
+		&i.IncludeWithoutArtwork,
+		&i.IncludeAdult,
+		&i.AdultConfirmedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories/db"
+	"biinge-api/internal/app/repositories/postgres"
+)
+
+// HistoryRepository reads the history of library movies, events are recorded by MovieRepository so they
+// are written within the transaction of the change
+type HistoryRepository interface {
+	List(ctx context.Context, userId uuid.UUID, limit, offset uint64) ([]models.MovieEvent, error)
+	Count(ctx context.Context, userId uuid.UUID) (uint64, error)
+	ListByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID, limit, offset uint64) ([]models.MovieEvent, error)
+	CountByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) (uint64, error)
+}
+
+type history struct {
+	client postgres.Postgres
+}
+
+func NewHistoryRepository(client postgres.Postgres) HistoryRepository {
+	return &history{client: client}
+}
+
+// List returns a page of the events of every movie of a user, the most recent first
+func (h *history) List(ctx context.Context, userId uuid.UUID, limit, offset uint64) ([]models.MovieEvent, error) {
+	rows, err := h.client.Queries().FindUserEvents(ctx, db.FindUserEventsParams{
+		UserID:      userId,
+		MaxResults:  limit,
+		SkipResults: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]models.MovieEvent, 0, len(rows))
+	for _, row := range rows {
+		event := newMovieEvent(db.MovieEvent{
+			ID:        row.ID,
+			UserID:    row.UserID,
+			TmdbID:    row.TmdbID,
+			Action:    row.Action,
+			OldState:  row.OldState,
+			NewState:  row.NewState,
+			OldPinned: row.OldPinned,
+			NewPinned: row.NewPinned,
+			Source:    row.Source,
+			CreatedAt: row.CreatedAt,
+		})
+		event.Title = row.Title
+		event.PosterPath = row.PosterPath
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (h *history) Count(ctx context.Context, userId uuid.UUID) (uint64, error) {
+	total, err := h.client.Queries().CountUserEvents(ctx, userId)
+
+	return uint64(total), err
+}
+
+// ListByTmdbId returns a page of the events of a movie, the most recent first
+func (h *history) ListByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID, limit, offset uint64) ([]models.MovieEvent, error) {
+	rows, err := h.client.Queries().FindMovieEvents(ctx, db.FindMovieEventsParams{
+		UserID:      userId,
+		TmdbID:      tmdbId,
+		MaxResults:  limit,
+		SkipResults: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]models.MovieEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, newMovieEvent(row))
+	}
+
+	return events, nil
+}
+
+func (h *history) CountByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) (uint64, error) {
+	total, err := h.client.Queries().CountMovieEvents(ctx, db.CountMovieEventsParams{
+		UserID: userId,
+		TmdbID: tmdbId,
+	})
+
+	return uint64(total), err
+}
+
+func newMovieEvent(row db.MovieEvent) models.MovieEvent {
+	return models.MovieEvent{
+		ID:        row.ID,
+		UserId:    row.UserID,
+		TmdbId:    row.TmdbID,
+		Action:    string(row.Action),
+		OldState:  string(row.OldState.StateTypes),
+		NewState:  string(row.NewState.StateTypes),
+		OldPinned: fromBool(row.OldPinned),
+		NewPinned: fromBool(row.NewPinned),
+		Source:    string(row.Source),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+func toNullState(state string) db.NullStateTypes {
+	return db.NullStateTypes{StateTypes: db.StateTypes(state), Valid: state != ""}
+}
+
+func toBool(value *bool) pgtype.Bool {
+	if value == nil {
+		return pgtype.Bool{}
+	}
+
+	return pgtype.Bool{Bool: *value, Valid: true}
+}
+
+func fromBool(value pgtype.Bool) *bool {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Bool
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=history.go -destination=history_mock.go -package=repositories
+//
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	models "biinge-api/internal/app/models"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryRepository is a mock of HistoryRepository interface.
+type MockHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryRepositoryMockRecorder is the mock recorder for MockHistoryRepository.
+type MockHistoryRepositoryMockRecorder struct {
+	mock *MockHistoryRepository
+}
+
+// NewMockHistoryRepository creates a new mock instance.
+func NewMockHistoryRepository(ctrl *gomock.Controller) *MockHistoryRepository {
+	mock := &MockHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepository) EXPECT() *MockHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockHistoryRepository) Count(ctx context.Context, userId uuid.UUID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, userId)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockHistoryRepositoryMockRecorder) Count(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockHistoryRepository)(nil).Count), ctx, userId)
+}
+
+// CountByTmdbId mocks base method.
+func (m *MockHistoryRepository) CountByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByTmdbId", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByTmdbId indicates an expected call of CountByTmdbId.
+func (mr *MockHistoryRepositoryMockRecorder) CountByTmdbId(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByTmdbId", reflect.TypeOf((*MockHistoryRepository)(nil).CountByTmdbId), ctx, tmdbId, userId)
+}
+
+// List mocks base method.
+func (m *MockHistoryRepository) List(ctx context.Context, userId uuid.UUID, limit, offset uint64) ([]models.MovieEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]models.MovieEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHistoryRepositoryMockRecorder) List(ctx, userId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHistoryRepository)(nil).List), ctx, userId, limit, offset)
+}
+
+// ListByTmdbId mocks base method.
+func (m *MockHistoryRepository) ListByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID, limit, offset uint64) ([]models.MovieEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTmdbId", ctx, tmdbId, userId, limit, offset)
+	ret0, _ := ret[0].([]models.MovieEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTmdbId indicates an expected call of ListByTmdbId.
+func (mr *MockHistoryRepositoryMockRecorder) ListByTmdbId(ctx, tmdbId, userId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTmdbId", reflect.TypeOf((*MockHistoryRepository)(nil).ListByTmdbId), ctx, tmdbId, userId, limit, offset)
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories/db"
+	"biinge-api/internal/app/repositories/postgres"
+)
+
+type IdempotencyRepository interface {
+	Claim(ctx context.Context, key *models.IdempotencyKey, expiredBefore, abandonedBefore time.Time) (uuid.UUID, error)
+	Find(ctx context.Context, userId uuid.UUID, key string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, key *models.IdempotencyKey) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, createdBefore time.Time) (uint64, error)
+}
+
+type idempotency struct {
+	client postgres.Postgres
+}
+
+func NewIdempotencyRepository(client postgres.Postgres) IdempotencyRepository {
+	return &idempotency{client: client}
+}
+
+// Claim stores a key for the request of the given fingerprint and returns its Id. A key stored before
+// expiredBefore, or still in progress since before abandonedBefore, is taken over. pgx.ErrNoRows is
+// returned when the key is held by another request
+func (i *idempotency) Claim(ctx context.Context, key *models.IdempotencyKey, expiredBefore, abandonedBefore time.Time) (uuid.UUID, error) {
+	return i.client.Queries().ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+		UserID:          key.UserId,
+		Key:             key.Key,
+		Fingerprint:     key.Fingerprint,
+		ExpiredBefore:   pgtype.Timestamp{Time: expiredBefore, Valid: true},
+		AbandonedBefore: pgtype.Timestamp{Time: abandonedBefore, Valid: true},
+	})
+}
+
+func (i *idempotency) Find(ctx context.Context, userId uuid.UUID, key string) (*models.IdempotencyKey, error) {
+	row, err := i.client.Queries().FindIdempotencyKey(ctx, db.FindIdempotencyKeyParams{
+		UserID: userId,
+		Key:    key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.IdempotencyKey{
+		ID:          row.ID,
+		UserId:      row.UserID,
+		Key:         row.Key,
+		Fingerprint: row.Fingerprint,
+		CreatedAt:   row.CreatedAt.Time,
+		Status:      int(row.Status.Int32),
+		ContentType: row.ContentType,
+		Body:        row.Body,
+	}, nil
+}
+
+// Complete stores the response of the request holding a key, for it to be replayed to retries
+func (i *idempotency) Complete(ctx context.Context, key *models.IdempotencyKey) error {
+	return i.client.Queries().CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		ID:          key.ID,
+		Status:      pgtype.Int4{Int32: int32(key.Status), Valid: true},
+		ContentType: key.ContentType,
+		Body:        key.Body,
+	})
+}
+
+func (i *idempotency) Delete(ctx context.Context, id uuid.UUID) error {
+	return i.client.Queries().DeleteIdempotencyKey(ctx, id)
+}
+
+// Purge removes the keys stored before createdBefore and returns how many were removed
+func (i *idempotency) Purge(ctx context.Context, createdBefore time.Time) (uint64, error) {
+	purged, err := i.client.Queries().PurgeIdempotencyKeys(ctx, pgtype.Timestamp{Time: createdBefore, Valid: true})
+
+	return uint64(purged), err
+}
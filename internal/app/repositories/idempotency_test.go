@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories/postgres"
+	"biinge-api/internal/config"
+)
+
+func Test_IdempotencyRepository_Claim(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		DatabaseDSN: os.Getenv("DATABASE_DSN"),
+	}
+
+	client, err := postgres.NewPostgresClient(cfg)
+	assert.NoError(t, err)
+
+	repository := NewIdempotencyRepository(client)
+
+	// NOTE: the margins leave room for the clock of the database differing from the one of the tests
+	now := time.Now()
+	expiredBefore, abandonedBefore := now.Add(-24*time.Hour), now.Add(-24*time.Hour)
+	later := now.Add(24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		before func(userId uuid.UUID) uuid.UUID
+		claim  *models.IdempotencyKey
+		expiry time.Time
+		left   time.Time
+		taken  bool
+		stored *models.IdempotencyKey
+	}{
+		{
+			name:   "New key",
+			before: func(uuid.UUID) uuid.UUID { return uuid.Nil },
+			claim:  &models.IdempotencyKey{Key: "key", Fingerprint: "first"},
+			expiry: expiredBefore,
+			left:   abandonedBefore,
+			taken:  true,
+			stored: &models.IdempotencyKey{Key: "key", Fingerprint: "first"},
+		},
+		{
+			name: "Key in progress",
+			before: func(userId uuid.UUID) uuid.UUID {
+				id, err := repository.Claim(ctx, &models.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "first"}, expiredBefore, abandonedBefore)
+				assert.NoError(t, err)
+				return id
+			},
+			claim:  &models.IdempotencyKey{Key: "key", Fingerprint: "first"},
+			expiry: expiredBefore,
+			left:   abandonedBefore,
+			stored: &models.IdempotencyKey{Key: "key", Fingerprint: "first"},
+		},
+		{
+			name: "Replays a completed key",
+			before: func(userId uuid.UUID) uuid.UUID {
+				id, err := repository.Claim(ctx, &models.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "first"}, expiredBefore, abandonedBefore)
+				assert.NoError(t, err)
+				err = repository.Complete(ctx, &models.IdempotencyKey{ID: id, Status: 201, ContentType: "application/json", Body: []byte(`{"id":27205}`)})
+				assert.NoError(t, err)
+				return id
+			},
+			claim:  &models.IdempotencyKey{Key: "key", Fingerprint: "first"},
+			expiry: expiredBefore,
+			left:   later,
+			stored: &models.IdempotencyKey{Key: "key", Fingerprint: "first", Status: 201, ContentType: "application/json", Body: []byte(`{"id":27205}`)},
+		},
+		{
+			name: "Keeps a key reused for another request",
+			before: func(userId uuid.UUID) uuid.UUID {
+				id, err := repository.Claim(ctx, &models.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "first"}, expiredBefore, abandonedBefore)
+				assert.NoError(t, err)
+				err = repository.Complete(ctx, &models.IdempotencyKey{ID: id, Status: 200, ContentType: "application/json", Body: []byte(`{}`)})
+				assert.NoError(t, err)
+				return id
+			},
+			claim:  &models.IdempotencyKey{Key: "key", Fingerprint: "second"},
+			expiry: expiredBefore,
+			left:   abandonedBefore,
+			stored: &models.IdempotencyKey{Key: "key", Fingerprint: "first", Status: 200, ContentType: "application/json", Body: []byte(`{}`)},
+		},
+		{
+			name: "Takes over an expired key",
+			before: func(userId uuid.UUID) uuid.UUID {
+				id, err := repository.Claim(ctx, &models.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "first"}, expiredBefore, abandonedBefore)
+				assert.NoError(t, err)
+				err = repository.Complete(ctx, &models.IdempotencyKey{ID: id, Status: 200, ContentType: "application/json", Body: []byte(`{}`)})
+				assert.NoError(t, err)
+				return id
+			},
+			claim:  &models.IdempotencyKey{Key: "key", Fingerprint: "second"},
+			expiry: later,
+			left:   abandonedBefore,
+			taken:  true,
+			stored: &models.IdempotencyKey{Key: "key", Fingerprint: "second"},
+		},
+		{
+			name: "Takes over an abandoned key",
+			before: func(userId uuid.UUID) uuid.UUID {
+				id, err := repository.Claim(ctx, &models.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "first"}, expiredBefore, abandonedBefore)
+				assert.NoError(t, err)
+				return id
+			},
+			claim:  &models.IdempotencyKey{Key: "key", Fingerprint: "first"},
+			expiry: expiredBefore,
+			left:   later,
+			taken:  true,
+			stored: &models.IdempotencyKey{Key: "key", Fingerprint: "first"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId := createTestUser(t, ctx, client)
+			existing := tt.before(userId)
+
+			tt.claim.UserId = userId
+			id, err := repository.Claim(ctx, tt.claim, tt.expiry, tt.left)
+
+			if tt.taken {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, id)
+				if existing != uuid.Nil {
+					assert.Equal(t, existing, id)
+				}
+			} else {
+				assert.ErrorIs(t, err, pgx.ErrNoRows)
+			}
+
+			stored, err := repository.Find(ctx, userId, tt.claim.Key)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.stored.Fingerprint, stored.Fingerprint)
+			assert.Equal(t, tt.stored.Status, stored.Status)
+			assert.Equal(t, tt.stored.ContentType, stored.ContentType)
+			assert.Equal(t, tt.stored.Body, stored.Body)
+		})
+	}
+}
+
+func Test_IdempotencyRepository_Claim_Concurrently(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		DatabaseDSN: os.Getenv("DATABASE_DSN"),
+	}
+
+	client, err := postgres.NewPostgresClient(cfg)
+	assert.NoError(t, err)
+
+	repository := NewIdempotencyRepository(client)
+	userId := createTestUser(t, ctx, client)
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = repository.Claim(ctx, &models.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "first"},
+				now.Add(-24*time.Hour), now.Add(-24*time.Hour))
+		}(i)
+	}
+	wg.Wait()
+
+	claimed := 0
+	for _, err := range errs {
+		if err == nil {
+			claimed++
+			continue
+		}
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	}
+	assert.Equal(t, 1, claimed)
+}
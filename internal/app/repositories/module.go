@@ -8,7 +8,12 @@ import (
 
 var Module = fx.Options(
 	fx.Provide(postgres.NewPostgresClient),
+	fx.Provide(NewCreditRepository),
 	fx.Provide(NewHealthRepository),
+	fx.Provide(NewHistoryRepository),
+	fx.Provide(NewIdempotencyRepository),
 	fx.Provide(NewMovieRepository),
+	fx.Provide(NewTagRepository),
+	fx.Provide(NewTitleRepository),
 	fx.Provide(NewUserRepository),
 )
@@ -2,77 +2,213 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"biinge-api/internal/app/models"
 	"biinge-api/internal/app/repositories/db"
 	"biinge-api/internal/app/repositories/postgres"
 )
 
+// positionGap spreads the manual positions of a library, matching the migrations and queries
+const positionGap int64 = 65536
+
 type MovieRepository interface {
-	List(ctx context.Context, userId uuid.UUID, state string, limit, offset uint64) ([]models.Movie, uint64, error)
+	List(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter, after *models.MovieCursor, limit, offset uint64) ([]models.Movie, *models.MovieCursor, error)
+	Count(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter) (uint64, error)
+	Move(ctx context.Context, userId uuid.UUID, tmdbId, anchorTmdbId uint64, after bool) error
 	Create(ctx context.Context, params *models.Movie) (*models.Movie, error)
+	Upsert(ctx context.Context, params *models.Movie) (*models.MovieUpsert, error)
 	Update(ctx context.Context, params *models.Movie) (*models.Movie, error)
 	UpdateByTmdbId(ctx context.Context, params *models.Movie) (*models.Movie, error)
+	UpdateNotes(ctx context.Context, tmdbId uint64, userId uuid.UUID, notes string) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) error
 	FindById(ctx context.Context, id uuid.UUID) (*models.Movie, error)
 	FindByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error)
 	FindMoviesByTmdbIds(ctx context.Context, tmdbIds []uint64, userId uuid.UUID) ([]models.Movie, error)
+	FindWithStaleWatchProviders(ctx context.Context, defaultRegion string, refreshedBefore time.Time, limit uint64) ([]models.Movie, error)
+	UpdateWatchProviders(ctx context.Context, ids []uuid.UUID, region string, providers []int32) error
+	AddTags(ctx context.Context, movieId, userId uuid.UUID, names []string) error
+	Trash(ctx context.Context, tmdbId uint64, userId uuid.UUID) error
+	Restore(ctx context.Context, tmdbId uint64, userId uuid.UUID) error
+	FindTrashed(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error)
+	ListTrashed(ctx context.Context, userId uuid.UUID, limit, offset uint64) ([]models.Movie, error)
+	CountTrashed(ctx context.Context, userId uuid.UUID) (uint64, error)
+	EmptyTrash(ctx context.Context, userId uuid.UUID, source string) (uint64, error)
+	PurgeTrash(ctx context.Context, deletedBefore time.Time, source string) (uint64, error)
+	CreateEvent(ctx context.Context, event *models.MovieEvent) error
+	Transaction(ctx context.Context, fn func(repository MovieRepository) error) error
 }
 
 type movie struct {
 	client postgres.Postgres
+	// tx is set on the repository handed to Transaction callbacks, every query then runs within it
+	tx pgx.Tx
 }
 
 func NewMovieRepository(client postgres.Postgres) MovieRepository {
 	return &movie{client: client}
 }
 
-func (m *movie) List(ctx context.Context, userId uuid.UUID, state string, limit, offset uint64) ([]models.Movie, uint64, error) {
-	rows, err := m.client.Queries().FindMoviesByState(ctx, db.FindMoviesByStateParams{
-		UserID: userId,
-		State:  db.StateTypes(state),
-		Limit:  limit,
-		Offset: offset,
-	})
+// Transaction runs fn with a repository bound to a new transaction, committed when fn succeeds and rolled
+// back otherwise. Nested calls run within a savepoint, so a failing step does not abort the outer transaction
+func (m *movie) Transaction(ctx context.Context, fn func(repository MovieRepository) error) error {
+	tx, err := m.begin(ctx)
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
+	defer tx.Rollback(ctx)
 
-	movies := make([]models.Movie, 0, len(rows))
-	var total uint64
+	if err := fn(&movie{client: m.client, tx: tx}); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
 
-	if len(rows) > 0 {
-		total = rows[0].Total
+func (m *movie) begin(ctx context.Context) (pgx.Tx, error) {
+	if m.tx != nil {
+		return m.tx.Begin(ctx)
 	}
 
-	for _, row := range rows {
-		movies = append(movies, models.Movie{
-			ID:         row.ID,
-			UserId:     row.UserID,
-			TmdbId:     row.TmdbID,
-			Title:      row.Title,
-			PosterPath: row.PosterPath,
-			Pinned:     row.Pinned,
-			State:      string(row.State),
-			CreatedAt:  row.CreatedAt.Time,
-			UpdatedAt:  row.UpdatedAt.Time,
-		})
+	return m.client.Db().Begin(ctx)
+}
+
+func (m *movie) conn() db.DBTX {
+	if m.tx != nil {
+		return m.tx
 	}
 
-	return movies, total, err
+	return m.client.Db()
 }
 
+func (m *movie) queries() *db.Queries {
+	if m.tx != nil {
+		return m.client.Queries().WithTx(m.tx)
+	}
+
+	return m.client.Queries()
+}
+
+// List returns a page of movies matching filter with the cursor of its last movie, nil once no
+// movies are left
+func (m *movie) List(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter, after *models.MovieCursor, limit, offset uint64) ([]models.Movie, *models.MovieCursor, error) {
+	// NOTE: one extra row tells whether another page follows
+	sql, args := buildMovieListQuery(userId, filter, after, limit+1, offset)
+
+	rows, err := m.conn().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	movies := make([]models.Movie, 0, limit)
+	var (
+		next    *models.MovieCursor
+		lastKey string
+	)
+
+	for rows.Next() {
+		var (
+			row       models.Movie
+			runtime   int32
+			state     db.StateTypes
+			createdAt pgtype.Timestamp
+			updatedAt pgtype.Timestamp
+			sortKey   string
+		)
+
+		if err := rows.Scan(
+			&row.ID,
+			&row.UserId,
+			&row.TmdbId,
+			&row.Title,
+			&row.PosterPath,
+			&runtime,
+			&row.Pinned,
+			&state,
+			&createdAt,
+			&updatedAt,
+			&row.MissingOnTmdb,
+			&row.Progress,
+			&sortKey,
+		); err != nil {
+			return nil, nil, err
+		}
+
+		if uint64(len(movies)) == limit {
+			last := movies[len(movies)-1]
+			next = &models.MovieCursor{
+				Sort:      filter.Sort,
+				Direction: filter.Direction,
+				Pinned:    last.Pinned,
+				Key:       lastKey,
+				Id:        last.ID,
+			}
+			break
+		}
+
+		row.Runtime = uint64(runtime)
+		row.State = string(state)
+		row.CreatedAt = createdAt.Time
+		row.UpdatedAt = updatedAt.Time
+
+		movies = append(movies, row)
+		lastKey = sortKey
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return movies, next, nil
+}
+
+func (m *movie) Count(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter) (uint64, error) {
+	sql, args := buildMovieCountQuery(userId, filter)
+
+	var total uint64
+	err := m.conn().QueryRow(ctx, sql, args...).Scan(&total)
+
+	return total, err
+}
+
+// Create adds a movie to a library, a title missing from the catalog is seeded with the given metadata
+// until the titles refresher fetches it from TMDB
 func (m *movie) Create(ctx context.Context, params *models.Movie) (*models.Movie, error) {
-	result, err := m.client.Queries().CreateMovie(ctx, db.CreateMovieParams{
-		UserID:     params.UserId,
-		TmdbID:     params.TmdbId,
+	metadata, err := json.Marshal(newTitleMetadata(&models.Title{
 		Title:      params.Title,
 		PosterPath: params.PosterPath,
 		Runtime:    params.Runtime,
-		State:      db.StateTypes(params.State),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.queries().CreateTitle(ctx, db.CreateTitleParams{
+		TmdbID:   params.TmdbId,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.queries().CreateMovie(ctx, db.CreateMovieParams{
+		UserID:      params.UserId,
+		TmdbID:      params.TmdbId,
+		Title:       params.Title,
+		PosterPath:  params.PosterPath,
+		Runtime:     params.Runtime,
+		State:       db.StateTypes(params.State),
+		NeedsResync: params.NeedsResync,
+		Progress:    params.Progress,
 	})
 	if err != nil {
 		return nil, err
@@ -84,16 +220,92 @@ func (m *movie) Create(ctx context.Context, params *models.Movie) (*models.Movie
 		TmdbId:     result.TmdbID,
 		Title:      result.Title,
 		PosterPath: result.PosterPath,
-		Runtime:    result.Runtime,
+		Runtime:    uint64(result.Runtime),
 		State:      string(result.State),
 		Pinned:     result.Pinned,
 		CreatedAt:  result.CreatedAt.Time,
 		UpdatedAt:  result.UpdatedAt.Time,
+		Progress:   result.Progress,
 	}, nil
 }
 
+// Upsert adds a movie to a library or, when the user already holds the title, replaces its state and pin
+// and brings it back from the trash, all in one statement. The progress is kept while the movie stays in
+// the watching state and none is reported
+func (m *movie) Upsert(ctx context.Context, params *models.Movie) (*models.MovieUpsert, error) {
+	metadata, err := json.Marshal(newTitleMetadata(&models.Title{
+		Title:      params.Title,
+		PosterPath: params.PosterPath,
+		Runtime:    params.Runtime,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.queries().CreateTitle(ctx, db.CreateTitleParams{
+		TmdbID:   params.TmdbId,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.UpsertMovieParams{
+		UserID:       params.UserId,
+		TmdbID:       params.TmdbId,
+		Title:        params.Title,
+		PosterPath:   params.PosterPath,
+		Runtime:      params.Runtime,
+		State:        db.StateTypes(params.State),
+		Pinned:       params.Pinned,
+		NeedsResync:  params.NeedsResync,
+		Progress:     params.Progress,
+		KeepProgress: params.ReportedProgress == nil,
+	}
+
+	result, err := m.queries().UpsertMovie(ctx, arg)
+	// NOTE: a movie added concurrently as asked is left alone and not seen by the statement, a new one reads it
+	if errors.Is(err, pgx.ErrNoRows) {
+		result, err = m.queries().UpsertMovie(ctx, arg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	upsert := &models.MovieUpsert{
+		Movie: &models.Movie{
+			ID:         result.ID,
+			UserId:     result.UserID,
+			TmdbId:     result.TmdbID,
+			Title:      result.Title,
+			PosterPath: result.PosterPath,
+			Runtime:    uint64(result.Runtime),
+			State:      string(result.State),
+			Pinned:     result.Pinned,
+			CreatedAt:  result.CreatedAt.Time,
+			UpdatedAt:  result.UpdatedAt.Time,
+			Progress:   result.Progress,
+		},
+		Inserted: result.Inserted,
+		Changed:  result.Changed,
+	}
+
+	// NOTE: a movie added concurrently is updated without being seen before, its previous state is unknown
+	if !result.Inserted {
+		upsert.Previous = &models.Movie{
+			State:  string(result.PreviousState.StateTypes),
+			Pinned: result.PreviousPinned.Bool,
+		}
+		if result.PreviousDeletedAt.Valid {
+			upsert.Previous.DeletedAt = &result.PreviousDeletedAt.Time
+		}
+	}
+
+	return upsert, nil
+}
+
 func (m *movie) Update(ctx context.Context, params *models.Movie) (*models.Movie, error) {
-	result, err := m.client.Queries().UpdateMovie(ctx, db.UpdateMovieParams{
+	result, err := m.queries().UpdateMovie(ctx, db.UpdateMovieParams{
 		ID:         params.ID,
 		Title:      params.Title,
 		PosterPath: params.PosterPath,
@@ -118,11 +330,12 @@ func (m *movie) Update(ctx context.Context, params *models.Movie) (*models.Movie
 }
 
 func (m *movie) UpdateByTmdbId(ctx context.Context, params *models.Movie) (*models.Movie, error) {
-	result, err := m.client.Queries().UpdateMovieByTmdbId(ctx, db.UpdateMovieByTmdbIdParams{
-		TmdbID: params.TmdbId,
-		UserID: params.UserId,
-		State:  db.StateTypes(params.State),
-		Pinned: params.Pinned,
+	result, err := m.queries().UpdateMovieByTmdbId(ctx, db.UpdateMovieByTmdbIdParams{
+		TmdbID:   params.TmdbId,
+		UserID:   params.UserId,
+		State:    db.StateTypes(params.State),
+		Pinned:   params.Pinned,
+		Progress: params.Progress,
 	})
 	if err != nil {
 		return nil, err
@@ -134,27 +347,46 @@ func (m *movie) UpdateByTmdbId(ctx context.Context, params *models.Movie) (*mode
 		TmdbId:     result.TmdbID,
 		Title:      result.Title,
 		PosterPath: result.PosterPath,
-		Runtime:    result.Runtime,
+		Runtime:    uint64(result.Runtime),
 		State:      string(result.State),
 		Pinned:     result.Pinned,
 		CreatedAt:  result.CreatedAt.Time,
 		UpdatedAt:  result.UpdatedAt.Time,
+		Progress:   result.Progress,
 	}, nil
 }
 
+// UpdateNotes replaces the private notes of a movie, pgx.ErrNoRows when the user does not hold it
+func (m *movie) UpdateNotes(ctx context.Context, tmdbId uint64, userId uuid.UUID, notes string) error {
+	updated, err := m.queries().UpdateMovieNotes(ctx, db.UpdateMovieNotesParams{
+		TmdbID: tmdbId,
+		UserID: userId,
+		Notes:  notes,
+	})
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
 func (m *movie) Delete(ctx context.Context, id uuid.UUID) error {
-	return m.client.Queries().DeleteMovie(ctx, id)
+	return m.queries().DeleteMovie(ctx, id)
 }
 
 func (m *movie) DeleteByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) error {
-	return m.client.Queries().DeleteMovieByTmdbId(ctx, db.DeleteMovieByTmdbIdParams{
+	return m.queries().DeleteMovieByTmdbId(ctx, db.DeleteMovieByTmdbIdParams{
 		TmdbID: tmdbId,
 		UserID: userId,
 	})
 }
 
 func (m *movie) FindById(ctx context.Context, id uuid.UUID) (*models.Movie, error) {
-	result, err := m.client.Queries().FindMovieById(ctx, id)
+	result, err := m.queries().FindMovieById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +397,7 @@ func (m *movie) FindById(ctx context.Context, id uuid.UUID) (*models.Movie, erro
 		TmdbId:     result.TmdbID,
 		Title:      result.Title,
 		PosterPath: result.PosterPath,
-		Runtime:    result.Runtime,
+		Runtime:    uint64(result.Runtime),
 		State:      string(result.State),
 		Pinned:     result.Pinned,
 		CreatedAt:  result.CreatedAt.Time,
@@ -174,7 +406,7 @@ func (m *movie) FindById(ctx context.Context, id uuid.UUID) (*models.Movie, erro
 }
 
 func (m *movie) FindByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error) {
-	result, err := m.client.Queries().FindMovieByTmdbId(ctx, db.FindMovieByTmdbIdParams{
+	result, err := m.queries().FindMovieByTmdbId(ctx, db.FindMovieByTmdbIdParams{
 		TmdbID: tmdbId,
 		UserID: userId,
 	})
@@ -188,16 +420,19 @@ func (m *movie) FindByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUI
 		TmdbId:     result.TmdbID,
 		Title:      result.Title,
 		PosterPath: result.PosterPath,
-		Runtime:    result.Runtime,
+		Runtime:    uint64(result.Runtime),
 		State:      string(result.State),
 		Pinned:     result.Pinned,
 		CreatedAt:  result.CreatedAt.Time,
 		UpdatedAt:  result.UpdatedAt.Time,
+		Progress:   result.Progress,
+		Notes:      result.Notes,
+		Tags:       result.Tags,
 	}, nil
 }
 
 func (m *movie) FindMoviesByTmdbIds(ctx context.Context, tmdbIds []uint64, userId uuid.UUID) ([]models.Movie, error) {
-	rows, err := m.client.Queries().FindMoviesByTmdbIds(ctx, db.FindMoviesByTmdbIdsParams{
+	rows, err := m.queries().FindMoviesByTmdbIds(ctx, db.FindMoviesByTmdbIdsParams{
 		TmdbIds: tmdbIds,
 		UserID:  userId,
 	})
@@ -213,13 +448,266 @@ func (m *movie) FindMoviesByTmdbIds(ctx context.Context, tmdbIds []uint64, userI
 			TmdbId:     row.TmdbID,
 			Title:      row.Title,
 			PosterPath: row.PosterPath,
-			Runtime:    row.Runtime,
+			Runtime:    uint64(row.Runtime),
+			State:      string(row.State),
+			Pinned:     row.Pinned,
+			CreatedAt:  row.CreatedAt.Time,
+			UpdatedAt:  row.UpdatedAt.Time,
+		})
+	}
+
+	return movies, nil
+}
+
+// FindWithStaleWatchProviders returns watchlist movies whose availability is older than refreshedBefore
+// or was fetched for another region, WatchRegion holds the region of the owner
+func (m *movie) FindWithStaleWatchProviders(ctx context.Context, defaultRegion string, refreshedBefore time.Time, limit uint64) ([]models.Movie, error) {
+	rows, err := m.queries().FindMoviesWithStaleProviders(ctx, db.FindMoviesWithStaleProvidersParams{
+		DefaultRegion:   defaultRegion,
+		RefreshedBefore: pgtype.Timestamp{Time: refreshedBefore, Valid: true},
+		BatchSize:       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	movies := make([]models.Movie, 0, len(rows))
+	for _, row := range rows {
+		movies = append(movies, models.Movie{
+			ID:          row.ID,
+			TmdbId:      row.TmdbID,
+			WatchRegion: row.Region,
+		})
+	}
+
+	return movies, nil
+}
+
+func (m *movie) UpdateWatchProviders(ctx context.Context, ids []uuid.UUID, region string, providers []int32) error {
+	return m.queries().UpdateMoviesWatchProviders(ctx, db.UpdateMoviesWatchProvidersParams{
+		WatchProviders: providers,
+		WatchRegion:    region,
+		Ids:            ids,
+	})
+}
+
+// AddTags labels a movie with the given tags on top of the ones it holds, creating the tags the user has
+// not used yet. Existing tags are matched regardless of case
+func (m *movie) AddTags(ctx context.Context, movieId, userId uuid.UUID, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	err := m.queries().EnsureTags(ctx, db.EnsureTagsParams{UserID: userId, Names: names})
+	if err != nil {
+		return err
+	}
+
+	lowered := make([]string, 0, len(names))
+	for _, name := range names {
+		lowered = append(lowered, strings.ToLower(name))
+	}
+
+	return m.queries().TagMovie(ctx, db.TagMovieParams{MovieID: movieId, UserID: userId, Names: lowered})
+}
+
+// Trash moves a movie to the trash, pgx.ErrNoRows when the user holds no such movie outside of it
+func (m *movie) Trash(ctx context.Context, tmdbId uint64, userId uuid.UUID) error {
+	trashed, err := m.queries().TrashMovieByTmdbId(ctx, db.TrashMovieByTmdbIdParams{TmdbID: tmdbId, UserID: userId})
+	if err != nil {
+		return err
+	}
+
+	if trashed == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// Restore brings a movie back from the trash, pgx.ErrNoRows when it is not in the trash
+func (m *movie) Restore(ctx context.Context, tmdbId uint64, userId uuid.UUID) error {
+	restored, err := m.queries().RestoreMovieByTmdbId(ctx, db.RestoreMovieByTmdbIdParams{TmdbID: tmdbId, UserID: userId})
+	if err != nil {
+		return err
+	}
+
+	if restored == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (m *movie) FindTrashed(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error) {
+	result, err := m.queries().FindTrashedMovieByTmdbId(ctx, db.FindTrashedMovieByTmdbIdParams{
+		TmdbID: tmdbId,
+		UserID: userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deletedAt := result.DeletedAt.Time
+	return &models.Movie{
+		ID:         result.ID,
+		UserId:     result.UserID,
+		TmdbId:     result.TmdbID,
+		Title:      result.Title,
+		PosterPath: result.PosterPath,
+		Runtime:    uint64(result.Runtime),
+		State:      string(result.State),
+		Pinned:     result.Pinned,
+		CreatedAt:  result.CreatedAt.Time,
+		UpdatedAt:  result.UpdatedAt.Time,
+		Progress:   result.Progress,
+		DeletedAt:  &deletedAt,
+	}, nil
+}
+
+// ListTrashed returns a page of the trash of a user, the most recently deleted movies first
+func (m *movie) ListTrashed(ctx context.Context, userId uuid.UUID, limit, offset uint64) ([]models.Movie, error) {
+	rows, err := m.queries().FindTrashedMovies(ctx, db.FindTrashedMoviesParams{
+		UserID:      userId,
+		MaxResults:  limit,
+		SkipResults: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	movies := make([]models.Movie, 0, len(rows))
+	for _, row := range rows {
+		deletedAt := row.DeletedAt.Time
+		movies = append(movies, models.Movie{
+			ID:         row.ID,
+			UserId:     row.UserID,
+			TmdbId:     row.TmdbID,
+			Title:      row.Title,
+			PosterPath: row.PosterPath,
+			Runtime:    uint64(row.Runtime),
 			State:      string(row.State),
 			Pinned:     row.Pinned,
 			CreatedAt:  row.CreatedAt.Time,
 			UpdatedAt:  row.UpdatedAt.Time,
+			Progress:   row.Progress,
+			DeletedAt:  &deletedAt,
 		})
 	}
 
 	return movies, nil
 }
+
+func (m *movie) CountTrashed(ctx context.Context, userId uuid.UUID) (uint64, error) {
+	total, err := m.queries().CountTrashedMovies(ctx, userId)
+
+	return uint64(total), err
+}
+
+// EmptyTrash purges every movie in the trash of a user, recording a purged event for each, and returns
+// how many were purged
+func (m *movie) EmptyTrash(ctx context.Context, userId uuid.UUID, source string) (uint64, error) {
+	purged, err := m.queries().EmptyTrash(ctx, db.EmptyTrashParams{
+		UserID: userId,
+		Source: db.EventSources(source),
+	})
+
+	return uint64(purged), err
+}
+
+// PurgeTrash purges the movies of every user trashed before deletedBefore, recording a purged event for each
+func (m *movie) PurgeTrash(ctx context.Context, deletedBefore time.Time, source string) (uint64, error) {
+	purged, err := m.queries().PurgeTrashedMovies(ctx, db.PurgeTrashedMoviesParams{
+		DeletedBefore: pgtype.Timestamp{Time: deletedBefore, Valid: true},
+		Source:        db.EventSources(source),
+	})
+
+	return uint64(purged), err
+}
+
+// CreateEvent appends an event to the history of a movie, within the transaction of the change it records
+func (m *movie) CreateEvent(ctx context.Context, event *models.MovieEvent) error {
+	return m.queries().CreateMovieEvent(ctx, db.CreateMovieEventParams{
+		UserID:    event.UserId,
+		TmdbID:    event.TmdbId,
+		Action:    db.EventActions(event.Action),
+		OldState:  toNullState(event.OldState),
+		NewState:  toNullState(event.NewState),
+		OldPinned: toBool(event.OldPinned),
+		NewPinned: toBool(event.NewPinned),
+		Source:    db.EventSources(event.Source),
+	})
+}
+
+// Move places a movie right before or after the anchor in the manual order of its user. Only the moved
+// row changes, unless the anchor and its neighbour are adjacent and the positions get spread out first.
+// The moved, anchor and neighbour rows are locked as they are read, a concurrent move next to the same
+// anchor then waits and places its movie from the committed positions
+func (m *movie) Move(ctx context.Context, userId uuid.UUID, tmdbId, anchorTmdbId uint64, after bool) error {
+	tx, err := m.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := m.client.Queries().WithTx(tx)
+
+	movie, err := q.FindMoviePosition(ctx, db.FindMoviePositionParams{TmdbID: tmdbId, UserID: userId})
+	if err != nil {
+		return err
+	}
+
+	position, ok, err := positionNextTo(ctx, q, userId, movie.ID, anchorTmdbId, after)
+	if err == nil && !ok {
+		if err = q.RebalanceMoviePositions(ctx, userId); err == nil {
+			position, _, err = positionNextTo(ctx, q, userId, movie.ID, anchorTmdbId, after)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	err = q.UpdateMoviePosition(ctx, db.UpdateMoviePositionParams{ID: movie.ID, Position: position})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// positionNextTo returns the position halfway between the anchor and its neighbour on the given side,
+// false when they are adjacent and leave no room in between
+func positionNextTo(ctx context.Context, q *db.Queries, userId, movieId uuid.UUID, anchorTmdbId uint64, after bool) (int64, bool, error) {
+	anchor, err := q.FindMoviePosition(ctx, db.FindMoviePositionParams{TmdbID: anchorTmdbId, UserID: userId})
+	if err != nil {
+		return 0, false, err
+	}
+
+	var neighbour int64
+	if after {
+		neighbour, err = q.FindNextMoviePosition(ctx, db.FindNextMoviePositionParams{
+			UserID:    userId,
+			Position:  anchor.Position,
+			ExcludeID: movieId,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return anchor.Position + positionGap, true, nil
+		}
+	} else {
+		neighbour, err = q.FindPreviousMoviePosition(ctx, db.FindPreviousMoviePositionParams{
+			UserID:    userId,
+			Position:  anchor.Position,
+			ExcludeID: movieId,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return anchor.Position - positionGap, true, nil
+		}
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	position := anchor.Position + (neighbour-anchor.Position)/2
+
+	return position, position != anchor.Position && position != neighbour, nil
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"biinge-api/internal/app/models"
+)
+
+// NOTE: lists are selected, filtered and sorted on the library copies of title, poster and runtime,
+// which the titles refresher keeps in sync with the catalog, so a page shows the values it was sorted
+// on and every condition and sort key can use an index
+const movieListSelect = `SELECT
+  m.id,
+  m.user_id,
+  m.tmdb_id,
+  m.title,
+  COALESCE(m.poster_path, '')::varchar AS poster_path,
+  m.runtime,
+  m.pinned,
+  m.state,
+  m.created_at,
+  m.updated_at,
+  m.missing_on_tmdb,
+  m.progress`
+
+// movieSortColumn is a sort key of the movies list, cast restores the type of its text form in cursors
+type movieSortColumn struct {
+	expression string
+	cast       string
+}
+
+var movieSortColumns = map[string]movieSortColumn{
+	models.MovieSortAdded:   {expression: "m.created_at", cast: "timestamp"},
+	models.MovieSortUpdated: {expression: "m.updated_at", cast: "timestamp"},
+	models.MovieSortTitle:   {expression: "lower(m.title)", cast: "text"},
+	models.MovieSortRuntime: {expression: "m.runtime", cast: "integer"},
+	models.MovieSortManual:  {expression: "m.position", cast: "bigint"},
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// listQuery collects the conditions of a dynamic query and numbers its arguments
+type listQuery struct {
+	conditions []string
+	args       []any
+}
+
+func (q *listQuery) arg(value any) string {
+	q.args = append(q.args, value)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+func (q *listQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// newMovieListQuery returns the conditions selecting the movies of a user matching filter.
+// Only the given filters become conditions, keeping the plans free of "param IS NULL OR" branches
+func newMovieListQuery(userId uuid.UUID, filter *models.MovieFilter) *listQuery {
+	q := &listQuery{}
+
+	q.where("m.user_id = " + q.arg(userId))
+	q.where("m.deleted_at IS NULL")
+	q.where("m.state = " + q.arg(filter.State))
+
+	if filter.AvailableOn != nil {
+		q.where("m.watch_providers && " + q.arg(filter.AvailableOn) + "::integer[]")
+	}
+	if filter.Pinned != nil {
+		q.where("m.pinned = " + q.arg(*filter.Pinned))
+	}
+	if filter.AddedAfter != nil {
+		q.where("m.created_at >= " + q.arg(*filter.AddedAfter))
+	}
+	if filter.AddedBefore != nil {
+		q.where("m.created_at < " + q.arg(*filter.AddedBefore))
+	}
+	if filter.RuntimeMin != nil {
+		q.where("m.runtime >= " + q.arg(int64(*filter.RuntimeMin)))
+	}
+	if filter.RuntimeMax != nil {
+		q.where("m.runtime <= " + q.arg(int64(*filter.RuntimeMax)))
+	}
+	if len(filter.Tags) > 0 {
+		// NOTE: a movie only carries tags of its owner, and must hold every one of the distinct names
+		q.where("m.id IN (SELECT mt.movie_id FROM movie_tags mt JOIN tags tg ON tg.id = mt.tag_id" +
+			" WHERE lower(tg.name) = ANY(" + q.arg(filter.Tags) + "::text[])" +
+			" GROUP BY mt.movie_id HAVING COUNT(*) = " + q.arg(len(filter.Tags)) + ")")
+	}
+	if filter.Query != "" {
+		q.where("m.title ILIKE '%' || " + q.arg(likeEscaper.Replace(filter.Query)) + " || '%'")
+	}
+
+	return q
+}
+
+// movieSort returns the sort column of filter and its SQL direction
+func movieSort(filter *models.MovieFilter) (movieSortColumn, string) {
+	column, ok := movieSortColumns[filter.Sort]
+	if !ok {
+		column = movieSortColumns[models.MovieSortAdded]
+	}
+
+	if filter.Direction == models.SortAsc {
+		return column, "ASC"
+	}
+
+	return column, "DESC"
+}
+
+// buildMovieListQuery returns the SQL and arguments listing a page of movies, starting after the
+// cursor when one is given. Pinned movies come first and the id breaks ties between equal sort keys
+func buildMovieListQuery(userId uuid.UUID, filter *models.MovieFilter, after *models.MovieCursor, limit, offset uint64) (string, []any) {
+	q := newMovieListQuery(userId, filter)
+	column, direction := movieSort(filter)
+
+	if after != nil {
+		comparison := ">"
+		if direction == "DESC" {
+			comparison = "<"
+		}
+
+		pinned := q.arg(after.Pinned)
+		q.where("(m.pinned < " + pinned + " OR (m.pinned = " + pinned + " AND (" + column.expression + ", m.id) " +
+			comparison + " (" + q.arg(after.Key) + "::" + column.cast + ", " + q.arg(after.Id) + "::uuid)))")
+	}
+
+	var sql strings.Builder
+	sql.WriteString(movieListSelect)
+	sql.WriteString(",\n  (" + column.expression + ")::text AS sort_key")
+	sql.WriteString("\nFROM movies m")
+	sql.WriteString("\nWHERE ")
+	sql.WriteString(strings.Join(q.conditions, " AND "))
+	sql.WriteString("\nORDER BY m.pinned DESC, " + column.expression + " " + direction + ", m.id " + direction)
+	sql.WriteString("\nLIMIT " + q.arg(limit) + " OFFSET " + q.arg(offset))
+
+	return sql.String(), q.args
+}
+
+// buildMovieCountQuery returns the SQL and arguments counting the movies matching filter
+func buildMovieCountQuery(userId uuid.UUID, filter *models.MovieFilter) (string, []any) {
+	q := newMovieListQuery(userId, filter)
+
+	return "SELECT COUNT(*) FROM movies m WHERE " + strings.Join(q.conditions, " AND "), q.args
+}
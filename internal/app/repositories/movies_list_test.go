@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories/postgres"
+	"biinge-api/internal/config"
+)
+
+func Test_MovieRepository_List_Pages(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		DatabaseDSN: os.Getenv("DATABASE_DSN"),
+	}
+
+	client, err := postgres.NewPostgresClient(cfg)
+	assert.NoError(t, err)
+
+	repository := NewMovieRepository(client)
+
+	userId := createTestUser(t, ctx, client)
+	createTestMovies(t, ctx, repository, userId, 201, 202, 203, 204, 205, 206, 207, 208, 209)
+
+	// NOTE: pinned movies, titles equal regardless of case, runtimes and timestamps tied on several rows,
+	// a title without runtime in the catalog and titles missing from it
+	statements := []string{
+		`UPDATE movies m SET title = v.title, runtime = v.runtime FROM (VALUES
+			(201, 'Alien', 117), (202, 'alien', 117), (203, 'Brazil', 0), (204, 'Brazil', 0), (205, 'Casablanca', 102),
+			(206, 'alien', 117), (207, 'Dune', 0), (208, 'Casablanca', 102), (209, 'Eraserhead', 89)
+		) AS v(tmdb_id, title, runtime) WHERE m.user_id = $1 AND m.tmdb_id = v.tmdb_id`,
+		`UPDATE movies SET pinned = true WHERE user_id = $1 AND tmdb_id IN (201, 202, 203)`,
+		`UPDATE movies SET created_at = '2025-01-01', updated_at = '2025-01-01', position = 0 WHERE user_id = $1 AND tmdb_id <= 205`,
+	}
+	for _, statement := range statements {
+		_, err := client.Db().Exec(ctx, statement, userId)
+		assert.NoError(t, err)
+	}
+
+	_, err = client.Db().Exec(ctx, `UPDATE titles SET metadata = metadata - 'runtime' WHERE tmdb_id = 204`)
+	assert.NoError(t, err)
+	_, err = client.Db().Exec(ctx, `DELETE FROM titles WHERE tmdb_id IN (207, 208)`)
+	assert.NoError(t, err)
+
+	sorts := []string{
+		models.MovieSortAdded,
+		models.MovieSortUpdated,
+		models.MovieSortTitle,
+		models.MovieSortRuntime,
+		models.MovieSortManual,
+	}
+
+	for _, sort := range sorts {
+		for _, direction := range []string{models.SortAsc, models.SortDesc} {
+			t.Run(sort+" "+direction, func(t *testing.T) {
+				filter := &models.MovieFilter{State: models.StateTypeWant, Sort: sort, Direction: direction}
+
+				all, next, err := repository.List(ctx, userId, filter, nil, 100, 0)
+				assert.NoError(t, err)
+				assert.Nil(t, next)
+				assert.Len(t, all, 9)
+
+				expected := make([]uint64, 0, len(all))
+				for i, movie := range all {
+					assert.Equal(t, i < 3, movie.Pinned)
+					expected = append(expected, movie.TmdbId)
+				}
+
+				paged := make([]uint64, 0, len(all))
+				var after *models.MovieCursor
+				for range len(all) {
+					page, next, err := repository.List(ctx, userId, filter, after, 2, 0)
+					assert.NoError(t, err)
+
+					for _, movie := range page {
+						paged = append(paged, movie.TmdbId)
+					}
+
+					if next == nil {
+						break
+					}
+					after = next
+				}
+
+				assert.Equal(t, expected, paged)
+			})
+		}
+	}
+}
@@ -0,0 +1,410 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: movies.go
+//
+// Generated by this command:
+//
+//	mockgen -source=movies.go -destination=movies_mock.go -package=repositories
+//
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	models "biinge-api/internal/app/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMovieRepository is a mock of MovieRepository interface.
+type MockMovieRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovieRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMovieRepositoryMockRecorder is the mock recorder for MockMovieRepository.
+type MockMovieRepositoryMockRecorder struct {
+	mock *MockMovieRepository
+}
+
+// NewMockMovieRepository creates a new mock instance.
+func NewMockMovieRepository(ctrl *gomock.Controller) *MockMovieRepository {
+	mock := &MockMovieRepository{ctrl: ctrl}
+	mock.recorder = &MockMovieRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovieRepository) EXPECT() *MockMovieRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddTags mocks base method.
+func (m *MockMovieRepository) AddTags(ctx context.Context, movieId, userId uuid.UUID, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", ctx, movieId, userId, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockMovieRepositoryMockRecorder) AddTags(ctx, movieId, userId, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockMovieRepository)(nil).AddTags), ctx, movieId, userId, names)
+}
+
+// Count mocks base method.
+func (m *MockMovieRepository) Count(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, userId, filter)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockMovieRepositoryMockRecorder) Count(ctx, userId, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockMovieRepository)(nil).Count), ctx, userId, filter)
+}
+
+// CountTrashed mocks base method.
+func (m *MockMovieRepository) CountTrashed(ctx context.Context, userId uuid.UUID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTrashed", ctx, userId)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTrashed indicates an expected call of CountTrashed.
+func (mr *MockMovieRepositoryMockRecorder) CountTrashed(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTrashed", reflect.TypeOf((*MockMovieRepository)(nil).CountTrashed), ctx, userId)
+}
+
+// Create mocks base method.
+func (m *MockMovieRepository) Create(ctx context.Context, params *models.Movie) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMovieRepositoryMockRecorder) Create(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMovieRepository)(nil).Create), ctx, params)
+}
+
+// CreateEvent mocks base method.
+func (m *MockMovieRepository) CreateEvent(ctx context.Context, event *models.MovieEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockMovieRepositoryMockRecorder) CreateEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockMovieRepository)(nil).CreateEvent), ctx, event)
+}
+
+// Delete mocks base method.
+func (m *MockMovieRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMovieRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMovieRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteByTmdbId mocks base method.
+func (m *MockMovieRepository) DeleteByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTmdbId", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTmdbId indicates an expected call of DeleteByTmdbId.
+func (mr *MockMovieRepositoryMockRecorder) DeleteByTmdbId(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTmdbId", reflect.TypeOf((*MockMovieRepository)(nil).DeleteByTmdbId), ctx, tmdbId, userId)
+}
+
+// EmptyTrash mocks base method.
+func (m *MockMovieRepository) EmptyTrash(ctx context.Context, userId uuid.UUID, source string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmptyTrash", ctx, userId, source)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmptyTrash indicates an expected call of EmptyTrash.
+func (mr *MockMovieRepositoryMockRecorder) EmptyTrash(ctx, userId, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyTrash", reflect.TypeOf((*MockMovieRepository)(nil).EmptyTrash), ctx, userId, source)
+}
+
+// FindById mocks base method.
+func (m *MockMovieRepository) FindById(ctx context.Context, id uuid.UUID) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockMovieRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockMovieRepository)(nil).FindById), ctx, id)
+}
+
+// FindByTmdbId mocks base method.
+func (m *MockMovieRepository) FindByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTmdbId", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTmdbId indicates an expected call of FindByTmdbId.
+func (mr *MockMovieRepositoryMockRecorder) FindByTmdbId(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTmdbId", reflect.TypeOf((*MockMovieRepository)(nil).FindByTmdbId), ctx, tmdbId, userId)
+}
+
+// FindMoviesByTmdbIds mocks base method.
+func (m *MockMovieRepository) FindMoviesByTmdbIds(ctx context.Context, tmdbIds []uint64, userId uuid.UUID) ([]models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMoviesByTmdbIds", ctx, tmdbIds, userId)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMoviesByTmdbIds indicates an expected call of FindMoviesByTmdbIds.
+func (mr *MockMovieRepositoryMockRecorder) FindMoviesByTmdbIds(ctx, tmdbIds, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMoviesByTmdbIds", reflect.TypeOf((*MockMovieRepository)(nil).FindMoviesByTmdbIds), ctx, tmdbIds, userId)
+}
+
+// FindTrashed mocks base method.
+func (m *MockMovieRepository) FindTrashed(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrashed", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrashed indicates an expected call of FindTrashed.
+func (mr *MockMovieRepositoryMockRecorder) FindTrashed(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrashed", reflect.TypeOf((*MockMovieRepository)(nil).FindTrashed), ctx, tmdbId, userId)
+}
+
+// FindWithStaleWatchProviders mocks base method.
+func (m *MockMovieRepository) FindWithStaleWatchProviders(ctx context.Context, defaultRegion string, refreshedBefore time.Time, limit uint64) ([]models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithStaleWatchProviders", ctx, defaultRegion, refreshedBefore, limit)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWithStaleWatchProviders indicates an expected call of FindWithStaleWatchProviders.
+func (mr *MockMovieRepositoryMockRecorder) FindWithStaleWatchProviders(ctx, defaultRegion, refreshedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithStaleWatchProviders", reflect.TypeOf((*MockMovieRepository)(nil).FindWithStaleWatchProviders), ctx, defaultRegion, refreshedBefore, limit)
+}
+
+// List mocks base method.
+func (m *MockMovieRepository) List(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter, after *models.MovieCursor, limit, offset uint64) ([]models.Movie, *models.MovieCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userId, filter, after, limit, offset)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(*models.MovieCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockMovieRepositoryMockRecorder) List(ctx, userId, filter, after, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMovieRepository)(nil).List), ctx, userId, filter, after, limit, offset)
+}
+
+// ListTrashed mocks base method.
+func (m *MockMovieRepository) ListTrashed(ctx context.Context, userId uuid.UUID, limit, offset uint64) ([]models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashed", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrashed indicates an expected call of ListTrashed.
+func (mr *MockMovieRepositoryMockRecorder) ListTrashed(ctx, userId, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashed", reflect.TypeOf((*MockMovieRepository)(nil).ListTrashed), ctx, userId, limit, offset)
+}
+
+// Move mocks base method.
+func (m *MockMovieRepository) Move(ctx context.Context, userId uuid.UUID, tmdbId, anchorTmdbId uint64, after bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, userId, tmdbId, anchorTmdbId, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockMovieRepositoryMockRecorder) Move(ctx, userId, tmdbId, anchorTmdbId, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockMovieRepository)(nil).Move), ctx, userId, tmdbId, anchorTmdbId, after)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockMovieRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time, source string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", ctx, deletedBefore, source)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockMovieRepositoryMockRecorder) PurgeTrash(ctx, deletedBefore, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockMovieRepository)(nil).PurgeTrash), ctx, deletedBefore, source)
+}
+
+// Restore mocks base method.
+func (m *MockMovieRepository) Restore(ctx context.Context, tmdbId uint64, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockMovieRepositoryMockRecorder) Restore(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockMovieRepository)(nil).Restore), ctx, tmdbId, userId)
+}
+
+// Transaction mocks base method.
+func (m *MockMovieRepository) Transaction(ctx context.Context, fn func(MovieRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockMovieRepositoryMockRecorder) Transaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockMovieRepository)(nil).Transaction), ctx, fn)
+}
+
+// Trash mocks base method.
+func (m *MockMovieRepository) Trash(ctx context.Context, tmdbId uint64, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trash", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trash indicates an expected call of Trash.
+func (mr *MockMovieRepositoryMockRecorder) Trash(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trash", reflect.TypeOf((*MockMovieRepository)(nil).Trash), ctx, tmdbId, userId)
+}
+
+// Update mocks base method.
+func (m *MockMovieRepository) Update(ctx context.Context, params *models.Movie) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, params)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMovieRepositoryMockRecorder) Update(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMovieRepository)(nil).Update), ctx, params)
+}
+
+// UpdateByTmdbId mocks base method.
+func (m *MockMovieRepository) UpdateByTmdbId(ctx context.Context, params *models.Movie) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByTmdbId", ctx, params)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateByTmdbId indicates an expected call of UpdateByTmdbId.
+func (mr *MockMovieRepositoryMockRecorder) UpdateByTmdbId(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByTmdbId", reflect.TypeOf((*MockMovieRepository)(nil).UpdateByTmdbId), ctx, params)
+}
+
+// UpdateNotes mocks base method.
+func (m *MockMovieRepository) UpdateNotes(ctx context.Context, tmdbId uint64, userId uuid.UUID, notes string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotes", ctx, tmdbId, userId, notes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotes indicates an expected call of UpdateNotes.
+func (mr *MockMovieRepositoryMockRecorder) UpdateNotes(ctx, tmdbId, userId, notes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotes", reflect.TypeOf((*MockMovieRepository)(nil).UpdateNotes), ctx, tmdbId, userId, notes)
+}
+
+// UpdateWatchProviders mocks base method.
+func (m *MockMovieRepository) UpdateWatchProviders(ctx context.Context, ids []uuid.UUID, region string, providers []int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWatchProviders", ctx, ids, region, providers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWatchProviders indicates an expected call of UpdateWatchProviders.
+func (mr *MockMovieRepositoryMockRecorder) UpdateWatchProviders(ctx, ids, region, providers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWatchProviders", reflect.TypeOf((*MockMovieRepository)(nil).UpdateWatchProviders), ctx, ids, region, providers)
+}
+
+// Upsert mocks base method.
+func (m *MockMovieRepository) Upsert(ctx context.Context, params *models.Movie) (*models.MovieUpsert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, params)
+	ret0, _ := ret[0].(*models.MovieUpsert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockMovieRepositoryMockRecorder) Upsert(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockMovieRepository)(nil).Upsert), ctx, params)
+}
@@ -0,0 +1,247 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories/db"
+	"biinge-api/internal/app/repositories/postgres"
+	"biinge-api/internal/config"
+)
+
+// createTestUser adds a user of its own to a test, so the libraries of tests do not mix
+func createTestUser(t *testing.T, ctx context.Context, client postgres.Postgres) uuid.UUID {
+	t.Helper()
+
+	login := "user-" + uuid.NewString()[:8]
+	user, err := NewUserRepository(client).Create(ctx, db.CreateUserParams{
+		Login:             login,
+		Email:             login + "@local",
+		EncryptedPassword: "SECRET",
+		FirstName:         "John",
+		LastName:          "Doe",
+		Appearance:        models.DarkAppearance,
+	})
+	if err != nil {
+		t.Fatalf("Error creating user: %v", err)
+	}
+
+	return user.ID
+}
+
+// createTestMovies adds a movie to the library of a user for each TMDB id, in the wanted list
+func createTestMovies(t *testing.T, ctx context.Context, repository MovieRepository, userId uuid.UUID, tmdbIds ...uint64) []*models.Movie {
+	t.Helper()
+
+	movies := make([]*models.Movie, 0, len(tmdbIds))
+	for _, tmdbId := range tmdbIds {
+		movie, err := repository.Create(ctx, &models.Movie{
+			UserId:  userId,
+			TmdbId:  tmdbId,
+			Title:   "Movie",
+			Runtime: 120,
+			State:   models.StateTypeWant,
+		})
+		if err != nil {
+			t.Fatalf("Error creating movie: %v", err)
+		}
+
+		movies = append(movies, movie)
+	}
+
+	return movies
+}
+
+func Test_MovieRepository_Move(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		DatabaseDSN: os.Getenv("DATABASE_DSN"),
+	}
+
+	client, err := postgres.NewPostgresClient(cfg)
+	assert.NoError(t, err)
+
+	repository := NewMovieRepository(client)
+
+	type position struct {
+		tmdbId   uint64
+		position int64
+	}
+
+	tests := []struct {
+		name      string
+		positions []int64
+		tmdbId    uint64
+		anchor    uint64
+		after     bool
+		expected  []position
+	}{
+		{
+			name:      "Before the first movie",
+			positions: []int64{65536, 131072, 196608},
+			tmdbId:    103,
+			anchor:    101,
+			expected:  []position{{103, 0}, {101, 65536}, {102, 131072}},
+		},
+		{
+			name:      "After the last movie",
+			positions: []int64{65536, 131072, 196608},
+			tmdbId:    101,
+			anchor:    103,
+			after:     true,
+			expected:  []position{{102, 131072}, {103, 196608}, {101, 262144}},
+		},
+		{
+			name:      "Between two movies",
+			positions: []int64{65536, 131072, 196608},
+			tmdbId:    103,
+			anchor:    101,
+			after:     true,
+			expected:  []position{{101, 65536}, {103, 98304}, {102, 131072}},
+		},
+		{
+			name:      "Rebalances adjacent movies",
+			positions: []int64{1, 2, 3},
+			tmdbId:    103,
+			anchor:    101,
+			after:     true,
+			expected:  []position{{101, 65536}, {103, 98304}, {102, 131072}},
+		},
+		{
+			name:      "Rebalances before adjacent movies",
+			positions: []int64{1, 2, 3},
+			tmdbId:    101,
+			anchor:    103,
+			expected:  []position{{102, 131072}, {101, 163840}, {103, 196608}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId := createTestUser(t, ctx, client)
+			movies := createTestMovies(t, ctx, repository, userId, 101, 102, 103)
+			for i, movie := range movies {
+				err := client.Queries().UpdateMoviePosition(ctx, db.UpdateMoviePositionParams{
+					ID:       movie.ID,
+					Position: tt.positions[i],
+				})
+				assert.NoError(t, err)
+			}
+
+			err := repository.Move(ctx, userId, tt.tmdbId, tt.anchor, tt.after)
+			assert.NoError(t, err)
+
+			rows, err := client.Db().Query(ctx, "SELECT tmdb_id, position FROM movies WHERE user_id = $1 ORDER BY position", userId)
+			assert.NoError(t, err)
+			defer rows.Close()
+
+			result := make([]position, 0, len(tt.expected))
+			for rows.Next() {
+				var row position
+				assert.NoError(t, rows.Scan(&row.tmdbId, &row.position))
+				result = append(result, row)
+			}
+			assert.NoError(t, rows.Err())
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_MovieRepository_Upsert(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		DatabaseDSN: os.Getenv("DATABASE_DSN"),
+	}
+
+	client, err := postgres.NewPostgresClient(cfg)
+	assert.NoError(t, err)
+
+	repository := NewMovieRepository(client)
+	userId := createTestUser(t, ctx, client)
+
+	// NOTE: the steps run in order on the same library row
+	tests := []struct {
+		name     string
+		before   func()
+		state    string
+		pinned   bool
+		inserted bool
+		changed  bool
+		previous *models.Movie
+		trashed  bool
+	}{
+		{
+			name:     "Creates a new title",
+			before:   func() {},
+			state:    models.StateTypeWant,
+			inserted: true,
+			changed:  true,
+		},
+		{
+			name:     "Leaves an unchanged title alone",
+			before:   func() {},
+			state:    models.StateTypeWant,
+			previous: &models.Movie{State: models.StateTypeWant},
+		},
+		{
+			name:     "Updates a title of the library",
+			before:   func() {},
+			state:    models.StateTypeWatched,
+			pinned:   true,
+			changed:  true,
+			previous: &models.Movie{State: models.StateTypeWant},
+		},
+		{
+			name: "Restores a trashed title",
+			before: func() {
+				assert.NoError(t, repository.Trash(ctx, 301, userId))
+			},
+			state:    models.StateTypeWatched,
+			pinned:   true,
+			changed:  true,
+			previous: &models.Movie{State: models.StateTypeWatched, Pinned: true},
+			trashed:  true,
+		},
+	}
+
+	var updatedAt time.Time
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := repository.Upsert(ctx, &models.Movie{
+				UserId:  userId,
+				TmdbId:  301,
+				Title:   "Movie",
+				Runtime: 120,
+				State:   tt.state,
+				Pinned:  tt.pinned,
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.inserted, result.Inserted)
+			assert.Equal(t, tt.changed, result.Changed)
+			assert.Equal(t, tt.state, result.Movie.State)
+			assert.Equal(t, tt.pinned, result.Movie.Pinned)
+
+			if tt.previous == nil {
+				assert.Nil(t, result.Previous)
+			} else {
+				assert.Equal(t, tt.previous.State, result.Previous.State)
+				assert.Equal(t, tt.previous.Pinned, result.Previous.Pinned)
+				assert.Equal(t, tt.trashed, result.Previous.DeletedAt != nil)
+			}
+
+			if !tt.changed {
+				assert.Equal(t, updatedAt, result.Movie.UpdatedAt)
+			}
+			updatedAt = result.Movie.UpdatedAt
+		})
+	}
+}
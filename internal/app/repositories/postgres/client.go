@@ -12,6 +12,7 @@ import (
 type Postgres interface {
 	Db() *pgxpool.Pool
 	Queries() *db.Queries
+	Transaction(ctx context.Context, fn func(queries *db.Queries) error) error
 }
 
 type pgClient struct {
@@ -45,3 +46,19 @@ func (p *pgClient) Db() *pgxpool.Pool {
 func (p *pgClient) Queries() *db.Queries {
 	return p.queries
 }
+
+// Transaction runs fn with queries bound to a new transaction, committed when fn succeeds and rolled back
+// otherwise
+func (p *pgClient) Transaction(ctx context.Context, fn func(queries *db.Queries) error) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(p.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
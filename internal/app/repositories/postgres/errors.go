@@ -0,0 +1,17 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the SQLSTATE raised when a write breaks a unique index
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err comes from a write breaking a unique index, such as a row
+// inserted concurrently by a retried request
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "Unique violation",
+			err:      &pgconn.PgError{Code: "23505"},
+			expected: true,
+		},
+		{
+			name:     "Wrapped unique violation",
+			err:      fmt.Errorf("failed to create movie: %w", &pgconn.PgError{Code: "23505"}),
+			expected: true,
+		},
+		{
+			name: "Other database error",
+			err:  &pgconn.PgError{Code: "23503"},
+		},
+		{
+			name: "Other error",
+			err:  assert.AnError,
+		},
+		{
+			name: "No error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsUniqueViolation(tt.err))
+		})
+	}
+}
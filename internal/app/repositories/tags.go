@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories/db"
+	"biinge-api/internal/app/repositories/postgres"
+)
+
+type TagRepository interface {
+	List(ctx context.Context, userId uuid.UUID) ([]models.Tag, error)
+	FindByName(ctx context.Context, userId uuid.UUID, name string) (*models.Tag, error)
+	Create(ctx context.Context, params *models.Tag) (*models.Tag, error)
+	Rename(ctx context.Context, params *models.Tag) (*models.Tag, error)
+	Delete(ctx context.Context, id, userId uuid.UUID) error
+	SetMovieTags(ctx context.Context, userId uuid.UUID, tmdbId uint64, names []string) error
+}
+
+type tag struct {
+	client postgres.Postgres
+}
+
+func NewTagRepository(client postgres.Postgres) TagRepository {
+	return &tag{client: client}
+}
+
+func (t *tag) List(ctx context.Context, userId uuid.UUID) ([]models.Tag, error) {
+	rows, err := t.client.Queries().ListTags(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]models.Tag, 0, len(rows))
+	for _, row := range rows {
+		tags = append(tags, models.Tag{
+			ID:          row.ID,
+			UserId:      row.UserID,
+			Name:        row.Name,
+			MoviesCount: uint64(row.MoviesCount),
+			CreatedAt:   row.CreatedAt.Time,
+			UpdatedAt:   row.UpdatedAt.Time,
+		})
+	}
+
+	return tags, nil
+}
+
+func (t *tag) FindByName(ctx context.Context, userId uuid.UUID, name string) (*models.Tag, error) {
+	result, err := t.client.Queries().FindTagByName(ctx, db.FindTagByNameParams{
+		UserID: userId,
+		Name:   name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTag(result), nil
+}
+
+func (t *tag) Create(ctx context.Context, params *models.Tag) (*models.Tag, error) {
+	result, err := t.client.Queries().CreateTag(ctx, db.CreateTagParams{
+		UserID: params.UserId,
+		Name:   params.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTag(result), nil
+}
+
+func (t *tag) Rename(ctx context.Context, params *models.Tag) (*models.Tag, error) {
+	result, err := t.client.Queries().RenameTag(ctx, db.RenameTagParams{
+		ID:     params.ID,
+		UserID: params.UserId,
+		Name:   params.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTag(result), nil
+}
+
+// Delete removes a tag from every movie of its user, pgx.ErrNoRows when the user holds no such tag
+func (t *tag) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	deleted, err := t.client.Queries().DeleteTag(ctx, db.DeleteTagParams{ID: id, UserID: userId})
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetMovieTags replaces the tags of a movie, names the user has not used yet are created along the way
+// and existing ones are matched regardless of case
+func (t *tag) SetMovieTags(ctx context.Context, userId uuid.UUID, tmdbId uint64, names []string) error {
+	tx, err := t.client.Db().Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := t.client.Queries().WithTx(tx)
+
+	movie, err := q.FindMoviePosition(ctx, db.FindMoviePositionParams{TmdbID: tmdbId, UserID: userId})
+	if err != nil {
+		return err
+	}
+
+	if err := q.ClearMovieTags(ctx, movie.ID); err != nil {
+		return err
+	}
+
+	if len(names) > 0 {
+		lowered := make([]string, 0, len(names))
+		for _, name := range names {
+			lowered = append(lowered, strings.ToLower(name))
+		}
+
+		if err := q.EnsureTags(ctx, db.EnsureTagsParams{UserID: userId, Names: names}); err != nil {
+			return err
+		}
+
+		err = q.TagMovie(ctx, db.TagMovieParams{MovieID: movie.ID, UserID: userId, Names: lowered})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func newTag(row db.Tag) *models.Tag {
+	return &models.Tag{
+		ID:        row.ID,
+		UserId:    row.UserID,
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories/db"
+	"biinge-api/internal/app/repositories/postgres"
+)
+
+type TitleRepository interface {
+	FindByTmdbId(ctx context.Context, tmdbId uint64) (*models.Title, error)
+	Refresh(ctx context.Context, title *models.Title) error
+	MarkMissing(ctx context.Context, tmdbId uint64, source string) error
+	Merge(ctx context.Context, fromTmdbId uint64, toTmdbId uint64, source string) (uint64, error)
+	Invalidate(ctx context.Context, tmdbIds []uint64) (uint64, error)
+	FindStaleIds(ctx context.Context, fetchedBefore time.Time, limit uint64) ([]uint64, error)
+	FindChangesCursor(ctx context.Context, feed string) (time.Time, error)
+	SaveChangesCursor(ctx context.Context, feed string, syncedUntil time.Time) error
+}
+
+type title struct {
+	client postgres.Postgres
+}
+
+// titleMetadata is the jsonb document stored in titles.metadata
+type titleMetadata struct {
+	Title         string `json:"title"`
+	OriginalTitle string `json:"original_title,omitempty"`
+	PosterPath    string `json:"poster_path"`
+	BackdropPath  string `json:"backdrop_path,omitempty"`
+	ReleaseDate   string `json:"release_date,omitempty"`
+	Runtime       uint64 `json:"runtime"`
+	GenreIds      []int  `json:"genre_ids,omitempty"`
+	Adult         bool   `json:"adult,omitempty"`
+}
+
+func NewTitleRepository(client postgres.Postgres) TitleRepository {
+	return &title{client: client}
+}
+
+func (t *title) FindByTmdbId(ctx context.Context, tmdbId uint64) (*models.Title, error) {
+	result, err := t.client.Queries().FindTitleByTmdbId(ctx, tmdbId)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata titleMetadata
+	if err := json.Unmarshal(result.Metadata, &metadata); err != nil {
+		return nil, err
+	}
+
+	return &models.Title{
+		TmdbId:        result.TmdbID,
+		Title:         metadata.Title,
+		OriginalTitle: metadata.OriginalTitle,
+		PosterPath:    metadata.PosterPath,
+		BackdropPath:  metadata.BackdropPath,
+		ReleaseDate:   metadata.ReleaseDate,
+		Runtime:       metadata.Runtime,
+		GenreIds:      metadata.GenreIds,
+		Adult:         metadata.Adult,
+		FetchedAt:     timePtr(result.FetchedAt),
+	}, nil
+}
+
+// Refresh stores freshly fetched metadata in the catalog and copies it to every library row of the title
+func (t *title) Refresh(ctx context.Context, params *models.Title) error {
+	metadata, err := json.Marshal(newTitleMetadata(params))
+	if err != nil {
+		return err
+	}
+
+	err = t.client.Queries().UpsertTitle(ctx, db.UpsertTitleParams{
+		TmdbID:   params.TmdbId,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.client.Queries().SyncMoviesWithTitle(ctx, db.SyncMoviesWithTitleParams{
+		Title:      params.Title,
+		PosterPath: params.PosterPath,
+		Runtime:    params.Runtime,
+		TmdbID:     params.TmdbId,
+	})
+}
+
+// MarkMissing keeps the last metadata of a title deleted on TMDB and flags its library rows, recording
+// an event coming from source for each row flagged
+func (t *title) MarkMissing(ctx context.Context, tmdbId uint64, source string) error {
+	return t.client.Transaction(ctx, func(queries *db.Queries) error {
+		return markMissing(ctx, queries, tmdbId, source)
+	})
+}
+
+// Merge re-points the library rows of a title merged on TMDB, along with their history, to the surviving id
+// and returns how many moved. Rows of users already holding the surviving id stay on the old one and are
+// flagged as missing. Each changed row records an event coming from source
+func (t *title) Merge(ctx context.Context, fromTmdbId uint64, toTmdbId uint64, source string) (uint64, error) {
+	var moved int64
+	err := t.client.Transaction(ctx, func(queries *db.Queries) error {
+		// NOTE: history moves first, its users are told apart by the rows not re-pointed yet
+		err := queries.RepointMovieEvents(ctx, db.RepointMovieEventsParams{
+			ToTmdbID:   toTmdbId,
+			FromTmdbID: fromTmdbId,
+		})
+		if err != nil {
+			return err
+		}
+
+		moved, err = queries.RepointMovies(ctx, db.RepointMoviesParams{
+			ToTmdbID:   toTmdbId,
+			FromTmdbID: fromTmdbId,
+			Source:     db.EventSources(source),
+		})
+		if err != nil {
+			return err
+		}
+
+		return markMissing(ctx, queries, fromTmdbId, source)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(moved), nil
+}
+
+// Invalidate clears the fetch time of the given library titles so the refresher fetches them first
+func (t *title) Invalidate(ctx context.Context, tmdbIds []uint64) (uint64, error) {
+	if len(tmdbIds) == 0 {
+		return 0, nil
+	}
+
+	count, err := t.client.Queries().InvalidateTitles(ctx, tmdbIds)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(count), nil
+}
+
+// FindStaleIds returns library titles never fetched or fetched before fetchedBefore, oldest first
+func (t *title) FindStaleIds(ctx context.Context, fetchedBefore time.Time, limit uint64) ([]uint64, error) {
+	return t.client.Queries().FindStaleTitles(ctx, db.FindStaleTitlesParams{
+		FetchedBefore: pgtype.Timestamp{Time: fetchedBefore, Valid: true},
+		BatchSize:     limit,
+	})
+}
+
+// FindChangesCursor returns until when a TMDB changes feed was synced, zero when it never was
+func (t *title) FindChangesCursor(ctx context.Context, feed string) (time.Time, error) {
+	result, err := t.client.Queries().FindChangesCursor(ctx, feed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return result.Time, nil
+}
+
+func (t *title) SaveChangesCursor(ctx context.Context, feed string, syncedUntil time.Time) error {
+	return t.client.Queries().SaveChangesCursor(ctx, db.SaveChangesCursorParams{
+		Feed:        feed,
+		SyncedUntil: pgtype.Timestamp{Time: syncedUntil, Valid: true},
+	})
+}
+
+func markMissing(ctx context.Context, queries *db.Queries, tmdbId uint64, source string) error {
+	if err := queries.TouchTitle(ctx, tmdbId); err != nil {
+		return err
+	}
+
+	return queries.MarkMoviesMissingOnTmdb(ctx, db.MarkMoviesMissingOnTmdbParams{
+		TmdbID: tmdbId,
+		Source: db.EventSources(source),
+	})
+}
+
+func newTitleMetadata(params *models.Title) titleMetadata {
+	return titleMetadata{
+		Title:         params.Title,
+		OriginalTitle: params.OriginalTitle,
+		PosterPath:    params.PosterPath,
+		BackdropPath:  params.BackdropPath,
+		ReleaseDate:   params.ReleaseDate,
+		Runtime:       params.Runtime,
+		GenreIds:      params.GenreIds,
+		Adult:         params.Adult,
+	}
+}
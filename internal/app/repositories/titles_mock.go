@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: titles.go
+//
+// Generated by this command:
+//
+//	mockgen -source=titles.go -destination=titles_mock.go -package=repositories
+//
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	models "biinge-api/internal/app/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTitleRepository is a mock of TitleRepository interface.
+type MockTitleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTitleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTitleRepositoryMockRecorder is the mock recorder for MockTitleRepository.
+type MockTitleRepositoryMockRecorder struct {
+	mock *MockTitleRepository
+}
+
+// NewMockTitleRepository creates a new mock instance.
+func NewMockTitleRepository(ctrl *gomock.Controller) *MockTitleRepository {
+	mock := &MockTitleRepository{ctrl: ctrl}
+	mock.recorder = &MockTitleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTitleRepository) EXPECT() *MockTitleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByTmdbId mocks base method.
+func (m *MockTitleRepository) FindByTmdbId(ctx context.Context, tmdbId uint64) (*models.Title, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTmdbId", ctx, tmdbId)
+	ret0, _ := ret[0].(*models.Title)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTmdbId indicates an expected call of FindByTmdbId.
+func (mr *MockTitleRepositoryMockRecorder) FindByTmdbId(ctx, tmdbId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTmdbId", reflect.TypeOf((*MockTitleRepository)(nil).FindByTmdbId), ctx, tmdbId)
+}
+
+// FindChangesCursor mocks base method.
+func (m *MockTitleRepository) FindChangesCursor(ctx context.Context, feed string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindChangesCursor", ctx, feed)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindChangesCursor indicates an expected call of FindChangesCursor.
+func (mr *MockTitleRepositoryMockRecorder) FindChangesCursor(ctx, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChangesCursor", reflect.TypeOf((*MockTitleRepository)(nil).FindChangesCursor), ctx, feed)
+}
+
+// FindStaleIds mocks base method.
+func (m *MockTitleRepository) FindStaleIds(ctx context.Context, fetchedBefore time.Time, limit uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStaleIds", ctx, fetchedBefore, limit)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStaleIds indicates an expected call of FindStaleIds.
+func (mr *MockTitleRepositoryMockRecorder) FindStaleIds(ctx, fetchedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStaleIds", reflect.TypeOf((*MockTitleRepository)(nil).FindStaleIds), ctx, fetchedBefore, limit)
+}
+
+// Invalidate mocks base method.
+func (m *MockTitleRepository) Invalidate(ctx context.Context, tmdbIds []uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", ctx, tmdbIds)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockTitleRepositoryMockRecorder) Invalidate(ctx, tmdbIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockTitleRepository)(nil).Invalidate), ctx, tmdbIds)
+}
+
+// MarkMissing mocks base method.
+func (m *MockTitleRepository) MarkMissing(ctx context.Context, tmdbId uint64, source string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMissing", ctx, tmdbId, source)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMissing indicates an expected call of MarkMissing.
+func (mr *MockTitleRepositoryMockRecorder) MarkMissing(ctx, tmdbId, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMissing", reflect.TypeOf((*MockTitleRepository)(nil).MarkMissing), ctx, tmdbId, source)
+}
+
+// Merge mocks base method.
+func (m *MockTitleRepository) Merge(ctx context.Context, fromTmdbId, toTmdbId uint64, source string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, fromTmdbId, toTmdbId, source)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockTitleRepositoryMockRecorder) Merge(ctx, fromTmdbId, toTmdbId, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockTitleRepository)(nil).Merge), ctx, fromTmdbId, toTmdbId, source)
+}
+
+// Refresh mocks base method.
+func (m *MockTitleRepository) Refresh(ctx context.Context, title *models.Title) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, title)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockTitleRepositoryMockRecorder) Refresh(ctx, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockTitleRepository)(nil).Refresh), ctx, title)
+}
+
+// SaveChangesCursor mocks base method.
+func (m *MockTitleRepository) SaveChangesCursor(ctx context.Context, feed string, syncedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChangesCursor", ctx, feed, syncedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChangesCursor indicates an expected call of SaveChangesCursor.
+func (mr *MockTitleRepositoryMockRecorder) SaveChangesCursor(ctx, feed, syncedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChangesCursor", reflect.TypeOf((*MockTitleRepository)(nil).SaveChangesCursor), ctx, feed, syncedUntil)
+}
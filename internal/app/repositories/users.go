@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"biinge-api/internal/app/models"
 	"biinge-api/internal/app/repositories/db"
@@ -48,12 +50,20 @@ func (u *user) Create(ctx context.Context, params db.CreateUserParams) (*models.
 	}
 
 	return &models.User{
-		ID:         result.ID,
-		Login:      result.Login,
-		Email:      result.Email,
-		FirstName:  result.FirstName,
-		LastName:   result.LastName,
-		Appearance: string(result.Appearance),
+		ID:             result.ID,
+		Login:          result.Login,
+		Email:          result.Email,
+		FirstName:      result.FirstName,
+		LastName:       result.LastName,
+		Appearance:     string(result.Appearance),
+		Language:       result.Language,
+		Region:         result.Region,
+		WatchProviders: result.WatchProviders,
+
+		IncludeDocumentaries:  boolPtr(result.IncludeDocumentaries),
+		IncludeWithoutArtwork: boolPtr(result.IncludeWithoutArtwork),
+		IncludeAdult:          result.IncludeAdult,
+		AdultConfirmedAt:      timePtr(result.AdultConfirmedAt),
 	}, tx.Commit(ctx)
 }
 
@@ -67,22 +77,38 @@ func (u *user) Update(ctx context.Context, params db.UpdateUserParams) (*models.
 	q := u.client.Queries().WithTx(tx)
 
 	result, err := q.UpdateUser(ctx, db.UpdateUserParams{
-		ID:         params.ID,
-		FirstName:  params.FirstName,
-		LastName:   params.LastName,
-		Appearance: params.Appearance,
+		ID:             params.ID,
+		FirstName:      params.FirstName,
+		LastName:       params.LastName,
+		Appearance:     params.Appearance,
+		Language:       params.Language,
+		Region:         params.Region,
+		WatchProviders: params.WatchProviders,
+
+		IncludeDocumentaries:  params.IncludeDocumentaries,
+		IncludeWithoutArtwork: params.IncludeWithoutArtwork,
+		IncludeAdult:          params.IncludeAdult,
+		AdultConfirmedAt:      params.AdultConfirmedAt,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.User{
-		ID:         result.ID,
-		Login:      result.Login,
-		Email:      result.Email,
-		FirstName:  result.FirstName,
-		LastName:   result.LastName,
-		Appearance: string(result.Appearance),
+		ID:             result.ID,
+		Login:          result.Login,
+		Email:          result.Email,
+		FirstName:      result.FirstName,
+		LastName:       result.LastName,
+		Appearance:     string(result.Appearance),
+		Language:       result.Language,
+		Region:         result.Region,
+		WatchProviders: result.WatchProviders,
+
+		IncludeDocumentaries:  boolPtr(result.IncludeDocumentaries),
+		IncludeWithoutArtwork: boolPtr(result.IncludeWithoutArtwork),
+		IncludeAdult:          result.IncludeAdult,
+		AdultConfirmedAt:      timePtr(result.AdultConfirmedAt),
 	}, tx.Commit(ctx)
 }
 
@@ -93,12 +119,20 @@ func (u *user) FindById(ctx context.Context, id uuid.UUID) (*models.User, error)
 	}
 
 	return &models.User{
-		ID:         result.ID,
-		Login:      result.Login,
-		Email:      result.Email,
-		FirstName:  result.FirstName,
-		LastName:   result.LastName,
-		Appearance: string(result.Appearance),
+		ID:             result.ID,
+		Login:          result.Login,
+		Email:          result.Email,
+		FirstName:      result.FirstName,
+		LastName:       result.LastName,
+		Appearance:     string(result.Appearance),
+		Language:       result.Language,
+		Region:         result.Region,
+		WatchProviders: result.WatchProviders,
+
+		IncludeDocumentaries:  boolPtr(result.IncludeDocumentaries),
+		IncludeWithoutArtwork: boolPtr(result.IncludeWithoutArtwork),
+		IncludeAdult:          result.IncludeAdult,
+		AdultConfirmedAt:      timePtr(result.AdultConfirmedAt),
 	}, nil
 }
 
@@ -116,6 +150,14 @@ func (u *user) FindByLogin(ctx context.Context, login string) (*models.User, err
 		FirstName:         result.FirstName,
 		LastName:          result.LastName,
 		Appearance:        string(result.Appearance),
+		Language:          result.Language,
+		Region:            result.Region,
+		WatchProviders:    result.WatchProviders,
+
+		IncludeDocumentaries:  boolPtr(result.IncludeDocumentaries),
+		IncludeWithoutArtwork: boolPtr(result.IncludeWithoutArtwork),
+		IncludeAdult:          result.IncludeAdult,
+		AdultConfirmedAt:      timePtr(result.AdultConfirmedAt),
 	}, nil
 }
 
@@ -133,5 +175,27 @@ func (u *user) FindByEmail(ctx context.Context, email string) (*models.User, err
 		FirstName:         result.FirstName,
 		LastName:          result.LastName,
 		Appearance:        string(result.Appearance),
+		Language:          result.Language,
+		Region:            result.Region,
+		WatchProviders:    result.WatchProviders,
+
+		IncludeDocumentaries:  boolPtr(result.IncludeDocumentaries),
+		IncludeWithoutArtwork: boolPtr(result.IncludeWithoutArtwork),
+		IncludeAdult:          result.IncludeAdult,
+		AdultConfirmedAt:      timePtr(result.AdultConfirmedAt),
 	}, nil
 }
+
+func boolPtr(v pgtype.Bool) *bool {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Bool
+}
+
+func timePtr(v pgtype.Timestamp) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Time
+}
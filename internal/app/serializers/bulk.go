@@ -0,0 +1,115 @@
+package serializers
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+)
+
+type BulkOperationSerializer struct {
+	Op    string   `json:"op"`
+	Id    uint64   `json:"id"`
+	State string   `json:"state,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// BulkMoviesRequestSerializer takes the operations of a bulk request, applied atomically unless atomic
+// is false. Invalid operations do not fail the request, they are reported in their result instead
+type BulkMoviesRequestSerializer struct {
+	Atomic     *bool                     `json:"atomic"`
+	Operations []BulkOperationSerializer `json:"operations"`
+}
+
+func (params *BulkMoviesRequestSerializer) Validate(body io.Reader) error {
+	if err := json.NewDecoder(body).Decode(params); err != nil {
+		return err
+	}
+
+	if len(params.Operations) == 0 {
+		return errors.ErrEmptyBulk
+	}
+
+	if len(params.Operations) > models.MaxBulkOperations {
+		return errors.ErrTooManyOperations
+	}
+
+	return nil
+}
+
+// IsAtomic tells whether a failing operation rolls back the whole request, the default
+func (params *BulkMoviesRequestSerializer) IsAtomic() bool {
+	return params.Atomic == nil || *params.Atomic
+}
+
+// BulkOperations returns the operations to apply, with the validation failure of each one if any
+func (params *BulkMoviesRequestSerializer) BulkOperations() []models.BulkOperation {
+	operations := make([]models.BulkOperation, 0, len(params.Operations))
+	for _, item := range params.Operations {
+		operation := models.BulkOperation{
+			Op:     strings.ToLower(strings.TrimSpace(item.Op)),
+			TmdbId: item.Id,
+			State:  strings.TrimSpace(item.State),
+		}
+		operation.Err = validateBulkOperation(&operation, item.Tags)
+
+		operations = append(operations, operation)
+	}
+
+	return operations
+}
+
+func validateBulkOperation(operation *models.BulkOperation, tags []string) error {
+	if operation.TmdbId == 0 {
+		return errors.ErrInvalidTmdbId
+	}
+
+	switch operation.Op {
+	case models.BulkOpAdd:
+		if operation.State == "" {
+			operation.State = models.StateTypeWant
+		}
+		return validateBulkState(operation.State)
+	case models.BulkOpState:
+		return validateBulkState(operation.State)
+	case models.BulkOpPin, models.BulkOpUnpin, models.BulkOpDelete:
+		return nil
+	case models.BulkOpTag:
+		request := MovieTagsRequestSerializer{Tags: tags}
+		if err := request.normalize(); err != nil {
+			return err
+		}
+		if len(request.Tags) == 0 {
+			return errors.ErrEmptyTagName
+		}
+		operation.Tags = request.Tags
+		return nil
+	default:
+		return errors.ErrInvalidOperation
+	}
+}
+
+func validateBulkState(state string) error {
+	switch state {
+	case models.StateTypeWant, models.StateTypeWatching, models.StateTypeWatched:
+		return nil
+	case "":
+		return errors.ErrEmptyState
+	default:
+		return errors.ErrInvalidState
+	}
+}
+
+type BulkResultSerializer struct {
+	Id     uint64 `json:"id"`
+	Op     string `json:"op"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkMoviesResponseSerializer struct {
+	Atomic  bool                   `json:"atomic"`
+	Results []BulkResultSerializer `json:"results"`
+}
@@ -0,0 +1,70 @@
+package serializers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+)
+
+func Test_BulkMoviesRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected error
+	}{
+		{
+			name:     "Success",
+			body:     `{ "operations": [{ "op": "pin", "id": 27205 }] }`,
+			expected: nil,
+		},
+		{
+			name:     "No operations",
+			body:     `{ "atomic": false, "operations": [] }`,
+			expected: errors.ErrEmptyBulk,
+		},
+		{
+			name:     "Too many operations",
+			body:     `{ "operations": [` + strings.Repeat(`{ "op": "pin", "id": 27205 },`, models.MaxBulkOperations) + `{ "op": "pin", "id": 155 }] }`,
+			expected: errors.ErrTooManyOperations,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &BulkMoviesRequestSerializer{}
+
+			err := params.Validate(strings.NewReader(tt.body))
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func Test_BulkMoviesRequest_BulkOperations(t *testing.T) {
+	params := &BulkMoviesRequestSerializer{}
+	err := params.Validate(strings.NewReader(`{ "atomic": false, "operations": [
+		{ "op": "add", "id": 27205 },
+		{ "op": "STATE", "id": 155, "state": "watched" },
+		{ "op": "state", "id": 603, "state": "seen" },
+		{ "op": "tag", "id": 27205, "tags": ["Cozy", "cozy "] },
+		{ "op": "tag", "id": 155, "tags": [] },
+		{ "op": "archive", "id": 603 },
+		{ "op": "delete", "id": 0 }
+	] }`))
+	assert.NoError(t, err)
+
+	assert.False(t, params.IsAtomic())
+	assert.Equal(t, []models.BulkOperation{
+		{Op: models.BulkOpAdd, TmdbId: 27205, State: models.StateTypeWant},
+		{Op: models.BulkOpState, TmdbId: 155, State: models.StateTypeWatched},
+		{Op: models.BulkOpState, TmdbId: 603, State: "seen", Err: errors.ErrInvalidState},
+		{Op: models.BulkOpTag, TmdbId: 27205, Tags: []string{"Cozy"}},
+		{Op: models.BulkOpTag, TmdbId: 155, Err: errors.ErrEmptyTagName},
+		{Op: "archive", TmdbId: 603, Err: errors.ErrInvalidOperation},
+		{Op: models.BulkOpDelete, Err: errors.ErrInvalidTmdbId},
+	}, params.BulkOperations())
+}
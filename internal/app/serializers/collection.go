@@ -0,0 +1,27 @@
+package serializers
+
+type CollectionPartSerializer struct {
+	Id          uint64            `json:"id"`
+	Title       string            `json:"title"`
+	PosterPath  string            `json:"posterPath"`
+	Images      *ImagesSerializer `json:"images,omitempty"`
+	ReleaseDate string            `json:"releaseDate,omitempty"`
+	Runtime     int               `json:"runtime"`
+	Rating      float64           `json:"rating"`
+	Released    bool              `json:"released"`
+	State       string            `json:"state"`
+}
+
+type CollectionDetailsSerializer struct {
+	Id               int                        `json:"id"`
+	Name             string                     `json:"name"`
+	Overview         string                     `json:"overview"`
+	PosterPath       string                     `json:"posterPath"`
+	Images           *ImagesSerializer          `json:"images,omitempty"`
+	BackdropPath     string                     `json:"backdropPath"`
+	Parts            []CollectionPartSerializer `json:"parts"`
+	WatchedCount     int                        `json:"watchedCount"`
+	ReleasedCount    int                        `json:"releasedCount"`
+	Completion       int                        `json:"completion"`
+	RemainingRuntime int                        `json:"remainingRuntime"`
+}
@@ -1,5 +1,6 @@
 package serializers
 
 type HealthSerializer struct {
-	Result string `json:"result"`
+	Result    string            `json:"result"`
+	Upstreams map[string]string `json:"upstreams,omitempty"`
 }
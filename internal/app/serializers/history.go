@@ -0,0 +1,24 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovieEventSerializer is an entry of the history of a movie, states and pins are left out on the side
+// of the change where the movie was not in the library. Title and posterPath only come along the activity
+type MovieEventSerializer struct {
+	Id         uuid.UUID         `json:"id"`
+	TmdbId     uint64            `json:"tmdbId"`
+	Title      string            `json:"title,omitempty"`
+	PosterPath string            `json:"posterPath,omitempty"`
+	Images     *ImagesSerializer `json:"images,omitempty"`
+	Action     string            `json:"action"`
+	OldState   string            `json:"oldState,omitempty"`
+	NewState   string            `json:"newState,omitempty"`
+	OldPinned  *bool             `json:"oldPinned,omitempty"`
+	NewPinned  *bool             `json:"newPinned,omitempty"`
+	Source     string            `json:"source"`
+	CreatedAt  time.Time         `json:"createdAt"`
+}
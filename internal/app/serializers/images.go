@@ -0,0 +1,23 @@
+package serializers
+
+import "biinge-api/pkg/tmdb"
+
+type ImagesSerializer struct {
+	Small    string `json:"small"`
+	Medium   string `json:"medium"`
+	Large    string `json:"large"`
+	Original string `json:"original"`
+}
+
+func NewImagesSerializer(set *tmdb.ImageSet) *ImagesSerializer {
+	if set == nil {
+		return nil
+	}
+
+	return &ImagesSerializer{
+		Small:    set.Small,
+		Medium:   set.Medium,
+		Large:    set.Large,
+		Original: set.Original,
+	}
+}
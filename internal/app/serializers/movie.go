@@ -4,23 +4,27 @@ import (
 	"encoding/json"
 	"io"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"biinge-api/internal/app/errors"
 	"biinge-api/internal/app/models"
 )
 
 type RecommendationSerializer struct {
-	Id         uint64 `json:"id"`
-	Title      string `json:"title"`
-	PosterPath string `json:"posterPath"`
-	State      string `json:"state,omitempty"`
+	Id         uint64            `json:"id"`
+	Title      string            `json:"title"`
+	PosterPath string            `json:"posterPath"`
+	Images     *ImagesSerializer `json:"images,omitempty"`
+	State      string            `json:"state,omitempty"`
 }
 
 type PersonSerializer struct {
-	Id          int    `json:"id"`
-	ProfilePath string `json:"profilePath"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Id          int               `json:"id"`
+	ProfilePath string            `json:"profilePath"`
+	Images      *ImagesSerializer `json:"images,omitempty"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
 }
 
 type VideoSerializer struct {
@@ -28,37 +32,149 @@ type VideoSerializer struct {
 	Key string `json:"key"`
 }
 
+type GenreSerializer struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type CountrySerializer struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type LanguageSerializer struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type CollectionSummarySerializer struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	PosterPath   string `json:"posterPath,omitempty"`
+	BackdropPath string `json:"backdropPath,omitempty"`
+}
+
+type ExternalIdsSerializer struct {
+	ImdbId      string `json:"imdbId,omitempty"`
+	WikidataId  string `json:"wikidataId,omitempty"`
+	FacebookId  string `json:"facebookId,omitempty"`
+	InstagramId string `json:"instagramId,omitempty"`
+	TwitterId   string `json:"twitterId,omitempty"`
+}
+
+type ReleaseDateSerializer struct {
+	Type          int    `json:"type"`
+	Date          string `json:"date"`
+	Certification string `json:"certification,omitempty"`
+	Note          string `json:"note,omitempty"`
+}
+
 type MovieSerializer struct {
-	Id         uint64 `json:"id"`
-	Title      string `json:"title"`
-	PosterPath string `json:"posterPath"`
-	Pinned     bool   `json:"pinned"`
-	State      string `json:"state"`
+	Id            uint64            `json:"id"`
+	Title         string            `json:"title"`
+	PosterPath    string            `json:"posterPath"`
+	Images        *ImagesSerializer `json:"images,omitempty"`
+	Runtime       uint64            `json:"runtime,omitempty"`
+	Pinned        bool              `json:"pinned"`
+	State         string            `json:"state"`
+	Progress      uint64            `json:"progress,omitempty"`
+	MissingOnTmdb bool              `json:"missingOnTmdb,omitempty"`
+}
+
+// TrashedMovieSerializer is a movie waiting in the trash, purgeAt is when it is removed for good
+type TrashedMovieSerializer struct {
+	MovieSerializer
+
+	DeletedAt time.Time  `json:"deletedAt"`
+	PurgeAt   *time.Time `json:"purgeAt,omitempty"`
+}
+
+// MovieInTrashSerializer answers adding a title waiting in the trash with its previous data
+type MovieInTrashSerializer struct {
+	Error string                 `json:"error"`
+	Movie TrashedMovieSerializer `json:"movie"`
 }
 
 type MovieDetailsSerializer struct {
-	Id              uint64                     `json:"id"`
-	ImdbId          string                     `json:"imdbId,omitempty"`
-	Title           string                     `json:"title"`
-	PosterPath      string                     `json:"posterPath"`
-	Pinned          bool                       `json:"pinned"`
-	State           string                     `json:"state"`
-	Overview        string                     `json:"overview"`
-	Status          string                     `json:"status,omitempty"`
-	ReleaseDate     string                     `json:"releaseDate,omitempty"`
-	Runtime         int                        `json:"runtime,omitempty"`
-	Rating          float64                    `json:"rating,omitempty"`
-	Credits         []PersonSerializer         `json:"credits"`
-	Recommendations []RecommendationSerializer `json:"recommendations"`
-	Videos          []VideoSerializer          `json:"videos"`
+	Id                  uint64                       `json:"id"`
+	ImdbId              string                       `json:"imdbId,omitempty"`
+	Title               string                       `json:"title"`
+	OriginalTitle       string                       `json:"originalTitle,omitempty"`
+	OriginalLanguage    string                       `json:"originalLanguage,omitempty"`
+	Tagline             string                       `json:"tagline,omitempty"`
+	PosterPath          string                       `json:"posterPath"`
+	Images              *ImagesSerializer            `json:"images,omitempty"`
+	BackdropPath        string                       `json:"backdropPath,omitempty"`
+	Pinned              bool                         `json:"pinned"`
+	State               string                       `json:"state"`
+	Progress            uint64                       `json:"progress,omitempty"`
+	Tags                []string                     `json:"tags,omitempty"`
+	Notes               string                       `json:"notes,omitempty"`
+	Overview            string                       `json:"overview"`
+	Status              string                       `json:"status,omitempty"`
+	ReleaseDate         string                       `json:"releaseDate,omitempty"`
+	Runtime             int                          `json:"runtime,omitempty"`
+	Rating              float64                      `json:"rating,omitempty"`
+	Budget              int64                        `json:"budget,omitempty"`
+	Revenue             int64                        `json:"revenue,omitempty"`
+	Certification       string                       `json:"certification,omitempty"`
+	Genres              []GenreSerializer            `json:"genres,omitempty"`
+	ProductionCountries []CountrySerializer          `json:"productionCountries,omitempty"`
+	SpokenLanguages     []LanguageSerializer         `json:"spokenLanguages,omitempty"`
+	Collection          *CollectionSummarySerializer `json:"collection,omitempty"`
+	ExternalIds         *ExternalIdsSerializer       `json:"externalIds,omitempty"`
+	ReleaseDates        []ReleaseDateSerializer      `json:"releaseDates,omitempty"`
+	WatchProviders      *WatchProvidersSerializer    `json:"watchProviders,omitempty"`
+	AvailableOnMine     bool                         `json:"availableOnMine"`
+	Credits             []PersonSerializer           `json:"credits"`
+	Recommendations     []RecommendationSerializer   `json:"recommendations"`
+	Videos              []VideoSerializer            `json:"videos"`
+}
+
+// WatchProgressSerializer is the progress reported along the watching state, in minutes or as
+// a percentage of the runtime
+type WatchProgressSerializer struct {
+	Progress        *uint64 `json:"progress,omitempty"`
+	ProgressPercent *uint64 `json:"progressPercent,omitempty" validate:"omitempty,max=100"`
 }
 
+func (params *WatchProgressSerializer) validate(state string) error {
+	if params.Progress == nil && params.ProgressPercent == nil {
+		return nil
+	}
+
+	if state != models.StateTypeWatching || params.Progress != nil && params.ProgressPercent != nil {
+		return errors.ErrInvalidProgress
+	}
+
+	if params.ProgressPercent != nil && *params.ProgressPercent > 100 {
+		return errors.ErrInvalidProgress
+	}
+
+	return nil
+}
+
+// WatchProgress returns the reported progress, nil when none was sent
+func (params *WatchProgressSerializer) WatchProgress() *models.WatchProgress {
+	if params.Progress == nil && params.ProgressPercent == nil {
+		return nil
+	}
+
+	return &models.WatchProgress{Minutes: params.Progress, Percent: params.ProgressPercent}
+}
+
+// CreateMovieRequestSerializer takes the metadata from TMDB, title, posterPath and runtime
+// are only stored when TMDB is unavailable. Trashed tells what to do when the title is in the
+// trash, restore its previous data or discard it
 type CreateMovieRequestSerializer struct {
 	Id         uint64 `json:"id" validate:"required"`
-	Title      string `json:"title" validate:"required"`
-	PosterPath string `json:"posterPath" validate:"required"`
+	Title      string `json:"title" validate:"omitempty"`
+	PosterPath string `json:"posterPath" validate:"omitempty"`
 	Runtime    uint64 `json:"runtime" validate:"omitempty,min=0"`
-	State      string `json:"state" validate:"omitempty,oneof=want watched"`
+	State      string `json:"state" validate:"omitempty,oneof=want watching watched"`
+	Trashed    string `json:"trashed" validate:"omitempty,oneof=restore discard"`
+
+	WatchProgressSerializer
 }
 
 func (params *CreateMovieRequestSerializer) Validate(body io.Reader) error {
@@ -66,31 +182,37 @@ func (params *CreateMovieRequestSerializer) Validate(body io.Reader) error {
 		return err
 	}
 
-	params.Title = strings.TrimSpace(params.Title)
-	if params.Title == "" {
-		return errors.ErrEmptyTitle
+	if params.Id == 0 {
+		return errors.ErrInvalidTmdbId
 	}
 
+	params.Title = strings.TrimSpace(params.Title)
 	params.PosterPath = strings.TrimSpace(params.PosterPath)
-	if params.PosterPath == "" {
-		return errors.ErrEmptyPoster
-	}
 
 	params.State = strings.TrimSpace(params.State)
 	switch params.State {
-	case models.StateTypeWant, models.StateTypeWatched:
+	case models.StateTypeWant, models.StateTypeWatching, models.StateTypeWatched:
 	case "":
 		return errors.ErrEmptyState
 	default:
 		return errors.ErrInvalidState
 	}
 
-	return nil
+	params.Trashed = strings.ToLower(strings.TrimSpace(params.Trashed))
+	switch params.Trashed {
+	case "", models.TrashedRestore, models.TrashedDiscard:
+	default:
+		return errors.ErrInvalidTrashed
+	}
+
+	return params.WatchProgressSerializer.validate(params.State)
 }
 
 type UpdateMovieRequestSerializer struct {
-	State  string `json:"state" validate:"omitempty,oneof=want watched"`
+	State  string `json:"state" validate:"omitempty,oneof=want watching watched"`
 	Pinned bool   `json:"pinned"`
+
+	WatchProgressSerializer
 }
 
 func (params *UpdateMovieRequestSerializer) Validate(body io.Reader) error {
@@ -100,12 +222,80 @@ func (params *UpdateMovieRequestSerializer) Validate(body io.Reader) error {
 
 	params.State = strings.TrimSpace(params.State)
 	switch params.State {
-	case models.StateTypeWant, models.StateTypeWatched:
+	case models.StateTypeWant, models.StateTypeWatching, models.StateTypeWatched:
 	case "":
 		return errors.ErrEmptyState
 	default:
 		return errors.ErrInvalidState
 	}
 
+	return params.WatchProgressSerializer.validate(params.State)
+}
+
+// UpsertMovieRequestSerializer sets a title of the library, title, posterPath and runtime are only
+// stored when TMDB is unavailable and the title is not in the library yet
+type UpsertMovieRequestSerializer struct {
+	Title      string `json:"title" validate:"omitempty"`
+	PosterPath string `json:"posterPath" validate:"omitempty"`
+	Runtime    uint64 `json:"runtime" validate:"omitempty,min=0"`
+	State      string `json:"state" validate:"omitempty,oneof=want watching watched"`
+	Pinned     bool   `json:"pinned"`
+
+	WatchProgressSerializer
+}
+
+func (params *UpsertMovieRequestSerializer) Validate(body io.Reader) error {
+	if err := json.NewDecoder(body).Decode(params); err != nil {
+		return err
+	}
+
+	params.Title = strings.TrimSpace(params.Title)
+	params.PosterPath = strings.TrimSpace(params.PosterPath)
+
+	params.State = strings.TrimSpace(params.State)
+	switch params.State {
+	case models.StateTypeWant, models.StateTypeWatching, models.StateTypeWatched:
+	case "":
+		return errors.ErrEmptyState
+	default:
+		return errors.ErrInvalidState
+	}
+
+	return params.WatchProgressSerializer.validate(params.State)
+}
+
+// MovieNotesRequestSerializer replaces the private notes of a movie, empty notes clear them
+type MovieNotesRequestSerializer struct {
+	Notes string `json:"notes"`
+}
+
+func (params *MovieNotesRequestSerializer) Validate(body io.Reader) error {
+	if err := json.NewDecoder(body).Decode(params); err != nil {
+		return err
+	}
+
+	params.Notes = strings.TrimSpace(params.Notes)
+	if utf8.RuneCountInString(params.Notes) > models.MaxNotesLength {
+		return errors.ErrInvalidNotes
+	}
+
+	return nil
+}
+
+// MoveMovieRequestSerializer places a movie right before or after another movie of the library
+type MoveMovieRequestSerializer struct {
+	Before uint64 `json:"before"`
+	After  uint64 `json:"after"`
+}
+
+func (params *MoveMovieRequestSerializer) Validate(body io.Reader) error {
+	if err := json.NewDecoder(body).Decode(params); err != nil {
+		return err
+	}
+
+	if (params.Before == 0) == (params.After == 0) {
+		return errors.ErrInvalidMove
+	}
+
 	return nil
 }
@@ -31,6 +31,31 @@ func Test_UpdateMovieRequest_Validate(t *testing.T) {
 			body:     strings.NewReader(`{ "state": "invalid", "pinned": true }`),
 			expected: errors.ErrInvalidState,
 		},
+		{
+			name:     "Watching with progress",
+			body:     strings.NewReader(`{ "state": "watching", "pinned": false, "progress": 42 }`),
+			expected: nil,
+		},
+		{
+			name:     "Watching with progress percent",
+			body:     strings.NewReader(`{ "state": "watching", "pinned": false, "progressPercent": 50 }`),
+			expected: nil,
+		},
+		{
+			name:     "Progress outside watching",
+			body:     strings.NewReader(`{ "state": "want", "pinned": false, "progress": 42 }`),
+			expected: errors.ErrInvalidProgress,
+		},
+		{
+			name:     "Progress in minutes and percent",
+			body:     strings.NewReader(`{ "state": "watching", "pinned": false, "progress": 42, "progressPercent": 50 }`),
+			expected: errors.ErrInvalidProgress,
+		},
+		{
+			name:     "Progress percent above 100",
+			body:     strings.NewReader(`{ "state": "watching", "pinned": false, "progressPercent": 150 }`),
+			expected: errors.ErrInvalidProgress,
+		},
 	}
 
 	for _, tt := range tests {
@@ -42,3 +67,146 @@ func Test_UpdateMovieRequest_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_CreateMovieRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     io.Reader
+		expected error
+	}{
+		{
+			name:     "Success",
+			body:     strings.NewReader(`{ "id": 27205, "state": "want" }`),
+			expected: nil,
+		},
+		{
+			name:     "Success with fallback metadata",
+			body:     strings.NewReader(`{ "id": 27205, "title": "Inception", "posterPath": "/inception.jpg", "runtime": 148, "state": "watched" }`),
+			expected: nil,
+		},
+		{
+			name:     "Success watching",
+			body:     strings.NewReader(`{ "id": 27205, "state": "watching", "progress": 30 }`),
+			expected: nil,
+		},
+		{
+			name:     "Missing id",
+			body:     strings.NewReader(`{ "state": "want" }`),
+			expected: errors.ErrInvalidTmdbId,
+		},
+		{
+			name:     "Empty state",
+			body:     strings.NewReader(`{ "id": 27205, "state": "" }`),
+			expected: errors.ErrEmptyState,
+		},
+		{
+			name:     "Invalid state",
+			body:     strings.NewReader(`{ "id": 27205, "state": "invalid" }`),
+			expected: errors.ErrInvalidState,
+		}, {
+			name:     "Restore a trashed title",
+			body:     strings.NewReader(`{ "id": 27205, "state": "want", "trashed": "Restore" }`),
+			expected: nil,
+		},
+		{
+			name:     "Invalid trashed",
+			body:     strings.NewReader(`{ "id": 27205, "state": "want", "trashed": "keep" }`),
+			expected: errors.ErrInvalidTrashed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params CreateMovieRequestSerializer
+			err := params.Validate(tt.body)
+
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func Test_UpsertMovieRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     io.Reader
+		expected error
+	}{
+		{
+			name:     "Success",
+			body:     strings.NewReader(`{ "state": "want", "pinned": true }`),
+			expected: nil,
+		},
+		{
+			name:     "Success with fallback metadata",
+			body:     strings.NewReader(`{ "title": "Inception", "posterPath": "/inception.jpg", "runtime": 148, "state": "watched" }`),
+			expected: nil,
+		},
+		{
+			name:     "Success watching",
+			body:     strings.NewReader(`{ "state": "watching", "progressPercent": 50 }`),
+			expected: nil,
+		},
+		{
+			name:     "Empty state",
+			body:     strings.NewReader(`{ "pinned": true }`),
+			expected: errors.ErrEmptyState,
+		},
+		{
+			name:     "Invalid state",
+			body:     strings.NewReader(`{ "state": "invalid" }`),
+			expected: errors.ErrInvalidState,
+		},
+		{
+			name:     "Progress without watching",
+			body:     strings.NewReader(`{ "state": "want", "progress": 30 }`),
+			expected: errors.ErrInvalidProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params UpsertMovieRequestSerializer
+			err := params.Validate(tt.body)
+
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func Test_MoveMovieRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     io.Reader
+		expected error
+	}{
+		{
+			name:     "Before",
+			body:     strings.NewReader(`{ "before": 27205 }`),
+			expected: nil,
+		},
+		{
+			name:     "After",
+			body:     strings.NewReader(`{ "after": 155 }`),
+			expected: nil,
+		},
+		{
+			name:     "Missing anchor",
+			body:     strings.NewReader(`{}`),
+			expected: errors.ErrInvalidMove,
+		},
+		{
+			name:     "Both anchors",
+			body:     strings.NewReader(`{ "before": 27205, "after": 155 }`),
+			expected: errors.ErrInvalidMove,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params MoveMovieRequestSerializer
+			err := params.Validate(tt.body)
+
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package serializers
 
+// PaginationMeta describes a page, Total is left out when the client skipped counting and
+// NextCursor on the last page
 type PaginationMeta struct {
-	Page  uint64 `json:"page"`
-	Per   uint64 `json:"per"`
-	Total uint64 `json:"total"`
+	Page       uint64  `json:"page"`
+	Per        uint64  `json:"per"`
+	Total      *uint64 `json:"total,omitempty"`
+	NextCursor string  `json:"next_cursor,omitempty"`
 }
 
 type PaginationResponse[T interface{}] struct {
@@ -1,18 +1,54 @@
 package serializers
 
 type MovieCreditSerializer struct {
-	Id         uint64 `json:"id"`
-	Title      string `json:"title"`
-	PosterPath string `json:"posterPath"`
-	State      string `json:"state,omitempty"`
-	Type       string `json:"type,omitempty"`
+	Id         uint64            `json:"id"`
+	Title      string            `json:"title"`
+	PosterPath string            `json:"posterPath"`
+	Images     *ImagesSerializer `json:"images,omitempty"`
+	State      string            `json:"state,omitempty"`
+	Type       string            `json:"type,omitempty"`
+}
+
+type TvCreditSerializer struct {
+	Id            int               `json:"id"`
+	Title         string            `json:"title"`
+	PosterPath    string            `json:"posterPath"`
+	Images        *ImagesSerializer `json:"images,omitempty"`
+	Type          string            `json:"type,omitempty"`
+	EpisodesCount int               `json:"episodesCount,omitempty"`
+}
+
+type ImageSerializer struct {
+	FilePath string `json:"filePath"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+type CompletionSerializer struct {
+	Watched    int `json:"watched"`
+	Total      int `json:"total"`
+	Percentage int `json:"percentage"`
+}
+
+type FilmographyCompletionSerializer struct {
+	Cast     CompletionSerializer `json:"cast"`
+	Director CompletionSerializer `json:"director"`
 }
 
 type PersonDetailsSerializer struct {
-	Id           uint64                  `json:"id"`
-	Name         string                  `json:"name"`
-	Birthday     string                  `json:"birthday,omitempty"`
-	ProfilePath  string                  `json:"profilePath"`
-	Gender       int                     `json:"gender"`
-	MovieCredits []MovieCreditSerializer `json:"movieCredits"`
+	Id                 uint64                           `json:"id"`
+	Name               string                           `json:"name"`
+	Biography          string                           `json:"biography,omitempty"`
+	Birthday           string                           `json:"birthday,omitempty"`
+	Deathday           string                           `json:"deathday,omitempty"`
+	PlaceOfBirth       string                           `json:"placeOfBirth,omitempty"`
+	KnownForDepartment string                           `json:"knownForDepartment,omitempty"`
+	ProfilePath        string                           `json:"profilePath"`
+	Images             *ImagesSerializer                `json:"images,omitempty"`
+	Gender             int                              `json:"gender"`
+	ExternalIds        *ExternalIdsSerializer           `json:"externalIds,omitempty"`
+	ProfileImages      []ImageSerializer                `json:"profileImages,omitempty"`
+	Completion         *FilmographyCompletionSerializer `json:"completion,omitempty"`
+	MovieCredits       []MovieCreditSerializer          `json:"movieCredits"`
+	TvCredits          []TvCreditSerializer             `json:"tvCredits"`
 }
@@ -0,0 +1,14 @@
+package serializers
+
+type PersonStatSerializer struct {
+	Id           int               `json:"id"`
+	Name         string            `json:"name"`
+	ProfilePath  string            `json:"profilePath"`
+	Images       *ImagesSerializer `json:"images,omitempty"`
+	WatchedCount uint64            `json:"watchedCount"`
+}
+
+type PeopleStatsSerializer struct {
+	Directors []PersonStatSerializer `json:"directors"`
+	Actors    []PersonStatSerializer `json:"actors"`
+}
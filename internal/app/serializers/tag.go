@@ -0,0 +1,91 @@
+package serializers
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+)
+
+type TagSerializer struct {
+	Id          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	MoviesCount uint64    `json:"moviesCount"`
+}
+
+// TagRequestSerializer creates or renames a tag
+type TagRequestSerializer struct {
+	Name string `json:"name"`
+}
+
+func (params *TagRequestSerializer) Validate(body io.Reader) error {
+	if err := json.NewDecoder(body).Decode(params); err != nil {
+		return err
+	}
+
+	name, err := normalizeTagName(params.Name)
+	if err != nil {
+		return err
+	}
+
+	params.Name = name
+	return nil
+}
+
+// MovieTagsRequestSerializer replaces the tags of a movie, names differing only by case are kept once
+type MovieTagsRequestSerializer struct {
+	Tags []string `json:"tags"`
+}
+
+func (params *MovieTagsRequestSerializer) Validate(body io.Reader) error {
+	if err := json.NewDecoder(body).Decode(params); err != nil {
+		return err
+	}
+
+	return params.normalize()
+}
+
+// normalize trims the names and drops the ones repeated regardless of case
+func (params *MovieTagsRequestSerializer) normalize() error {
+	seen := make(map[string]struct{}, len(params.Tags))
+	names := make([]string, 0, len(params.Tags))
+	for _, tag := range params.Tags {
+		name, err := normalizeTagName(tag)
+		if err != nil {
+			return err
+		}
+
+		key := strings.ToLower(name)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		names = append(names, name)
+	}
+
+	if len(names) > models.MaxMovieTags {
+		return errors.ErrTooManyTags
+	}
+
+	params.Tags = names
+	return nil
+}
+
+func normalizeTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", errors.ErrEmptyTagName
+	}
+
+	if utf8.RuneCountInString(name) > models.MaxTagNameLength {
+		return "", errors.ErrInvalidTagName
+	}
+
+	return name, nil
+}
@@ -0,0 +1,92 @@
+package serializers
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/app/errors"
+)
+
+func Test_TagRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     io.Reader
+		expected error
+		tag      string
+	}{
+		{
+			name: "Success",
+			body: strings.NewReader(`{ "name": "  Sci-Fi  " }`),
+			tag:  "Sci-Fi",
+		},
+		{
+			name:     "Empty name",
+			body:     strings.NewReader(`{ "name": "   " }`),
+			expected: errors.ErrEmptyTagName,
+		},
+		{
+			name:     "Name too long",
+			body:     strings.NewReader(`{ "name": "` + strings.Repeat("é", 65) + `" }`),
+			expected: errors.ErrInvalidTagName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &TagRequestSerializer{}
+
+			err := params.Validate(tt.body)
+
+			assert.ErrorIs(t, err, tt.expected)
+			if tt.expected == nil {
+				assert.Equal(t, tt.tag, params.Name)
+			}
+		})
+	}
+}
+
+func Test_MovieTagsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     io.Reader
+		expected error
+		tags     []string
+	}{
+		{
+			name: "Success",
+			body: strings.NewReader(`{ "tags": ["Cozy", " cozy ", "Rewatch"] }`),
+			tags: []string{"Cozy", "Rewatch"},
+		},
+		{
+			name: "Clear tags",
+			body: strings.NewReader(`{ "tags": [] }`),
+			tags: []string{},
+		},
+		{
+			name:     "Empty tag",
+			body:     strings.NewReader(`{ "tags": ["Cozy", ""] }`),
+			expected: errors.ErrEmptyTagName,
+		},
+		{
+			name:     "Too many tags",
+			body:     strings.NewReader(`{ "tags": ["1","2","3","4","5","6","7","8","9","10","11","12","13","14","15","16","17","18","19","20","21"] }`),
+			expected: errors.ErrTooManyTags,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &MovieTagsRequestSerializer{}
+
+			err := params.Validate(tt.body)
+
+			assert.ErrorIs(t, err, tt.expected)
+			if tt.expected == nil {
+				assert.Equal(t, tt.tags, params.Tags)
+			}
+		})
+	}
+}
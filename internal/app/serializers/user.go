@@ -8,21 +8,38 @@ import (
 	"github.com/google/uuid"
 
 	"biinge-api/internal/app/errors"
+	"biinge-api/pkg/tmdb"
 )
 
 type UserSerializer struct {
-	ID         uuid.UUID `json:"id"`
-	Login      string    `json:"login"`
-	Email      string    `json:"email"`
-	FirstName  string    `json:"first_name,omitempty"`
-	LastName   string    `json:"last_name,omitempty"`
-	Appearance string    `json:"appearance"`
+	ID             uuid.UUID `json:"id"`
+	Login          string    `json:"login"`
+	Email          string    `json:"email"`
+	FirstName      string    `json:"first_name,omitempty"`
+	LastName       string    `json:"last_name,omitempty"`
+	Appearance     string    `json:"appearance"`
+	Language       string    `json:"language,omitempty"`
+	Region         string    `json:"region,omitempty"`
+	WatchProviders []int32   `json:"watch_providers,omitempty"`
+
+	IncludeDocumentaries  *bool `json:"include_documentaries,omitempty"`
+	IncludeWithoutArtwork *bool `json:"include_without_artwork,omitempty"`
+	IncludeAdult          bool  `json:"include_adult,omitempty"`
+	AdultConfirmed        bool  `json:"adult_confirmed,omitempty"`
 }
 
 type UpdateAccountRequestSerializer struct {
-	FirstName  string `json:"first_name" validate:"omitempty,min=2,max=20"`
-	LastName   string `json:"last_name" validate:"omitempty,min=2,max=20"`
-	Appearance string `json:"appearance" validate:"omitempty,oneof=light dark system"`
+	FirstName      string   `json:"first_name" validate:"omitempty,min=2,max=20"`
+	LastName       string   `json:"last_name" validate:"omitempty,min=2,max=20"`
+	Appearance     string   `json:"appearance" validate:"omitempty,oneof=light dark system"`
+	Language       *string  `json:"language" validate:"omitempty,len=2"`
+	Region         *string  `json:"region" validate:"omitempty,len=2"`
+	WatchProviders *[]int32 `json:"watch_providers"`
+
+	IncludeDocumentaries  *bool `json:"include_documentaries"`
+	IncludeWithoutArtwork *bool `json:"include_without_artwork"`
+	IncludeAdult          *bool `json:"include_adult"`
+	ConfirmAdultAge       bool  `json:"confirm_adult_age"`
 }
 
 func (params *UpdateAccountRequestSerializer) Validate(body io.Reader) error {
@@ -46,5 +63,37 @@ func (params *UpdateAccountRequestSerializer) Validate(body io.Reader) error {
 		return errors.ErrEmptyAppearance
 	}
 
+	if params.Language != nil {
+		language := strings.ToLower(strings.TrimSpace(*params.Language))
+		if language != "" && !tmdb.IsValidLanguage(language) {
+			return errors.ErrInvalidLanguage
+		}
+		params.Language = &language
+	}
+
+	if params.Region != nil {
+		region := strings.ToUpper(strings.TrimSpace(*params.Region))
+		if region != "" && !tmdb.IsValidRegion(region) {
+			return errors.ErrInvalidRegion
+		}
+		params.Region = &region
+	}
+
+	if params.WatchProviders != nil {
+		seen := make(map[int32]struct{}, len(*params.WatchProviders))
+		providers := make([]int32, 0, len(*params.WatchProviders))
+		for _, id := range *params.WatchProviders {
+			if id <= 0 {
+				return errors.ErrInvalidWatchProvider
+			}
+
+			if _, exists := seen[id]; !exists {
+				seen[id] = struct{}{}
+				providers = append(providers, id)
+			}
+		}
+		params.WatchProviders = &providers
+	}
+
 	return nil
 }
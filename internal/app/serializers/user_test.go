@@ -36,6 +36,36 @@ func Test_UpdateAccountRequest_Validate(t *testing.T) {
 			body:     strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "" }`),
 			expected: errors.ErrEmptyAppearance,
 		},
+		{
+			name:     "With locale",
+			body:     strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "language": "ET", "region": "ee" }`),
+			expected: nil,
+		},
+		{
+			name:     "Empty locale",
+			body:     strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "language": "", "region": "" }`),
+			expected: nil,
+		},
+		{
+			name:     "Invalid language",
+			body:     strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "language": "english" }`),
+			expected: errors.ErrInvalidLanguage,
+		},
+		{
+			name:     "Invalid region",
+			body:     strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "region": "USA" }`),
+			expected: errors.ErrInvalidRegion,
+		},
+		{
+			name:     "With watch providers",
+			body:     strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "watch_providers": [8, 337] }`),
+			expected: nil,
+		},
+		{
+			name:     "Invalid watch provider",
+			body:     strings.NewReader(`{ "first_name": "John", "last_name": "Doe", "appearance": "dark", "watch_providers": [8, 0] }`),
+			expected: errors.ErrInvalidWatchProvider,
+		},
 	}
 
 	for _, tt := range tests {
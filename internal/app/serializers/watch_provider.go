@@ -0,0 +1,16 @@
+package serializers
+
+type WatchProviderSerializer struct {
+	Id         int    `json:"id"`
+	Name       string `json:"name"`
+	LogoPath   string `json:"logoPath"`
+	Subscribed bool   `json:"subscribed"`
+}
+
+type WatchProvidersSerializer struct {
+	Region   string                    `json:"region"`
+	Link     string                    `json:"link,omitempty"`
+	Flatrate []WatchProviderSerializer `json:"flatrate"`
+	Rent     []WatchProviderSerializer `json:"rent"`
+	Buy      []WatchProviderSerializer `json:"buy"`
+}
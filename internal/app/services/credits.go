@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+// MaxCachedCast is the number of top-billed actors cached per movie
+const MaxCachedCast = 10
+
+type Credits interface {
+	CacheMovieCredits(ctx context.Context, tmdbId uint64) error
+	FindUncachedMovieIds(ctx context.Context, limit uint64) ([]uint64, error)
+	TopPeople(ctx context.Context, userId uuid.UUID, limit uint64) ([]models.PersonStat, []models.PersonStat, error)
+}
+
+type credits struct {
+	repository repositories.CreditRepository
+	client     tmdb.Client
+	log        *logger.Logger
+}
+
+func NewCredits(repository repositories.CreditRepository, client tmdb.Client, log *logger.Logger) Credits {
+	return &credits{
+		repository: repository,
+		client:     client,
+		log:        log.WithComponent("CreditsService"),
+	}
+}
+
+// CacheMovieCredits stores the directors and top-billed cast of a movie once,
+// later library rows of the same movie are only marked as cached
+func (c *credits) CacheMovieCredits(ctx context.Context, tmdbId uint64) error {
+	cached, err := c.repository.IsMovieCached(ctx, tmdbId)
+	if err != nil {
+		c.log.Error().Err(err).Uint64("TmdbId", tmdbId).Msg("Failed to check movie credits cache")
+		return errors.ErrFailedToCacheCredits
+	}
+
+	items := make([]models.Credit, 0)
+	if !cached {
+		response, err := c.client.FetchMovieCredits(ctx, tmdbId)
+		switch {
+		case errors.Is(err, tmdb.ErrUpstreamUnavailable), errors.Is(err, context.Canceled):
+			return err
+		case errors.Is(err, tmdb.ErrNotFound):
+			response = &tmdb.Credits{}
+		case err != nil:
+			c.log.Error().Err(err).Uint64("TmdbId", tmdbId).Msg("Failed to fetch movie credits")
+			return errors.ErrFailedToCacheCredits
+		}
+
+		items = transformCredits(response)
+	}
+
+	if err := c.repository.CacheMovieCredits(ctx, tmdbId, items); err != nil {
+		c.log.Error().Err(err).Uint64("TmdbId", tmdbId).Msg("Failed to cache movie credits")
+		return errors.ErrFailedToCacheCredits
+	}
+
+	return nil
+}
+
+func (c *credits) FindUncachedMovieIds(ctx context.Context, limit uint64) ([]uint64, error) {
+	ids, err := c.repository.FindUncachedMovieIds(ctx, limit)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to fetch movies without cached credits")
+		return nil, errors.ErrFailedToFetchMovies
+	}
+
+	return ids, nil
+}
+
+// TopPeople returns the directors and actors credited on most of the movies the user watched
+func (c *credits) TopPeople(ctx context.Context, userId uuid.UUID, limit uint64) ([]models.PersonStat, []models.PersonStat, error) {
+	directors, err := c.repository.TopPeople(ctx, userId, models.CreditRoleDirector, limit)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to fetch top directors")
+		return nil, nil, errors.ErrFailedToFetchStats
+	}
+
+	actors, err := c.repository.TopPeople(ctx, userId, models.CreditRoleCast, limit)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to fetch top actors")
+		return nil, nil, errors.ErrFailedToFetchStats
+	}
+
+	return directors, actors, nil
+}
+
+func transformCredits(response *tmdb.Credits) []models.Credit {
+	items := make([]models.Credit, 0, MaxCachedCast)
+
+	for _, person := range response.Cast {
+		if person.Order >= MaxCachedCast {
+			continue
+		}
+
+		items = append(items, models.Credit{
+			PersonId:    person.Id,
+			Role:        models.CreditRoleCast,
+			Name:        person.Name,
+			ProfilePath: person.ProfilePath,
+			Position:    person.Order,
+		})
+	}
+
+	position := 0
+	for _, person := range response.Crew {
+		if person.Job != tmdb.TMDBJobDirector {
+			continue
+		}
+
+		items = append(items, models.Credit{
+			PersonId:    person.Id,
+			Role:        models.CreditRoleDirector,
+			Name:        person.Name,
+			ProfilePath: person.ProfilePath,
+			Position:    position,
+		})
+		position++
+	}
+
+	return items
+}
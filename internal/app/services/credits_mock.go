@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/services/credits.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/services/credits.go -destination=internal/app/services/credits_mock.go -package=services
+//
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	models "biinge-api/internal/app/models"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCredits is a mock of Credits interface.
+type MockCredits struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditsMockRecorder
+	isgomock struct{}
+}
+
+// MockCreditsMockRecorder is the mock recorder for MockCredits.
+type MockCreditsMockRecorder struct {
+	mock *MockCredits
+}
+
+// NewMockCredits creates a new mock instance.
+func NewMockCredits(ctrl *gomock.Controller) *MockCredits {
+	mock := &MockCredits{ctrl: ctrl}
+	mock.recorder = &MockCreditsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredits) EXPECT() *MockCreditsMockRecorder {
+	return m.recorder
+}
+
+// CacheMovieCredits mocks base method.
+func (m *MockCredits) CacheMovieCredits(ctx context.Context, tmdbId uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheMovieCredits", ctx, tmdbId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CacheMovieCredits indicates an expected call of CacheMovieCredits.
+func (mr *MockCreditsMockRecorder) CacheMovieCredits(ctx, tmdbId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheMovieCredits", reflect.TypeOf((*MockCredits)(nil).CacheMovieCredits), ctx, tmdbId)
+}
+
+// FindUncachedMovieIds mocks base method.
+func (m *MockCredits) FindUncachedMovieIds(ctx context.Context, limit uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUncachedMovieIds", ctx, limit)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUncachedMovieIds indicates an expected call of FindUncachedMovieIds.
+func (mr *MockCreditsMockRecorder) FindUncachedMovieIds(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUncachedMovieIds", reflect.TypeOf((*MockCredits)(nil).FindUncachedMovieIds), ctx, limit)
+}
+
+// TopPeople mocks base method.
+func (m *MockCredits) TopPeople(ctx context.Context, userId uuid.UUID, limit uint64) ([]models.PersonStat, []models.PersonStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopPeople", ctx, userId, limit)
+	ret0, _ := ret[0].([]models.PersonStat)
+	ret1, _ := ret[1].([]models.PersonStat)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TopPeople indicates an expected call of TopPeople.
+func (mr *MockCreditsMockRecorder) TopPeople(ctx, userId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopPeople", reflect.TypeOf((*MockCredits)(nil).TopPeople), ctx, userId, limit)
+}
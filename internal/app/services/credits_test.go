@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_Credits_CacheMovieCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockCreditRepository(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	service := NewCredits(repository, client, logger.NewLogger(cfg))
+
+	tests := []struct {
+		name   string
+		before func()
+		error  error
+	}{
+		{
+			name: "Caches directors and top-billed cast",
+			before: func() {
+				repository.EXPECT().IsMovieCached(ctx, uint64(27205)).Return(false, nil)
+				client.EXPECT().FetchMovieCredits(ctx, uint64(27205)).Return(&tmdb.Credits{
+					Cast: []tmdb.PersonCast{
+						{Person: tmdb.Person{Id: 6193, Name: "Leonardo DiCaprio", ProfilePath: "/leo.jpg"}, Order: 0},
+						{Person: tmdb.Person{Id: 99, Name: "Extra"}, Order: MaxCachedCast},
+					},
+					Crew: []tmdb.PersonCrew{
+						{Person: tmdb.Person{Id: 525, Name: "Christopher Nolan"}, Job: "Director"},
+						{Person: tmdb.Person{Id: 525, Name: "Christopher Nolan"}, Job: "Writer"},
+					},
+				}, nil)
+				repository.EXPECT().CacheMovieCredits(ctx, uint64(27205), []models.Credit{
+					{PersonId: 6193, Role: models.CreditRoleCast, Name: "Leonardo DiCaprio", ProfilePath: "/leo.jpg"},
+					{PersonId: 525, Role: models.CreditRoleDirector, Name: "Christopher Nolan"},
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Marks an already cached movie without fetching",
+			before: func() {
+				repository.EXPECT().IsMovieCached(ctx, uint64(27205)).Return(true, nil)
+				repository.EXPECT().CacheMovieCredits(ctx, uint64(27205), []models.Credit{}).Return(nil)
+			},
+		},
+		{
+			name: "Caches a movie missing on TMDB without credits",
+			before: func() {
+				repository.EXPECT().IsMovieCached(ctx, uint64(27205)).Return(false, nil)
+				client.EXPECT().FetchMovieCredits(ctx, uint64(27205)).Return(nil, tmdb.ErrNotFound)
+				repository.EXPECT().CacheMovieCredits(ctx, uint64(27205), []models.Credit{}).Return(nil)
+			},
+		},
+		{
+			name: "Upstream unavailable",
+			before: func() {
+				repository.EXPECT().IsMovieCached(ctx, uint64(27205)).Return(false, nil)
+				client.EXPECT().FetchMovieCredits(ctx, uint64(27205)).Return(nil, &tmdb.UnavailableError{RetryAfter: time.Second})
+			},
+			error: tmdb.ErrUpstreamUnavailable,
+		},
+		{
+			name: "Failure",
+			before: func() {
+				repository.EXPECT().IsMovieCached(ctx, uint64(27205)).Return(false, nil)
+				client.EXPECT().FetchMovieCredits(ctx, uint64(27205)).Return(&tmdb.Credits{}, nil)
+				repository.EXPECT().CacheMovieCredits(ctx, uint64(27205), []models.Credit{}).Return(errors.ErrFailedToCacheCredits)
+			},
+			error: errors.ErrFailedToCacheCredits,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			err := service.CacheMovieCredits(ctx, 27205)
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Credits_TopPeople(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockCreditRepository(ctrl)
+	service := NewCredits(repository, tmdb.NewMockClient(ctrl), logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	directors := []models.PersonStat{{PersonId: 137427, Name: "Denis Villeneuve", WatchedCount: 12}}
+	actors := []models.PersonStat{{PersonId: 6193, Name: "Leonardo DiCaprio", WatchedCount: 7}}
+
+	repository.EXPECT().TopPeople(ctx, userId, models.CreditRoleDirector, uint64(5)).Return(directors, nil)
+	repository.EXPECT().TopPeople(ctx, userId, models.CreditRoleCast, uint64(5)).Return(actors, nil)
+
+	resultDirectors, resultActors, err := service.TopPeople(ctx, userId, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, directors, resultDirectors)
+	assert.Equal(t, actors, resultActors)
+
+	repository.EXPECT().TopPeople(ctx, userId, models.CreditRoleDirector, uint64(5)).Return(nil, errors.ErrFailedToFetchStats)
+
+	_, _, err = service.TopPeople(ctx, userId, 5)
+	assert.ErrorIs(t, err, errors.ErrFailedToFetchStats)
+}
@@ -4,22 +4,33 @@ import (
 	"context"
 
 	"biinge-api/internal/app/repositories"
+	"biinge-api/pkg/tmdb"
 )
 
 type HealthChecker interface {
 	Ping(ctx context.Context) error
+	Upstreams() map[string]string
 }
 
 type health struct {
 	repository repositories.HealthRepository
+	client     tmdb.Client
 }
 
-func NewHealthChecker(repository repositories.HealthRepository) HealthChecker {
+func NewHealthChecker(repository repositories.HealthRepository, client tmdb.Client) HealthChecker {
 	return &health{
 		repository: repository,
+		client:     client,
 	}
 }
 
 func (h *health) Ping(ctx context.Context) error {
 	return h.repository.Ping(ctx)
 }
+
+// Upstreams reports the circuit breaker state of external APIs
+func (h *health) Upstreams() map[string]string {
+	return map[string]string{
+		"tmdb": string(h.client.BreakerState()),
+	}
+}
@@ -53,3 +53,17 @@ func (mr *MockHealthCheckerMockRecorder) Ping(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockHealthChecker)(nil).Ping), ctx)
 }
+
+// Upstreams mocks base method.
+func (m *MockHealthChecker) Upstreams() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upstreams")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// Upstreams indicates an expected call of Upstreams.
+func (mr *MockHealthCheckerMockRecorder) Upstreams() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upstreams", reflect.TypeOf((*MockHealthChecker)(nil).Upstreams))
+}
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"biinge-api/internal/app/repositories"
+	"biinge-api/pkg/tmdb"
 )
 
 func Test_HealthChecker_Ping(t *testing.T) {
@@ -16,7 +17,8 @@ func Test_HealthChecker_Ping(t *testing.T) {
 
 	ctx := context.Background()
 	repository := repositories.NewMockHealthRepository(ctrl)
-	service := NewHealthChecker(repository)
+	client := tmdb.NewMockClient(ctrl)
+	service := NewHealthChecker(repository, client)
 
 	tests := []struct {
 		name     string
@@ -48,3 +50,42 @@ func Test_HealthChecker_Ping(t *testing.T) {
 		})
 	}
 }
+
+func Test_HealthChecker_Upstreams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := repositories.NewMockHealthRepository(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	service := NewHealthChecker(repository, client)
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected map[string]string
+	}{
+		{
+			name: "Closed",
+			before: func() {
+				client.EXPECT().BreakerState().Return(tmdb.BreakerClosed)
+			},
+			expected: map[string]string{"tmdb": "closed"},
+		},
+		{
+			name: "Open",
+			before: func() {
+				client.EXPECT().BreakerState().Return(tmdb.BreakerOpen)
+			},
+			expected: map[string]string{"tmdb": "open"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result := service.Upstreams()
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config/logger"
+)
+
+type eventSourceKey struct{}
+
+// WithEventSource returns a copy of ctx recording the changes made with it as coming from source,
+// changes are recorded as made through the API otherwise
+func WithEventSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, eventSourceKey{}, source)
+}
+
+func EventSourceFromContext(ctx context.Context) string {
+	if source, ok := ctx.Value(eventSourceKey{}).(string); ok && source != "" {
+		return source
+	}
+
+	return models.EventSourceApi
+}
+
+type History interface {
+	List(ctx context.Context, userId uuid.UUID, pagination *Pagination) ([]models.MovieEvent, *PageInfo, error)
+	ListByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID, pagination *Pagination) ([]models.MovieEvent, *PageInfo, error)
+}
+
+type history struct {
+	repository repositories.HistoryRepository
+	log        *logger.Logger
+}
+
+func NewHistory(repository repositories.HistoryRepository, log *logger.Logger) History {
+	return &history{
+		repository: repository,
+		log:        log.WithComponent("HistoryService"),
+	}
+}
+
+// List returns a page of the activity of a user across the library, the most recent events first
+func (h *history) List(ctx context.Context, userId uuid.UUID, pagination *Pagination) ([]models.MovieEvent, *PageInfo, error) {
+	collection, err := h.repository.List(ctx, userId, pagination.Limit(), pagination.Offset())
+	if err != nil {
+		h.log.Error().Err(err).Msg("Failed to fetch activity")
+		return nil, nil, errors.ErrFailedToFetchHistory
+	}
+
+	info := &PageInfo{}
+	if !pagination.SkipTotal {
+		total, err := h.repository.Count(ctx, userId)
+		if err != nil {
+			h.log.Error().Err(err).Msg("Failed to count activity")
+			return nil, nil, errors.ErrFailedToFetchHistory
+		}
+		info.Total = &total
+	}
+
+	return collection, info, nil
+}
+
+// ListByTmdbId returns a page of the history of a movie, the most recent events first. The history
+// outlives the movie, so it is still listed once the movie is purged
+func (h *history) ListByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID, pagination *Pagination) ([]models.MovieEvent, *PageInfo, error) {
+	collection, err := h.repository.ListByTmdbId(ctx, tmdbId, userId, pagination.Limit(), pagination.Offset())
+	if err != nil {
+		h.log.Error().Err(err).Msg("Failed to fetch movie history")
+		return nil, nil, errors.ErrFailedToFetchHistory
+	}
+
+	info := &PageInfo{}
+	if !pagination.SkipTotal {
+		total, err := h.repository.CountByTmdbId(ctx, tmdbId, userId)
+		if err != nil {
+			h.log.Error().Err(err).Msg("Failed to count movie history")
+			return nil, nil, errors.ErrFailedToFetchHistory
+		}
+		info.Total = &total
+	}
+
+	return collection, info, nil
+}
@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=history.go -destination=history_mock.go -package=services
+//
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	models "biinge-api/internal/app/models"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistory is a mock of History interface.
+type MockHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryMockRecorder is the mock recorder for MockHistory.
+type MockHistoryMockRecorder struct {
+	mock *MockHistory
+}
+
+// NewMockHistory creates a new mock instance.
+func NewMockHistory(ctrl *gomock.Controller) *MockHistory {
+	mock := &MockHistory{ctrl: ctrl}
+	mock.recorder = &MockHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistory) EXPECT() *MockHistoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockHistory) List(ctx context.Context, userId uuid.UUID, pagination *Pagination) ([]models.MovieEvent, *PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userId, pagination)
+	ret0, _ := ret[0].([]models.MovieEvent)
+	ret1, _ := ret[1].(*PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockHistoryMockRecorder) List(ctx, userId, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHistory)(nil).List), ctx, userId, pagination)
+}
+
+// ListByTmdbId mocks base method.
+func (m *MockHistory) ListByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID, pagination *Pagination) ([]models.MovieEvent, *PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTmdbId", ctx, tmdbId, userId, pagination)
+	ret0, _ := ret[0].([]models.MovieEvent)
+	ret1, _ := ret[1].(*PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByTmdbId indicates an expected call of ListByTmdbId.
+func (mr *MockHistoryMockRecorder) ListByTmdbId(ctx, tmdbId, userId, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTmdbId", reflect.TypeOf((*MockHistory)(nil).ListByTmdbId), ctx, tmdbId, userId, pagination)
+}
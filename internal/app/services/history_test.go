@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_History_ListByTmdbId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockHistoryRepository(ctrl)
+	service := NewHistory(repository, logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	events := []models.MovieEvent{
+		{TmdbId: 27205, Action: models.MovieEventUpdated, OldState: models.StateTypeWant, NewState: models.StateTypeWatched},
+		{TmdbId: 27205, Action: models.MovieEventCreated, NewState: models.StateTypeWant},
+	}
+
+	tests := []struct {
+		name       string
+		pagination *Pagination
+		before     func()
+		total      *uint64
+		error      error
+	}{
+		{
+			name:       "Counts the events",
+			pagination: &Pagination{Page: 1, PerPage: 24},
+			before: func() {
+				repository.EXPECT().ListByTmdbId(ctx, uint64(27205), userId, uint64(24), uint64(0)).Return(events, nil)
+				repository.EXPECT().CountByTmdbId(ctx, uint64(27205), userId).Return(uint64(2), nil)
+			},
+			total: func() *uint64 { total := uint64(2); return &total }(),
+		},
+		{
+			name:       "Skips the total",
+			pagination: &Pagination{Page: 2, PerPage: 10, SkipTotal: true},
+			before: func() {
+				repository.EXPECT().ListByTmdbId(ctx, uint64(27205), userId, uint64(10), uint64(10)).Return(nil, nil)
+			},
+		},
+		{
+			name:       "Failure",
+			pagination: &Pagination{Page: 1, PerPage: 24},
+			before: func() {
+				repository.EXPECT().ListByTmdbId(ctx, uint64(27205), userId, uint64(24), uint64(0)).Return(nil, assert.AnError)
+			},
+			error: errors.ErrFailedToFetchHistory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			_, info, err := service.ListByTmdbId(ctx, 27205, userId, tt.pagination)
+
+			assert.ErrorIs(t, err, tt.error)
+			if tt.error == nil {
+				assert.Equal(t, tt.total, info.Total)
+			}
+		})
+	}
+}
+
+func Test_EventSourceFromContext(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, models.EventSourceApi, EventSourceFromContext(ctx))
+	assert.Equal(t, models.EventSourceSync, EventSourceFromContext(WithEventSource(ctx, models.EventSourceSync)))
+}
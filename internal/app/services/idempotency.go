@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+const (
+	// DefaultIdempotencyKeyTTL is how long the response to an idempotency key is replayed to retries
+	DefaultIdempotencyKeyTTL = 24 * time.Hour
+	// IdempotencyClaimTimeout is how long a key stays held by a request that never completed, such as one
+	// interrupted by a restart, before a retry takes it over
+	IdempotencyClaimTimeout = 5 * time.Minute
+)
+
+type Idempotency interface {
+	Claim(ctx context.Context, userId uuid.UUID, key, fingerprint string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, key *models.IdempotencyKey) error
+	Release(ctx context.Context, key *models.IdempotencyKey) error
+	Purge(ctx context.Context, createdBefore time.Time) (uint64, error)
+}
+
+type idempotency struct {
+	repository repositories.IdempotencyRepository
+	ttl        time.Duration
+	log        *logger.Logger
+}
+
+func NewIdempotency(cfg *config.Config, repository repositories.IdempotencyRepository, log *logger.Logger) Idempotency {
+	ttl := cfg.IdempotencyConfig.KeyTTL
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyKeyTTL
+	}
+
+	return &idempotency{
+		repository: repository,
+		ttl:        ttl,
+		log:        log.WithComponent("IdempotencyService"),
+	}
+}
+
+// Claim holds a key for the request of the given fingerprint. The key returned is either a new claim, for
+// the request to run and be completed, or the completed key of a previous request to replay. A key used for
+// another request fails with ErrIdempotencyKeyReused, one held by a request in progress with
+// ErrIdempotencyKeyInProgress
+func (i *idempotency) Claim(ctx context.Context, userId uuid.UUID, key, fingerprint string) (*models.IdempotencyKey, error) {
+	now := time.Now()
+	claim := &models.IdempotencyKey{
+		UserId:      userId,
+		Key:         key,
+		Fingerprint: fingerprint,
+	}
+
+	id, err := i.repository.Claim(ctx, claim, now.Add(-i.ttl), now.Add(-IdempotencyClaimTimeout))
+	if err == nil {
+		claim.ID = id
+		return claim, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		i.log.Error().Err(err).Msg("Failed to claim idempotency key")
+		return nil, errors.ErrFailedToSaveIdempotencyKey
+	}
+
+	stored, err := i.repository.Find(ctx, userId, key)
+	// NOTE: released by the request holding it in the meantime, a retry can claim it
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.ErrIdempotencyKeyInProgress
+	}
+	if err != nil {
+		i.log.Error().Err(err).Msg("Failed to fetch idempotency key")
+		return nil, errors.ErrFailedToSaveIdempotencyKey
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, errors.ErrIdempotencyKeyReused
+	}
+	if !stored.Completed() {
+		return nil, errors.ErrIdempotencyKeyInProgress
+	}
+
+	return stored, nil
+}
+
+// Complete stores the response of the request holding the key, retries are answered with it from then on
+func (i *idempotency) Complete(ctx context.Context, key *models.IdempotencyKey) error {
+	if err := i.repository.Complete(ctx, key); err != nil {
+		i.log.Error().Err(err).Msg("Failed to complete idempotency key")
+		return errors.ErrFailedToSaveIdempotencyKey
+	}
+
+	return nil
+}
+
+// Release gives up a claimed key so a retry runs the request again, used when it failed on the server side
+func (i *idempotency) Release(ctx context.Context, key *models.IdempotencyKey) error {
+	if err := i.repository.Delete(ctx, key.ID); err != nil {
+		i.log.Error().Err(err).Msg("Failed to release idempotency key")
+		return errors.ErrFailedToSaveIdempotencyKey
+	}
+
+	return nil
+}
+
+// Purge removes the keys claimed before createdBefore and returns how many were removed
+func (i *idempotency) Purge(ctx context.Context, createdBefore time.Time) (uint64, error) {
+	purged, err := i.repository.Purge(ctx, createdBefore)
+	if err != nil {
+		i.log.Error().Err(err).Msg("Failed to purge idempotency keys")
+		return 0, errors.ErrFailedToSaveIdempotencyKey
+	}
+
+	return purged, nil
+}
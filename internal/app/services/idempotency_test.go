@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_Idempotency_Claim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockIdempotencyRepository(ctrl)
+	service := NewIdempotency(cfg, repository, logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	claimId := uuid.New()
+	claim := &models.IdempotencyKey{UserId: userId, Key: "key", Fingerprint: "fingerprint"}
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected *models.IdempotencyKey
+		error    error
+	}{
+		{
+			name: "Claims a new key",
+			before: func() {
+				repository.EXPECT().Claim(ctx, claim, gomock.Any(), gomock.Any()).Return(claimId, nil)
+			},
+			expected: &models.IdempotencyKey{ID: claimId, UserId: userId, Key: "key", Fingerprint: "fingerprint"},
+		},
+		{
+			name: "Replays a completed key",
+			before: func() {
+				repository.EXPECT().Claim(ctx, claim, gomock.Any(), gomock.Any()).Return(uuid.Nil, pgx.ErrNoRows)
+				repository.EXPECT().Find(ctx, userId, "key").Return(&models.IdempotencyKey{
+					ID: claimId, Fingerprint: "fingerprint", Status: 200, Body: []byte(`{}`),
+				}, nil)
+			},
+			expected: &models.IdempotencyKey{ID: claimId, Fingerprint: "fingerprint", Status: 200, Body: []byte(`{}`)},
+		},
+		{
+			name: "Key in progress",
+			before: func() {
+				repository.EXPECT().Claim(ctx, claim, gomock.Any(), gomock.Any()).Return(uuid.Nil, pgx.ErrNoRows)
+				repository.EXPECT().Find(ctx, userId, "key").Return(&models.IdempotencyKey{ID: claimId, Fingerprint: "fingerprint"}, nil)
+			},
+			error: errors.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name: "Key released in the meantime",
+			before: func() {
+				repository.EXPECT().Claim(ctx, claim, gomock.Any(), gomock.Any()).Return(uuid.Nil, pgx.ErrNoRows)
+				repository.EXPECT().Find(ctx, userId, "key").Return(nil, pgx.ErrNoRows)
+			},
+			error: errors.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name: "Key used for another request",
+			before: func() {
+				repository.EXPECT().Claim(ctx, claim, gomock.Any(), gomock.Any()).Return(uuid.Nil, pgx.ErrNoRows)
+				repository.EXPECT().Find(ctx, userId, "key").Return(&models.IdempotencyKey{
+					ID: claimId, Fingerprint: "other", Status: 200,
+				}, nil)
+			},
+			error: errors.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "Failure",
+			before: func() {
+				repository.EXPECT().Claim(ctx, claim, gomock.Any(), gomock.Any()).Return(uuid.Nil, assert.AnError)
+			},
+			error: errors.ErrFailedToSaveIdempotencyKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := service.Claim(ctx, userId, "key", "fingerprint")
+
+			assert.ErrorIs(t, err, tt.error)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Idempotency_Claim_Windows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+		IdempotencyConfig: config.IdempotencyConfig{
+			KeyTTL: time.Hour,
+		},
+	}
+	repository := repositories.NewMockIdempotencyRepository(ctrl)
+	service := NewIdempotency(cfg, repository, logger.NewLogger(cfg))
+
+	repository.EXPECT().Claim(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *models.IdempotencyKey, expiredBefore, abandonedBefore time.Time) (uuid.UUID, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), expiredBefore, time.Minute)
+			assert.WithinDuration(t, time.Now().Add(-IdempotencyClaimTimeout), abandonedBefore, time.Minute)
+			return uuid.New(), nil
+		},
+	)
+
+	_, err := service.Claim(ctx, uuid.New(), "key", "fingerprint")
+	assert.NoError(t, err)
+}
@@ -4,8 +4,13 @@ import "go.uber.org/fx"
 
 var Module = fx.Options(
 	fx.Provide(NewAuthentication),
+	fx.Provide(NewCredits),
 	fx.Provide(NewHealthChecker),
+	fx.Provide(NewHistory),
+	fx.Provide(NewIdempotency),
 	fx.Provide(NewTmdbProvider),
 	fx.Provide(NewMovies),
+	fx.Provide(NewTags),
+	fx.Provide(NewTitles),
 	fx.Provide(NewUsers),
 )
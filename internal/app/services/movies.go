@@ -2,67 +2,320 @@ package services
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"biinge-api/internal/app/errors"
 	"biinge-api/internal/app/models"
 	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/app/repositories/postgres"
+	"biinge-api/internal/config"
 	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
 )
 
 type Movies interface {
-	List(ctx context.Context, userId uuid.UUID, status string, pagination *Pagination) ([]models.Movie, uint64, error)
+	List(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter, pagination *Pagination) ([]models.Movie, *PageInfo, error)
 	Create(ctx context.Context, params *models.Movie) (*models.Movie, error)
+	Upsert(ctx context.Context, params *models.Movie) (*models.MovieUpsert, error)
 	Update(ctx context.Context, params *models.Movie) (*models.Movie, error)
 	UpdateByTmdbId(ctx context.Context, params *models.Movie) (*models.Movie, error)
+	UpdateNotes(ctx context.Context, tmdbId uint64, userId uuid.UUID, notes string) (*models.Movie, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) error
+	Move(ctx context.Context, userId uuid.UUID, tmdbId, anchorTmdbId uint64, after bool) (*models.Movie, error)
+	Bulk(ctx context.Context, user *models.User, operations []models.BulkOperation, atomic bool) ([]models.BulkResult, error)
+	ListTrash(ctx context.Context, userId uuid.UUID, pagination *Pagination) ([]models.Movie, *PageInfo, error)
+	Restore(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error)
+	EmptyTrash(ctx context.Context, userId uuid.UUID) (uint64, error)
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (uint64, error)
 	FindById(ctx context.Context, id uuid.UUID) (*models.Movie, error)
 	FindByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error)
 	FindMoviesByTmdbIds(ctx context.Context, tmdbIds []uint64, userId uuid.UUID) ([]models.Movie, error)
+	FindWithStaleWatchProviders(ctx context.Context, defaultRegion string, refreshedBefore time.Time, limit uint64) ([]models.Movie, error)
+	UpdateWatchProviders(ctx context.Context, ids []uuid.UUID, region string, providers []int32) error
+}
+
+const (
+	// DefaultWatchedThreshold is the share of the runtime in percent after which a movie counts as watched
+	DefaultWatchedThreshold = 90
+	// DefaultTrashRetention is how long deleted movies stay in the trash before being purged
+	DefaultTrashRetention = 30 * 24 * time.Hour
+)
+
+// TrashedMovieError is returned when adding a title waiting in the trash, Movie holds its previous data
+// for the client to offer restoring it
+type TrashedMovieError struct {
+	Movie *models.Movie
+}
+
+func (e *TrashedMovieError) Error() string {
+	return errors.ErrMovieInTrash.Error()
+}
+
+func (e *TrashedMovieError) Is(target error) bool {
+	return target == errors.ErrMovieInTrash
 }
 
 type movies struct {
-	repository repositories.MovieRepository
-	log        *logger.Logger
+	repository       repositories.MovieRepository
+	titles           Titles
+	allowAdult       bool
+	watchedThreshold uint64
+	trashRetention   time.Duration
+	log              *logger.Logger
 }
 
-func NewMovies(repository repositories.MovieRepository, log *logger.Logger) Movies {
+func NewMovies(cfg *config.Config, repository repositories.MovieRepository, titles Titles, log *logger.Logger) Movies {
+	threshold := cfg.LibraryConfig.WatchedThreshold
+	if threshold <= 0 || threshold > 100 {
+		threshold = DefaultWatchedThreshold
+	}
+
+	retention := cfg.LibraryConfig.TrashRetention
+	if retention <= 0 {
+		retention = DefaultTrashRetention
+	}
+
 	return &movies{
-		repository: repository,
-		log:        log.WithComponent("MoviesService"),
+		repository:       repository,
+		titles:           titles,
+		allowAdult:       cfg.ContentConfig.AllowAdult,
+		watchedThreshold: uint64(threshold),
+		trashRetention:   retention,
+		log:              log.WithComponent("MoviesService"),
 	}
 }
 
-func (m *movies) List(ctx context.Context, userId uuid.UUID, status string, pagination *Pagination) ([]models.Movie, uint64, error) {
-	collection, total, err := m.repository.List(ctx, userId, status, pagination.Limit(), pagination.Offset())
+func (m *movies) List(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter, pagination *Pagination) ([]models.Movie, *PageInfo, error) {
+	var after *models.MovieCursor
+	if pagination.Keyset && pagination.Cursor != "" {
+		cursor, err := decodeMovieCursor(pagination.Cursor)
+		if err != nil || cursor.Sort != filter.Sort || cursor.Direction != filter.Direction {
+			return nil, nil, errors.ErrInvalidCursor
+		}
+		after = cursor
+	}
 
+	collection, next, err := m.repository.List(ctx, userId, filter, after, pagination.Limit(), pagination.Offset())
 	if err != nil {
 		m.log.Error().Err(err).Msg("Failed to fetch movies")
-		return nil, 0, errors.ErrFailedToFetchMovies
+		return nil, nil, errors.ErrFailedToFetchMovies
 	}
 
-	return collection, total, nil
+	info := &PageInfo{}
+	if next != nil {
+		info.NextCursor = encodeMovieCursor(next)
+	}
+
+	if !pagination.SkipTotal {
+		total, err := m.repository.Count(ctx, userId, filter)
+		if err != nil {
+			m.log.Error().Err(err).Msg("Failed to count movies")
+			return nil, nil, errors.ErrFailedToFetchMovies
+		}
+		info.Total = &total
+	}
+
+	return collection, info, nil
 }
 
+// Create adds a movie with its metadata from TMDB. While TMDB is unavailable the metadata supplied
+// by the client is stored instead and the movie is flagged for the titles refresher. A title waiting
+// in the trash is restored or discarded as params.Trashed says, a TrashedMovieError is returned otherwise
 func (m *movies) Create(ctx context.Context, params *models.Movie) (*models.Movie, error) {
-	item, err := m.repository.Create(ctx, &models.Movie{
-		UserId:     params.UserId,
-		TmdbId:     params.TmdbId,
-		Title:      params.Title,
-		PosterPath: params.PosterPath,
-		Runtime:    params.Runtime,
-		State:      params.State,
+	return m.create(ctx, params, nil)
+}
+
+// create is Create with the title of the movie already resolved, it is resolved from TMDB when title is nil
+func (m *movies) create(ctx context.Context, params *models.Movie, title *models.Title) (*models.Movie, error) {
+	trashed, err := m.repository.FindTrashed(ctx, params.TmdbId, params.UserId)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		m.log.Error().Err(err).Msg("Failed to fetch trashed movie")
+		return nil, errors.ErrFailedToCreateMovie
+	}
+
+	if trashed != nil {
+		switch params.Trashed {
+		case models.TrashedRestore:
+			return m.Restore(ctx, params.TmdbId, params.UserId)
+		case models.TrashedDiscard:
+			// NOTE: purged along the creation below
+		default:
+			trashed.PurgeAt = m.purgeAt(trashed.DeletedAt)
+			return nil, &TrashedMovieError{Movie: trashed}
+		}
+	}
+
+	movie := &models.Movie{
+		UserId: params.UserId,
+		TmdbId: params.TmdbId,
+		State:  params.State,
+	}
+
+	if title != nil {
+		setTitle(movie, title)
+	} else if err := m.resolveTitle(ctx, movie, params); err != nil {
+		return nil, err
+	}
+
+	if err := m.trackProgress(movie, params.ReportedProgress, 0); err != nil {
+		return nil, err
+	}
+
+	var item *models.Movie
+	err = m.transaction(ctx, errors.ErrFailedToCreateMovie, func(m *movies) error {
+		if trashed != nil {
+			if err := m.repository.DeleteByTmdbId(ctx, params.TmdbId, params.UserId); err != nil {
+				m.log.Error().Err(err).Msg("Failed to discard trashed movie")
+				return errors.ErrFailedToCreateMovie
+			}
+
+			err := m.record(ctx, &models.MovieEvent{
+				UserId:    trashed.UserId,
+				TmdbId:    trashed.TmdbId,
+				Action:    models.MovieEventPurged,
+				OldState:  trashed.State,
+				OldPinned: &trashed.Pinned,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		created, err := m.repository.Create(ctx, movie)
+		// NOTE: a retried request or another device added the title first
+		if postgres.IsUniqueViolation(err) {
+			return errors.ErrMovieAlreadyExists
+		}
+		if err != nil {
+			m.log.Error().Err(err).Msg("Failed to create movie")
+			return errors.ErrFailedToCreateMovie
+		}
+		item = created
+
+		return m.record(ctx, &models.MovieEvent{
+			UserId:    movie.UserId,
+			TmdbId:    movie.TmdbId,
+			Action:    models.MovieEventCreated,
+			NewState:  item.State,
+			NewPinned: &item.Pinned,
+		})
 	})
 	if err != nil {
-		m.log.Error().Err(err).Msg("Failed to create movie")
-		return nil, errors.ErrFailedToCreateMovie
+		return nil, err
 	}
 
 	return item, nil
 }
 
+// Upsert adds a movie with its metadata from TMDB like Create or, when the user already holds the title,
+// sets its state and pin and brings it back from the trash. Sending it again leaves the library as it was
+// after the first call, the event recorded tells whether the movie was created, restored or updated and
+// none is when the movie already was as asked
+func (m *movies) Upsert(ctx context.Context, params *models.Movie) (*models.MovieUpsert, error) {
+	movie := &models.Movie{
+		UserId: params.UserId,
+		TmdbId: params.TmdbId,
+		State:  params.State,
+		Pinned: params.Pinned,
+
+		ReportedProgress: params.ReportedProgress,
+	}
+
+	if err := m.resolveTitle(ctx, movie, params); err != nil {
+		return nil, err
+	}
+
+	if err := m.trackProgress(movie, params.ReportedProgress, 0); err != nil {
+		return nil, err
+	}
+
+	var upsert *models.MovieUpsert
+	err := m.transaction(ctx, errors.ErrFailedToSaveMovie, func(m *movies) error {
+		result, err := m.repository.Upsert(ctx, movie)
+		if err != nil {
+			m.log.Error().Err(err).Msg("Failed to upsert movie")
+			return errors.ErrFailedToSaveMovie
+		}
+		upsert = result
+
+		if !upsert.Changed {
+			return nil
+		}
+
+		event := &models.MovieEvent{
+			UserId:    movie.UserId,
+			TmdbId:    movie.TmdbId,
+			Action:    models.MovieEventCreated,
+			NewState:  upsert.Movie.State,
+			NewPinned: &upsert.Movie.Pinned,
+		}
+
+		switch previous := upsert.Previous; {
+		case previous == nil:
+		case previous.DeletedAt != nil:
+			event.Action = models.MovieEventRestored
+		default:
+			event.Action = models.MovieEventUpdated
+			event.OldState = previous.State
+			event.OldPinned = &previous.Pinned
+		}
+
+		return m.record(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return upsert, nil
+}
+
+// resolveTitle fills movie with the metadata of the title from TMDB, under its canonical Id. While TMDB is
+// unavailable the metadata of params is used and the movie flagged for the titles refresher, if there is any.
+// An adult title is not found unless params.IncludeAdult is set, like on the movie details
+func (m *movies) resolveTitle(ctx context.Context, movie *models.Movie, params *models.Movie) error {
+	title, err := m.titles.Resolve(ctx, params.TmdbId)
+	if err == nil && m.hidden(title, params.IncludeAdult) {
+		err = errors.ErrMovieNotFound
+	}
+
+	switch {
+	case err == nil:
+		setTitle(movie, title)
+	case errors.Is(err, errors.ErrMovieNotFound), params.Title == "":
+		return err
+	default:
+		m.log.Warn().Err(err).Uint64("TmdbId", params.TmdbId).Msg("Failed to resolve movie, using client metadata")
+
+		movie.Title = params.Title
+		movie.PosterPath = params.PosterPath
+		movie.Runtime = params.Runtime
+		movie.NeedsResync = true
+	}
+
+	return nil
+}
+
+// hidden tells whether title is an adult one kept out of the library, the server has to allow adult
+// titles and the user to have confirmed them
+func (m *movies) hidden(title *models.Title, includeAdult bool) bool {
+	return title.Adult && !(m.allowAdult && includeAdult)
+}
+
+// setTitle fills movie with the metadata of title, under its canonical Id
+func setTitle(movie *models.Movie, title *models.Title) {
+	movie.TmdbId = title.TmdbId
+	movie.Title = title.Title
+	movie.PosterPath = title.PosterPath
+	movie.Runtime = title.Runtime
+}
+
 func (m *movies) Update(ctx context.Context, params *models.Movie) (*models.Movie, error) {
 	item, err := m.repository.Update(ctx, &models.Movie{
 		ID:         params.ID,
@@ -79,20 +332,107 @@ func (m *movies) Update(ctx context.Context, params *models.Movie) (*models.Movi
 }
 
 func (m *movies) UpdateByTmdbId(ctx context.Context, params *models.Movie) (*models.Movie, error) {
-	item, err := m.repository.UpdateByTmdbId(ctx, &models.Movie{
-		TmdbId: params.TmdbId,
-		UserId: params.UserId,
-		State:  params.State,
-		Pinned: params.Pinned,
+	var item *models.Movie
+	err := m.transaction(ctx, errors.ErrFailedToUpdateMovie, func(m *movies) error {
+		current, err := m.repository.FindByTmdbId(ctx, params.TmdbId, params.UserId)
+		if err != nil {
+			m.log.Error().Err(err).Msg("Failed to fetch movie by TMDB Id")
+			return errors.ErrMovieNotFound
+		}
+
+		movie := &models.Movie{
+			TmdbId: params.TmdbId,
+			UserId: params.UserId,
+			State:  params.State,
+			Pinned: params.Pinned,
+		}
+
+		var progress uint64
+		if params.State == models.StateTypeWatching {
+			movie.Runtime = current.Runtime
+			progress = current.Progress
+		}
+
+		if err := m.trackProgress(movie, params.ReportedProgress, progress); err != nil {
+			return err
+		}
+
+		updated, err := m.repository.UpdateByTmdbId(ctx, movie)
+		if err != nil {
+			m.log.Error().Err(err).Msg("Failed to update movie by TMDB Id")
+			return errors.ErrFailedToUpdateMovie
+		}
+		item = updated
+
+		return m.record(ctx, &models.MovieEvent{
+			UserId:    params.UserId,
+			TmdbId:    params.TmdbId,
+			Action:    models.MovieEventUpdated,
+			OldState:  current.State,
+			NewState:  item.State,
+			OldPinned: &current.Pinned,
+			NewPinned: &item.Pinned,
+		})
 	})
 	if err != nil {
-		m.log.Error().Err(err).Msg("Failed to update movie by TMDB Id")
-		return nil, errors.ErrFailedToUpdateMovie
+		return nil, err
 	}
 
 	return item, nil
 }
 
+// trackProgress stores the progress of a movie being watched in minutes, the current one unless a new
+// one is reported, and switches the movie to watched once past the threshold. Other states drop it
+func (m *movies) trackProgress(movie *models.Movie, reported *models.WatchProgress, current uint64) error {
+	if movie.State != models.StateTypeWatching {
+		movie.Progress = 0
+		return nil
+	}
+
+	progress := current
+	switch {
+	case reported == nil:
+	case reported.Minutes != nil:
+		progress = *reported.Minutes
+	case reported.Percent != nil:
+		// NOTE: without a runtime a percentage cannot be turned into minutes
+		if movie.Runtime == 0 {
+			return errors.ErrInvalidProgress
+		}
+		progress = movie.Runtime * *reported.Percent / 100
+	}
+
+	if movie.Runtime > 0 {
+		progress = min(progress, movie.Runtime)
+
+		if progress*100 >= movie.Runtime*m.watchedThreshold {
+			movie.State = models.StateTypeWatched
+			progress = 0
+		}
+	}
+
+	movie.Progress = progress
+	return nil
+}
+
+// UpdateNotes replaces the private notes of a movie of the library, empty notes clear them
+func (m *movies) UpdateNotes(ctx context.Context, tmdbId uint64, userId uuid.UUID, notes string) (*models.Movie, error) {
+	if utf8.RuneCountInString(notes) > models.MaxNotesLength {
+		return nil, errors.ErrInvalidNotes
+	}
+
+	err := m.repository.UpdateNotes(ctx, tmdbId, userId, notes)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.ErrMovieNotFound
+	}
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to update movie notes")
+		return nil, errors.ErrFailedToUpdateMovie
+	}
+
+	return m.FindByTmdbId(ctx, tmdbId, userId)
+}
+
 func (m *movies) Delete(ctx context.Context, id uuid.UUID) error {
 	err := m.repository.Delete(ctx, id)
 	if err != nil {
@@ -103,11 +443,316 @@ func (m *movies) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// DeleteByTmdbId moves a movie to the trash, where it keeps its data until restored or purged
 func (m *movies) DeleteByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) error {
-	err := m.repository.DeleteByTmdbId(ctx, tmdbId, userId)
+	return m.transaction(ctx, errors.ErrFailedToDeleteMovie, func(m *movies) error {
+		current, err := m.repository.FindByTmdbId(ctx, tmdbId, userId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.ErrMovieNotFound
+		}
+		if err != nil {
+			m.log.Error().Err(err).Msg("Failed to fetch movie by TMDB Id")
+			return errors.ErrFailedToDeleteMovie
+		}
+
+		if err := m.repository.Trash(ctx, tmdbId, userId); err != nil {
+			m.log.Error().Err(err).Msg("Failed to delete movie by TMDB Id")
+			return errors.ErrFailedToDeleteMovie
+		}
+
+		return m.record(ctx, &models.MovieEvent{
+			UserId:    userId,
+			TmdbId:    tmdbId,
+			Action:    models.MovieEventDeleted,
+			OldState:  current.State,
+			OldPinned: &current.Pinned,
+		})
+	})
+}
+
+// ListTrash returns a page of the trash, the most recently deleted movies first. The trash is only
+// paged by number, a cursor is refused with ErrCursorNotSupported
+func (m *movies) ListTrash(ctx context.Context, userId uuid.UUID, pagination *Pagination) ([]models.Movie, *PageInfo, error) {
+	if pagination.Keyset {
+		return nil, nil, errors.ErrCursorNotSupported
+	}
+
+	collection, err := m.repository.ListTrashed(ctx, userId, pagination.PerPage, pagination.Offset())
 	if err != nil {
-		m.log.Error().Err(err).Msg("Failed to delete movie by TMDB Id")
-		return errors.ErrFailedToDeleteMovie
+		m.log.Error().Err(err).Msg("Failed to fetch trashed movies")
+		return nil, nil, errors.ErrFailedToFetchTrash
+	}
+
+	for i := range collection {
+		collection[i].PurgeAt = m.purgeAt(collection[i].DeletedAt)
+	}
+
+	info := &PageInfo{}
+	if !pagination.SkipTotal {
+		total, err := m.repository.CountTrashed(ctx, userId)
+		if err != nil {
+			m.log.Error().Err(err).Msg("Failed to count trashed movies")
+			return nil, nil, errors.ErrFailedToFetchTrash
+		}
+		info.Total = &total
+	}
+
+	return collection, info, nil
+}
+
+// Restore brings a movie back from the trash with the pin, state and progress it had
+func (m *movies) Restore(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error) {
+	var item *models.Movie
+	err := m.transaction(ctx, errors.ErrFailedToRestoreMovie, func(m *movies) error {
+		err := m.repository.Restore(ctx, tmdbId, userId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.ErrMovieNotFound
+		}
+		if err != nil {
+			m.log.Error().Err(err).Msg("Failed to restore movie")
+			return errors.ErrFailedToRestoreMovie
+		}
+
+		restored, err := m.FindByTmdbId(ctx, tmdbId, userId)
+		if err != nil {
+			return err
+		}
+		item = restored
+
+		return m.record(ctx, &models.MovieEvent{
+			UserId:    userId,
+			TmdbId:    tmdbId,
+			Action:    models.MovieEventRestored,
+			NewState:  item.State,
+			NewPinned: &item.Pinned,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// EmptyTrash purges every movie in the trash of the user and returns how many were purged
+func (m *movies) EmptyTrash(ctx context.Context, userId uuid.UUID) (uint64, error) {
+	purged, err := m.repository.EmptyTrash(ctx, userId, EventSourceFromContext(ctx))
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to empty trash")
+		return 0, errors.ErrFailedToEmptyTrash
+	}
+
+	return purged, nil
+}
+
+// PurgeTrash purges the movies of every user trashed before deletedBefore
+func (m *movies) PurgeTrash(ctx context.Context, deletedBefore time.Time) (uint64, error) {
+	purged, err := m.repository.PurgeTrash(ctx, deletedBefore, EventSourceFromContext(ctx))
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to purge trash")
+		return 0, errors.ErrFailedToEmptyTrash
+	}
+
+	return purged, nil
+}
+
+func (m *movies) purgeAt(deletedAt *time.Time) *time.Time {
+	if deletedAt == nil {
+		return nil
+	}
+
+	purgeAt := deletedAt.Add(m.trashRetention)
+	return &purgeAt
+}
+
+// Move places a movie right before or after the anchor movie in the manual order of the user
+func (m *movies) Move(ctx context.Context, userId uuid.UUID, tmdbId, anchorTmdbId uint64, after bool) (*models.Movie, error) {
+	if tmdbId == anchorTmdbId {
+		return nil, errors.ErrInvalidMove
+	}
+
+	err := m.repository.Move(ctx, userId, tmdbId, anchorTmdbId, after)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.ErrMovieNotFound
+	}
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to move movie")
+		return nil, errors.ErrFailedToMoveMovie
+	}
+
+	return m.FindByTmdbId(ctx, tmdbId, userId)
+}
+
+// Bulk applies operations in order within a single transaction, each in a savepoint of its own. An atomic
+// bulk is rolled back as a whole on the first failure and returns ErrBulkAborted along the results,
+// otherwise failed operations are reported in their result and the others are kept. Titles to add are
+// resolved from TMDB before the transaction opens, an atomic bulk fails as a whole while TMDB is unavailable.
+// Adult titles are only added for a user who confirmed them
+func (m *movies) Bulk(ctx context.Context, user *models.User, operations []models.BulkOperation, atomic bool) ([]models.BulkResult, error) {
+	userId := user.ID
+	results := make([]models.BulkResult, len(operations))
+	failures := make([]error, len(operations))
+	titles := make(map[uint64]*models.Title)
+	for i, operation := range operations {
+		results[i] = models.BulkResult{Op: operation.Op, TmdbId: operation.TmdbId, Status: models.BulkStatusSkipped}
+		failures[i] = operation.Err
+
+		if failures[i] != nil || operation.Op != models.BulkOpAdd || titles[operation.TmdbId] != nil {
+			continue
+		}
+
+		title, err := m.titles.Resolve(ctx, operation.TmdbId)
+		switch {
+		case atomic && errors.Is(err, tmdb.ErrUpstreamUnavailable):
+			return nil, err
+		case err != nil:
+			failures[i] = err
+		case m.hidden(title, user.AdultConfirmed()):
+			failures[i] = errors.ErrMovieNotFound
+		default:
+			titles[operation.TmdbId] = title
+		}
+	}
+
+	err := m.repository.Transaction(ctx, func(repository repositories.MovieRepository) error {
+		for i := range operations {
+			err := failures[i]
+			if err == nil {
+				err = repository.Transaction(ctx, func(repository repositories.MovieRepository) error {
+					return m.withRepository(repository).apply(ctx, userId, &operations[i], titles[operations[i].TmdbId])
+				})
+			}
+
+			if err != nil {
+				results[i].Status = models.BulkStatusFailed
+				results[i].Err = err
+				if atomic {
+					return errors.ErrBulkAborted
+				}
+				continue
+			}
+
+			results[i].Status = models.BulkStatusApplied
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errors.ErrBulkAborted) {
+		for i := range results {
+			if results[i].Status == models.BulkStatusApplied {
+				results[i].Status = models.BulkStatusRolledBack
+			}
+		}
+		return results, err
+	}
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to apply bulk operations")
+		return nil, errors.ErrFailedToBulkUpdate
+	}
+
+	return results, nil
+}
+
+// apply runs a single bulk operation on the movie it targets, title being the resolved title of an add
+func (m *movies) apply(ctx context.Context, userId uuid.UUID, operation *models.BulkOperation, title *models.Title) error {
+	current, err := m.repository.FindByTmdbId(ctx, operation.TmdbId, userId)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		m.log.Error().Err(err).Msg("Failed to fetch movie by TMDB Id")
+		return errors.ErrFailedToFetchMovie
+	}
+
+	if operation.Op == models.BulkOpAdd {
+		if current != nil {
+			return errors.ErrMovieAlreadyExists
+		}
+
+		_, err = m.create(ctx, &models.Movie{UserId: userId, TmdbId: operation.TmdbId, State: operation.State}, title)
+		return err
+	}
+
+	if current == nil {
+		return errors.ErrMovieNotFound
+	}
+
+	switch operation.Op {
+	case models.BulkOpState:
+		_, err = m.UpdateByTmdbId(ctx, &models.Movie{
+			TmdbId: operation.TmdbId,
+			UserId: userId,
+			State:  operation.State,
+			Pinned: current.Pinned,
+		})
+	case models.BulkOpPin, models.BulkOpUnpin:
+		_, err = m.UpdateByTmdbId(ctx, &models.Movie{
+			TmdbId: operation.TmdbId,
+			UserId: userId,
+			State:  current.State,
+			Pinned: operation.Op == models.BulkOpPin,
+		})
+	case models.BulkOpDelete:
+		err = m.DeleteByTmdbId(ctx, operation.TmdbId, userId)
+	case models.BulkOpTag:
+		err = m.addTags(ctx, current, operation.Tags)
+	default:
+		err = errors.ErrInvalidOperation
+	}
+
+	return err
+}
+
+// addTags labels a movie with tags on top of the ones it holds, within the limit of tags per movie
+func (m *movies) addTags(ctx context.Context, movie *models.Movie, names []string) error {
+	held := make(map[string]struct{}, len(movie.Tags)+len(names))
+	for _, name := range append(slices.Clone(movie.Tags), names...) {
+		held[strings.ToLower(name)] = struct{}{}
+	}
+	if len(held) > models.MaxMovieTags {
+		return errors.ErrTooManyTags
+	}
+
+	if err := m.repository.AddTags(ctx, movie.ID, movie.UserId, names); err != nil {
+		m.log.Error().Err(err).Msg("Failed to tag movie")
+		return errors.ErrFailedToTagMovie
+	}
+
+	return nil
+}
+
+// withRepository returns a copy of the service running its queries on repository, bound to a transaction
+func (m *movies) withRepository(repository repositories.MovieRepository) *movies {
+	scoped := *m
+	scoped.repository = repository
+
+	return &scoped
+}
+
+// transaction runs fn with a copy of the service bound to a transaction, so a change and the events recording
+// it are committed together. Errors of fn are returned as is, failing to open or commit the transaction as failure
+func (m *movies) transaction(ctx context.Context, failure error, fn func(m *movies) error) error {
+	var failed error
+	err := m.repository.Transaction(ctx, func(repository repositories.MovieRepository) error {
+		failed = fn(m.withRepository(repository))
+		return failed
+	})
+	if failed != nil {
+		return failed
+	}
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to commit movie change")
+		return failure
+	}
+
+	return nil
+}
+
+// record appends an event to the history of a movie, from the source ctx carries
+func (m *movies) record(ctx context.Context, event *models.MovieEvent) error {
+	event.Source = EventSourceFromContext(ctx)
+
+	if err := m.repository.CreateEvent(ctx, event); err != nil {
+		m.log.Error().Err(err).Str("action", event.Action).Msg("Failed to record movie event")
+		return errors.ErrFailedToRecordEvent
 	}
 
 	return nil
@@ -142,3 +787,23 @@ func (m *movies) FindMoviesByTmdbIds(ctx context.Context, tmdbIds []uint64, user
 
 	return collection, nil
 }
+
+func (m *movies) FindWithStaleWatchProviders(ctx context.Context, defaultRegion string, refreshedBefore time.Time, limit uint64) ([]models.Movie, error) {
+	collection, err := m.repository.FindWithStaleWatchProviders(ctx, defaultRegion, refreshedBefore, limit)
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to fetch movies with stale watch providers")
+		return nil, errors.ErrFailedToFetchMovies
+	}
+
+	return collection, nil
+}
+
+func (m *movies) UpdateWatchProviders(ctx context.Context, ids []uuid.UUID, region string, providers []int32) error {
+	err := m.repository.UpdateWatchProviders(ctx, ids, region, providers)
+	if err != nil {
+		m.log.Error().Err(err).Msg("Failed to update movie watch providers")
+		return errors.ErrFailedToUpdateMovie
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: movies.go
+//
+// Generated by this command:
+//
+//	mockgen -source=movies.go -destination=movies_mock.go -package=services
+//
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	models "biinge-api/internal/app/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMovies is a mock of Movies interface.
+type MockMovies struct {
+	ctrl     *gomock.Controller
+	recorder *MockMoviesMockRecorder
+	isgomock struct{}
+}
+
+// MockMoviesMockRecorder is the mock recorder for MockMovies.
+type MockMoviesMockRecorder struct {
+	mock *MockMovies
+}
+
+// NewMockMovies creates a new mock instance.
+func NewMockMovies(ctrl *gomock.Controller) *MockMovies {
+	mock := &MockMovies{ctrl: ctrl}
+	mock.recorder = &MockMoviesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovies) EXPECT() *MockMoviesMockRecorder {
+	return m.recorder
+}
+
+// Bulk mocks base method.
+func (m *MockMovies) Bulk(ctx context.Context, user *models.User, operations []models.BulkOperation, atomic bool) ([]models.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", ctx, user, operations, atomic)
+	ret0, _ := ret[0].([]models.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockMoviesMockRecorder) Bulk(ctx, user, operations, atomic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockMovies)(nil).Bulk), ctx, user, operations, atomic)
+}
+
+// Create mocks base method.
+func (m *MockMovies) Create(ctx context.Context, params *models.Movie) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, params)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMoviesMockRecorder) Create(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMovies)(nil).Create), ctx, params)
+}
+
+// Delete mocks base method.
+func (m *MockMovies) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMoviesMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMovies)(nil).Delete), ctx, id)
+}
+
+// DeleteByTmdbId mocks base method.
+func (m *MockMovies) DeleteByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTmdbId", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTmdbId indicates an expected call of DeleteByTmdbId.
+func (mr *MockMoviesMockRecorder) DeleteByTmdbId(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTmdbId", reflect.TypeOf((*MockMovies)(nil).DeleteByTmdbId), ctx, tmdbId, userId)
+}
+
+// EmptyTrash mocks base method.
+func (m *MockMovies) EmptyTrash(ctx context.Context, userId uuid.UUID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmptyTrash", ctx, userId)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmptyTrash indicates an expected call of EmptyTrash.
+func (mr *MockMoviesMockRecorder) EmptyTrash(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyTrash", reflect.TypeOf((*MockMovies)(nil).EmptyTrash), ctx, userId)
+}
+
+// FindById mocks base method.
+func (m *MockMovies) FindById(ctx context.Context, id uuid.UUID) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockMoviesMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockMovies)(nil).FindById), ctx, id)
+}
+
+// FindByTmdbId mocks base method.
+func (m *MockMovies) FindByTmdbId(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTmdbId", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTmdbId indicates an expected call of FindByTmdbId.
+func (mr *MockMoviesMockRecorder) FindByTmdbId(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTmdbId", reflect.TypeOf((*MockMovies)(nil).FindByTmdbId), ctx, tmdbId, userId)
+}
+
+// FindMoviesByTmdbIds mocks base method.
+func (m *MockMovies) FindMoviesByTmdbIds(ctx context.Context, tmdbIds []uint64, userId uuid.UUID) ([]models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMoviesByTmdbIds", ctx, tmdbIds, userId)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMoviesByTmdbIds indicates an expected call of FindMoviesByTmdbIds.
+func (mr *MockMoviesMockRecorder) FindMoviesByTmdbIds(ctx, tmdbIds, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMoviesByTmdbIds", reflect.TypeOf((*MockMovies)(nil).FindMoviesByTmdbIds), ctx, tmdbIds, userId)
+}
+
+// FindWithStaleWatchProviders mocks base method.
+func (m *MockMovies) FindWithStaleWatchProviders(ctx context.Context, defaultRegion string, refreshedBefore time.Time, limit uint64) ([]models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWithStaleWatchProviders", ctx, defaultRegion, refreshedBefore, limit)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWithStaleWatchProviders indicates an expected call of FindWithStaleWatchProviders.
+func (mr *MockMoviesMockRecorder) FindWithStaleWatchProviders(ctx, defaultRegion, refreshedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithStaleWatchProviders", reflect.TypeOf((*MockMovies)(nil).FindWithStaleWatchProviders), ctx, defaultRegion, refreshedBefore, limit)
+}
+
+// List mocks base method.
+func (m *MockMovies) List(ctx context.Context, userId uuid.UUID, filter *models.MovieFilter, pagination *Pagination) ([]models.Movie, *PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userId, filter, pagination)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(*PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockMoviesMockRecorder) List(ctx, userId, filter, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMovies)(nil).List), ctx, userId, filter, pagination)
+}
+
+// ListTrash mocks base method.
+func (m *MockMovies) ListTrash(ctx context.Context, userId uuid.UUID, pagination *Pagination) ([]models.Movie, *PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, userId, pagination)
+	ret0, _ := ret[0].([]models.Movie)
+	ret1, _ := ret[1].(*PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockMoviesMockRecorder) ListTrash(ctx, userId, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockMovies)(nil).ListTrash), ctx, userId, pagination)
+}
+
+// Move mocks base method.
+func (m *MockMovies) Move(ctx context.Context, userId uuid.UUID, tmdbId, anchorTmdbId uint64, after bool) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, userId, tmdbId, anchorTmdbId, after)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockMoviesMockRecorder) Move(ctx, userId, tmdbId, anchorTmdbId, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockMovies)(nil).Move), ctx, userId, tmdbId, anchorTmdbId, after)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockMovies) PurgeTrash(ctx context.Context, deletedBefore time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", ctx, deletedBefore)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockMoviesMockRecorder) PurgeTrash(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockMovies)(nil).PurgeTrash), ctx, deletedBefore)
+}
+
+// Restore mocks base method.
+func (m *MockMovies) Restore(ctx context.Context, tmdbId uint64, userId uuid.UUID) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, tmdbId, userId)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockMoviesMockRecorder) Restore(ctx, tmdbId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockMovies)(nil).Restore), ctx, tmdbId, userId)
+}
+
+// Update mocks base method.
+func (m *MockMovies) Update(ctx context.Context, params *models.Movie) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, params)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMoviesMockRecorder) Update(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMovies)(nil).Update), ctx, params)
+}
+
+// UpdateByTmdbId mocks base method.
+func (m *MockMovies) UpdateByTmdbId(ctx context.Context, params *models.Movie) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByTmdbId", ctx, params)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateByTmdbId indicates an expected call of UpdateByTmdbId.
+func (mr *MockMoviesMockRecorder) UpdateByTmdbId(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByTmdbId", reflect.TypeOf((*MockMovies)(nil).UpdateByTmdbId), ctx, params)
+}
+
+// UpdateNotes mocks base method.
+func (m *MockMovies) UpdateNotes(ctx context.Context, tmdbId uint64, userId uuid.UUID, notes string) (*models.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotes", ctx, tmdbId, userId, notes)
+	ret0, _ := ret[0].(*models.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotes indicates an expected call of UpdateNotes.
+func (mr *MockMoviesMockRecorder) UpdateNotes(ctx, tmdbId, userId, notes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotes", reflect.TypeOf((*MockMovies)(nil).UpdateNotes), ctx, tmdbId, userId, notes)
+}
+
+// UpdateWatchProviders mocks base method.
+func (m *MockMovies) UpdateWatchProviders(ctx context.Context, ids []uuid.UUID, region string, providers []int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWatchProviders", ctx, ids, region, providers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWatchProviders indicates an expected call of UpdateWatchProviders.
+func (mr *MockMoviesMockRecorder) UpdateWatchProviders(ctx, ids, region, providers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWatchProviders", reflect.TypeOf((*MockMovies)(nil).UpdateWatchProviders), ctx, ids, region, providers)
+}
+
+// Upsert mocks base method.
+func (m *MockMovies) Upsert(ctx context.Context, params *models.Movie) (*models.MovieUpsert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, params)
+	ret0, _ := ret[0].(*models.MovieUpsert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockMoviesMockRecorder) Upsert(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockMovies)(nil).Upsert), ctx, params)
+}
@@ -0,0 +1,1061 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+// inTransaction expects times transactions, each running its callback on repository itself
+func inTransaction(repository *repositories.MockMovieRepository, times int) {
+	repository.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(repositories.MovieRepository) error) error {
+			return fn(repository)
+		},
+	).Times(times)
+}
+
+func Test_Movies_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:        "test",
+		AppAddr:       "localhost:8080",
+		LogLevel:      "info",
+		ContentConfig: config.ContentConfig{AllowAdult: true},
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	titles := NewMockTitles(ctrl)
+	service := NewMovies(cfg, repository, titles, logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	unavailable := &tmdb.UnavailableError{RetryAfter: time.Second}
+	progress := uint64(30)
+	deletedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	trashed := &models.Movie{UserId: userId, TmdbId: 27205, Title: "Inception", State: models.StateTypeWatched, DeletedAt: &deletedAt}
+
+	pinned := false
+
+	notTrashed := func() {
+		repository.EXPECT().FindTrashed(ctx, uint64(27205), userId).Return(nil, pgx.ErrNoRows)
+	}
+	recorded := func() {
+		repository.EXPECT().CreateEvent(ctx, gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name   string
+		params *models.Movie
+		before func()
+		error  error
+	}{
+		{
+			name:   "Takes the metadata from TMDB",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, Title: "Bogus", PosterPath: "/bogus.jpg", State: models.StateTypeWant},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Inception", PosterPath: "/inception.jpg", Runtime: 148}, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Create(ctx, &models.Movie{
+					UserId:     userId,
+					TmdbId:     27205,
+					Title:      "Inception",
+					PosterPath: "/inception.jpg",
+					Runtime:    148,
+					State:      models.StateTypeWant,
+				}).Return(&models.Movie{TmdbId: 27205, State: models.StateTypeWant}, nil)
+				repository.EXPECT().CreateEvent(ctx, &models.MovieEvent{
+					UserId:    userId,
+					TmdbId:    27205,
+					Action:    models.MovieEventCreated,
+					NewState:  models.StateTypeWant,
+					NewPinned: &pinned,
+					Source:    models.EventSourceApi,
+				}).Return(nil)
+			},
+		},
+		{
+			name:   "Unknown TMDB id",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, Title: "Bogus", State: models.StateTypeWant},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(nil, errors.ErrMovieNotFound)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name:   "Hides an adult title",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Adult", Adult: true}, nil)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name:   "Adds an adult title for a user who confirmed them",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant, IncludeAdult: true},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Adult", Adult: true}, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Create(ctx, &models.Movie{
+					UserId: userId,
+					TmdbId: 27205,
+					Title:  "Adult",
+					State:  models.StateTypeWant,
+				}).Return(&models.Movie{TmdbId: 27205}, nil)
+				recorded()
+			},
+		},
+		{
+			name:   "Falls back to the client metadata while TMDB is unavailable",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, Title: "Inception", PosterPath: "/inception.jpg", Runtime: 148, State: models.StateTypeWant},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(nil, unavailable)
+				inTransaction(repository, 1)
+				repository.EXPECT().Create(ctx, &models.Movie{
+					UserId:      userId,
+					TmdbId:      27205,
+					Title:       "Inception",
+					PosterPath:  "/inception.jpg",
+					Runtime:     148,
+					State:       models.StateTypeWant,
+					NeedsResync: true,
+				}).Return(&models.Movie{TmdbId: 27205}, nil)
+				recorded()
+			},
+		},
+		{
+			name: "Starts watching",
+			params: &models.Movie{
+				UserId: userId, TmdbId: 27205, State: models.StateTypeWatching,
+				ReportedProgress: &models.WatchProgress{Minutes: &progress},
+			},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Inception", Runtime: 148}, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Create(ctx, &models.Movie{
+					UserId:   userId,
+					TmdbId:   27205,
+					Title:    "Inception",
+					Runtime:  148,
+					State:    models.StateTypeWatching,
+					Progress: 30,
+				}).Return(&models.Movie{TmdbId: 27205}, nil)
+				recorded()
+			},
+		},
+		{
+			name:   "Upstream unavailable without client metadata",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(nil, unavailable)
+			},
+			error: tmdb.ErrUpstreamUnavailable,
+		},
+		{
+			name:   "Failure",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Inception"}, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Create(ctx, gomock.Any()).Return(nil, errors.ErrFailedToCreateMovie)
+			},
+			error: errors.ErrFailedToCreateMovie,
+		},
+		{
+			name:   "Already in the library",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant},
+			before: func() {
+				notTrashed()
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Inception"}, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Create(ctx, gomock.Any()).Return(nil, &pgconn.PgError{Code: "23505"})
+			},
+			error: errors.ErrMovieAlreadyExists,
+		},
+		{
+			name:   "Offers to restore a trashed title",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant},
+			before: func() {
+				repository.EXPECT().FindTrashed(ctx, uint64(27205), userId).Return(trashed, nil)
+			},
+			error: errors.ErrMovieInTrash,
+		},
+		{
+			name:   "Restores a trashed title",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant, Trashed: models.TrashedRestore},
+			before: func() {
+				repository.EXPECT().FindTrashed(ctx, uint64(27205), userId).Return(trashed, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Restore(ctx, uint64(27205), userId).Return(nil)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{TmdbId: 27205, State: models.StateTypeWatched}, nil)
+				recorded()
+			},
+		},
+		{
+			name:   "Discards a trashed title",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant, Trashed: models.TrashedDiscard},
+			before: func() {
+				repository.EXPECT().FindTrashed(ctx, uint64(27205), userId).Return(trashed, nil)
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Inception"}, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().DeleteByTmdbId(ctx, uint64(27205), userId).Return(nil)
+				repository.EXPECT().CreateEvent(ctx, &models.MovieEvent{
+					UserId:    userId,
+					TmdbId:    27205,
+					Action:    models.MovieEventPurged,
+					OldState:  models.StateTypeWatched,
+					OldPinned: &pinned,
+					Source:    models.EventSourceApi,
+				}).Return(nil)
+				repository.EXPECT().Create(ctx, &models.Movie{
+					UserId: userId,
+					TmdbId: 27205,
+					Title:  "Inception",
+					State:  models.StateTypeWant,
+				}).Return(&models.Movie{TmdbId: 27205}, nil)
+				recorded()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			_, err := service.Create(ctx, tt.params)
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Movies_Upsert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	titles := NewMockTitles(ctrl)
+	service := NewMovies(cfg, repository, titles, logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	unavailable := &tmdb.UnavailableError{RetryAfter: time.Second}
+	percent := uint64(50)
+	deletedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	inception := &models.Title{TmdbId: 27205, Title: "Inception", PosterPath: "/inception.jpg", Runtime: 148}
+
+	pinned, unpinned := true, false
+
+	tests := []struct {
+		name     string
+		params   *models.Movie
+		before   func()
+		inserted bool
+		error    error
+	}{
+		{
+			name:   "Adds a new title",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant, Pinned: true},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(inception, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Upsert(ctx, &models.Movie{
+					UserId:     userId,
+					TmdbId:     27205,
+					Title:      "Inception",
+					PosterPath: "/inception.jpg",
+					Runtime:    148,
+					State:      models.StateTypeWant,
+					Pinned:     true,
+				}).Return(&models.MovieUpsert{
+					Movie:    &models.Movie{TmdbId: 27205, State: models.StateTypeWant, Pinned: true},
+					Inserted: true,
+					Changed:  true,
+				}, nil)
+				repository.EXPECT().CreateEvent(ctx, &models.MovieEvent{
+					UserId:    userId,
+					TmdbId:    27205,
+					Action:    models.MovieEventCreated,
+					NewState:  models.StateTypeWant,
+					NewPinned: &pinned,
+					Source:    models.EventSourceApi,
+				}).Return(nil)
+			},
+			inserted: true,
+		},
+		{
+			name:   "Updates a title of the library",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWatched},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(inception, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Upsert(ctx, gomock.Any()).Return(&models.MovieUpsert{
+					Movie:    &models.Movie{TmdbId: 27205, State: models.StateTypeWatched},
+					Previous: &models.Movie{State: models.StateTypeWant, Pinned: true},
+					Changed:  true,
+				}, nil)
+				repository.EXPECT().CreateEvent(ctx, &models.MovieEvent{
+					UserId:    userId,
+					TmdbId:    27205,
+					Action:    models.MovieEventUpdated,
+					OldState:  models.StateTypeWant,
+					NewState:  models.StateTypeWatched,
+					OldPinned: &pinned,
+					NewPinned: &unpinned,
+					Source:    models.EventSourceApi,
+				}).Return(nil)
+			},
+		},
+		{
+			name:   "Leaves a title already as asked alone",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant, Pinned: true},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(inception, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Upsert(ctx, gomock.Any()).Return(&models.MovieUpsert{
+					Movie:    &models.Movie{TmdbId: 27205, State: models.StateTypeWant, Pinned: true},
+					Previous: &models.Movie{State: models.StateTypeWant, Pinned: true},
+				}, nil)
+			},
+		},
+		{
+			name:   "Restores a trashed title",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(inception, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Upsert(ctx, gomock.Any()).Return(&models.MovieUpsert{
+					Movie:    &models.Movie{TmdbId: 27205, State: models.StateTypeWant},
+					Previous: &models.Movie{State: models.StateTypeWatched, DeletedAt: &deletedAt},
+					Changed:  true,
+				}, nil)
+				repository.EXPECT().CreateEvent(ctx, &models.MovieEvent{
+					UserId:    userId,
+					TmdbId:    27205,
+					Action:    models.MovieEventRestored,
+					NewState:  models.StateTypeWant,
+					NewPinned: &unpinned,
+					Source:    models.EventSourceApi,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Reports the progress",
+			params: &models.Movie{
+				UserId: userId, TmdbId: 27205, State: models.StateTypeWatching,
+				ReportedProgress: &models.WatchProgress{Percent: &percent},
+			},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(inception, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Upsert(ctx, &models.Movie{
+					UserId:     userId,
+					TmdbId:     27205,
+					Title:      "Inception",
+					PosterPath: "/inception.jpg",
+					Runtime:    148,
+					State:      models.StateTypeWatching,
+					Progress:   74,
+
+					ReportedProgress: &models.WatchProgress{Percent: &percent},
+				}).Return(&models.MovieUpsert{
+					Movie:    &models.Movie{TmdbId: 27205, State: models.StateTypeWatching, Progress: 74},
+					Inserted: true,
+					Changed:  true,
+				}, nil)
+				repository.EXPECT().CreateEvent(ctx, gomock.Any()).Return(nil)
+			},
+			inserted: true,
+		},
+		{
+			name:   "Falls back to the client metadata while TMDB is unavailable",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, Title: "Inception", Runtime: 148, State: models.StateTypeWant},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(nil, unavailable)
+				inTransaction(repository, 1)
+				repository.EXPECT().Upsert(ctx, &models.Movie{
+					UserId:      userId,
+					TmdbId:      27205,
+					Title:       "Inception",
+					Runtime:     148,
+					State:       models.StateTypeWant,
+					NeedsResync: true,
+				}).Return(&models.MovieUpsert{
+					Movie:    &models.Movie{TmdbId: 27205, State: models.StateTypeWant},
+					Inserted: true,
+					Changed:  true,
+				}, nil)
+				repository.EXPECT().CreateEvent(ctx, gomock.Any()).Return(nil)
+			},
+			inserted: true,
+		},
+		{
+			name:   "Unknown TMDB id",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(nil, errors.ErrMovieNotFound)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name:   "Hides an adult title",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant, IncludeAdult: true},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Adult", Adult: true}, nil)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name:   "Failure",
+			params: &models.Movie{UserId: userId, TmdbId: 27205, State: models.StateTypeWant},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(27205)).Return(inception, nil)
+				inTransaction(repository, 1)
+				repository.EXPECT().Upsert(ctx, gomock.Any()).Return(nil, assert.AnError)
+			},
+			error: errors.ErrFailedToSaveMovie,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			upsert, err := service.Upsert(ctx, tt.params)
+			assert.ErrorIs(t, err, tt.error)
+			if tt.error == nil {
+				assert.Equal(t, tt.inserted, upsert.Inserted)
+			}
+		})
+	}
+}
+
+func Test_Movies_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	service := NewMovies(cfg, repository, NewMockTitles(ctrl), logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	filter := &models.MovieFilter{State: models.StateTypeWant, Sort: models.MovieSortTitle, Direction: models.SortAsc}
+	movies := []models.Movie{{TmdbId: 27205}, {TmdbId: 155}}
+	cursor := &models.MovieCursor{Sort: models.MovieSortTitle, Direction: models.SortAsc, Key: "inception", Id: uuid.New()}
+	total := uint64(3)
+
+	tests := []struct {
+		name       string
+		pagination *Pagination
+		before     func()
+		expected   *PageInfo
+		error      error
+	}{
+		{
+			name:       "Page mode counts the movies",
+			pagination: &Pagination{Page: 2, PerPage: 2},
+			before: func() {
+				repository.EXPECT().List(ctx, userId, filter, nil, uint64(2), uint64(2)).Return(movies, nil, nil)
+				repository.EXPECT().Count(ctx, userId, filter).Return(total, nil)
+			},
+			expected: &PageInfo{Total: &total},
+		},
+		{
+			name:       "First keyset page",
+			pagination: &Pagination{Page: 1, PerPage: 2, Keyset: true, SkipTotal: true},
+			before: func() {
+				repository.EXPECT().List(ctx, userId, filter, nil, uint64(2), uint64(0)).Return(movies, cursor, nil)
+			},
+			expected: &PageInfo{NextCursor: encodeMovieCursor(cursor)},
+		},
+		{
+			name:       "Next keyset page",
+			pagination: &Pagination{Page: 1, PerPage: 2, Keyset: true, Cursor: encodeMovieCursor(cursor), SkipTotal: true},
+			before: func() {
+				repository.EXPECT().List(ctx, userId, filter, cursor, uint64(2), uint64(0)).Return(movies[:1], nil, nil)
+			},
+			expected: &PageInfo{},
+		},
+		{
+			name:       "Malformed cursor",
+			pagination: &Pagination{Page: 1, PerPage: 2, Keyset: true, Cursor: "not a cursor"},
+			before:     func() {},
+			error:      errors.ErrInvalidCursor,
+		},
+		{
+			name: "Cursor of another sort",
+			pagination: &Pagination{Page: 1, PerPage: 2, Keyset: true, Cursor: encodeMovieCursor(&models.MovieCursor{
+				Sort: models.MovieSortRuntime, Direction: models.SortAsc, Key: "148", Id: uuid.New(),
+			})},
+			before: func() {},
+			error:  errors.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			collection, info, err := service.List(ctx, userId, filter, tt.pagination)
+
+			assert.ErrorIs(t, err, tt.error)
+			assert.Equal(t, tt.expected, info)
+			if tt.error == nil {
+				assert.NotEmpty(t, collection)
+			}
+		})
+	}
+}
+
+func Test_Movies_Move(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	service := NewMovies(cfg, repository, NewMockTitles(ctrl), logger.NewLogger(cfg))
+
+	userId := uuid.New()
+
+	tests := []struct {
+		name   string
+		anchor uint64
+		before func()
+		error  error
+	}{
+		{
+			name:   "Success",
+			anchor: 155,
+			before: func() {
+				repository.EXPECT().Move(ctx, userId, uint64(27205), uint64(155), true).Return(nil)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{TmdbId: 27205}, nil)
+			},
+		},
+		{
+			name:   "Next to itself",
+			anchor: 27205,
+			before: func() {},
+			error:  errors.ErrInvalidMove,
+		},
+		{
+			name:   "Unknown movie",
+			anchor: 155,
+			before: func() {
+				repository.EXPECT().Move(ctx, userId, uint64(27205), uint64(155), true).Return(pgx.ErrNoRows)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name:   "Failure",
+			anchor: 155,
+			before: func() {
+				repository.EXPECT().Move(ctx, userId, uint64(27205), uint64(155), true).Return(assert.AnError)
+			},
+			error: errors.ErrFailedToMoveMovie,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			movie, err := service.Move(ctx, userId, 27205, tt.anchor, true)
+
+			assert.ErrorIs(t, err, tt.error)
+			if tt.error == nil {
+				assert.Equal(t, uint64(27205), movie.TmdbId)
+			}
+		})
+	}
+}
+
+func Test_Movies_UpdateByTmdbId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:        "test",
+		AppAddr:       "localhost:8080",
+		LogLevel:      "info",
+		LibraryConfig: config.LibraryConfig{WatchedThreshold: 90},
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	service := NewMovies(cfg, repository, NewMockTitles(ctrl), logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	minutes := func(value uint64) *models.WatchProgress { return &models.WatchProgress{Minutes: &value} }
+	percent := func(value uint64) *models.WatchProgress { return &models.WatchProgress{Percent: &value} }
+	current := func(runtime, progress uint64) {
+		repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{TmdbId: 27205, Runtime: runtime, Progress: progress}, nil)
+	}
+	unpinned := false
+
+	tests := []struct {
+		name     string
+		state    string
+		progress *models.WatchProgress
+		before   func()
+		expected *models.Movie
+		error    error
+	}{
+		{
+			name:     "Drops the progress outside watching",
+			state:    models.StateTypeWant,
+			before:   func() { current(148, 10) },
+			expected: &models.Movie{TmdbId: 27205, UserId: userId, State: models.StateTypeWant},
+		},
+		{
+			name:     "Stores minutes",
+			state:    models.StateTypeWatching,
+			progress: minutes(42),
+			before:   func() { current(148, 10) },
+			expected: &models.Movie{TmdbId: 27205, UserId: userId, State: models.StateTypeWatching, Runtime: 148, Progress: 42},
+		},
+		{
+			name:     "Converts a percentage of the runtime",
+			state:    models.StateTypeWatching,
+			progress: percent(50),
+			before:   func() { current(148, 10) },
+			expected: &models.Movie{TmdbId: 27205, UserId: userId, State: models.StateTypeWatching, Runtime: 148, Progress: 74},
+		},
+		{
+			name:     "Keeps the current progress",
+			state:    models.StateTypeWatching,
+			before:   func() { current(148, 10) },
+			expected: &models.Movie{TmdbId: 27205, UserId: userId, State: models.StateTypeWatching, Runtime: 148, Progress: 10},
+		},
+		{
+			name:     "Switches to watched past the threshold",
+			state:    models.StateTypeWatching,
+			progress: minutes(140),
+			before:   func() { current(148, 10) },
+			expected: &models.Movie{TmdbId: 27205, UserId: userId, State: models.StateTypeWatched, Runtime: 148},
+		},
+		{
+			name:     "Percentage without runtime",
+			state:    models.StateTypeWatching,
+			progress: percent(50),
+			before:   func() { current(0, 0) },
+			error:    errors.ErrInvalidProgress,
+		},
+		{
+			name:     "Unknown movie",
+			state:    models.StateTypeWatching,
+			progress: minutes(42),
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(nil, pgx.ErrNoRows)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTransaction(repository, 1)
+			tt.before()
+			if tt.expected != nil {
+				repository.EXPECT().UpdateByTmdbId(ctx, tt.expected).Return(tt.expected, nil)
+				repository.EXPECT().CreateEvent(ctx, &models.MovieEvent{
+					UserId:    userId,
+					TmdbId:    27205,
+					Action:    models.MovieEventUpdated,
+					NewState:  tt.expected.State,
+					OldPinned: &unpinned,
+					NewPinned: &unpinned,
+					Source:    models.EventSourceApi,
+				}).Return(nil)
+			}
+
+			_, err := service.UpdateByTmdbId(ctx, &models.Movie{
+				TmdbId:           27205,
+				UserId:           userId,
+				State:            tt.state,
+				ReportedProgress: tt.progress,
+			})
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Movies_Bulk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	titles := NewMockTitles(ctrl)
+	service := NewMovies(cfg, repository, titles, logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	movieId := uuid.New()
+
+	// NOTE: the bulk runs in a transaction and every operation attempted in a savepoint of its own, changes
+	// then record their event within one more
+	transaction := func(times int) {
+		inTransaction(repository, times)
+	}
+
+	tests := []struct {
+		name       string
+		operations []models.BulkOperation
+		atomic     bool
+		before     func()
+		statuses   []string
+		errors     []error
+		error      error
+	}{
+		{
+			name: "Keeps valid operations",
+			operations: []models.BulkOperation{
+				{Op: models.BulkOpPin, TmdbId: 27205},
+				{Op: models.BulkOpDelete, TmdbId: 155},
+				{Op: models.BulkOpState, TmdbId: 603, Err: errors.ErrInvalidState},
+			},
+			before: func() {
+				transaction(4)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{TmdbId: 27205, State: models.StateTypeWant}, nil).Times(2)
+				repository.EXPECT().UpdateByTmdbId(ctx, &models.Movie{TmdbId: 27205, UserId: userId, State: models.StateTypeWant, Pinned: true}).Return(&models.Movie{TmdbId: 27205}, nil)
+				repository.EXPECT().CreateEvent(ctx, gomock.Any()).Return(nil)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(155), userId).Return(nil, pgx.ErrNoRows)
+			},
+			statuses: []string{models.BulkStatusApplied, models.BulkStatusFailed, models.BulkStatusFailed},
+			errors:   []error{nil, errors.ErrMovieNotFound, errors.ErrInvalidState},
+		},
+		{
+			name: "Atomic rolls back on the first failure",
+			operations: []models.BulkOperation{
+				{Op: models.BulkOpDelete, TmdbId: 27205},
+				{Op: models.BulkOpAdd, TmdbId: 155, State: models.StateTypeWant},
+				{Op: models.BulkOpUnpin, TmdbId: 603},
+			},
+			atomic: true,
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(155)).Return(&models.Title{TmdbId: 155, Title: "The Dark Knight"}, nil)
+				transaction(4)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{TmdbId: 27205}, nil).Times(2)
+				repository.EXPECT().Trash(ctx, uint64(27205), userId).Return(nil)
+				repository.EXPECT().CreateEvent(ctx, gomock.Any()).Return(nil)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(155), userId).Return(&models.Movie{TmdbId: 155}, nil)
+			},
+			statuses: []string{models.BulkStatusRolledBack, models.BulkStatusFailed, models.BulkStatusSkipped},
+			errors:   []error{nil, errors.ErrMovieAlreadyExists, nil},
+			error:    errors.ErrBulkAborted,
+		},
+		{
+			name: "Adds titles resolved before the transaction",
+			operations: []models.BulkOperation{
+				{Op: models.BulkOpAdd, TmdbId: 155, State: models.StateTypeWant},
+			},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(155)).Return(&models.Title{TmdbId: 155, Title: "The Dark Knight", Runtime: 152}, nil)
+				transaction(3)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(155), userId).Return(nil, pgx.ErrNoRows)
+				repository.EXPECT().FindTrashed(ctx, uint64(155), userId).Return(nil, pgx.ErrNoRows)
+				repository.EXPECT().Create(ctx, &models.Movie{
+					UserId:  userId,
+					TmdbId:  155,
+					Title:   "The Dark Knight",
+					Runtime: 152,
+					State:   models.StateTypeWant,
+				}).Return(&models.Movie{TmdbId: 155, State: models.StateTypeWant}, nil)
+				repository.EXPECT().CreateEvent(ctx, gomock.Any()).Return(nil)
+			},
+			statuses: []string{models.BulkStatusApplied},
+			errors:   []error{nil},
+		},
+		{
+			name: "Hides adult titles",
+			operations: []models.BulkOperation{
+				{Op: models.BulkOpAdd, TmdbId: 155, State: models.StateTypeWant},
+			},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(155)).Return(&models.Title{TmdbId: 155, Title: "Adult", Adult: true}, nil)
+				transaction(1)
+			},
+			statuses: []string{models.BulkStatusFailed},
+			errors:   []error{errors.ErrMovieNotFound},
+		},
+		{
+			name: "Reports TMDB being unavailable per operation",
+			operations: []models.BulkOperation{
+				{Op: models.BulkOpAdd, TmdbId: 155, State: models.StateTypeWant},
+				{Op: models.BulkOpPin, TmdbId: 27205},
+			},
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(155)).Return(nil, tmdb.ErrUpstreamUnavailable)
+				transaction(3)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{TmdbId: 27205, State: models.StateTypeWant}, nil).Times(2)
+				repository.EXPECT().UpdateByTmdbId(ctx, &models.Movie{TmdbId: 27205, UserId: userId, State: models.StateTypeWant, Pinned: true}).Return(&models.Movie{TmdbId: 27205}, nil)
+				repository.EXPECT().CreateEvent(ctx, gomock.Any()).Return(nil)
+			},
+			statuses: []string{models.BulkStatusFailed, models.BulkStatusApplied},
+			errors:   []error{tmdb.ErrUpstreamUnavailable, nil},
+		},
+		{
+			name: "Atomic fails as a whole while TMDB is unavailable",
+			operations: []models.BulkOperation{
+				{Op: models.BulkOpPin, TmdbId: 27205},
+				{Op: models.BulkOpAdd, TmdbId: 155, State: models.StateTypeWant},
+			},
+			atomic: true,
+			before: func() {
+				titles.EXPECT().Resolve(ctx, uint64(155)).Return(nil, &tmdb.UnavailableError{RetryAfter: time.Minute})
+			},
+			error: tmdb.ErrUpstreamUnavailable,
+		},
+		{
+			name: "Tags within the limit",
+			operations: []models.BulkOperation{
+				{Op: models.BulkOpTag, TmdbId: 27205, Tags: []string{"Cozy", "rewatch"}},
+			},
+			before: func() {
+				transaction(2)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{ID: movieId, UserId: userId, TmdbId: 27205, Tags: []string{"cozy"}}, nil)
+				repository.EXPECT().AddTags(ctx, movieId, userId, []string{"Cozy", "rewatch"}).Return(nil)
+			},
+			statuses: []string{models.BulkStatusApplied},
+			errors:   []error{nil},
+		},
+		{
+			name: "Failure",
+			operations: []models.BulkOperation{
+				{Op: models.BulkOpPin, TmdbId: 27205},
+			},
+			before: func() {
+				repository.EXPECT().Transaction(ctx, gomock.Any()).Return(assert.AnError)
+			},
+			error: errors.ErrFailedToBulkUpdate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			results, err := service.Bulk(ctx, &models.User{ID: userId}, tt.operations, tt.atomic)
+
+			assert.ErrorIs(t, err, tt.error)
+			assert.Len(t, results, len(tt.statuses))
+			for i, result := range results {
+				assert.Equal(t, tt.statuses[i], result.Status)
+				assert.ErrorIs(t, result.Err, tt.errors[i])
+			}
+		})
+	}
+}
+
+func Test_Movies_DeleteByTmdbId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	service := NewMovies(cfg, repository, NewMockTitles(ctrl), logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	pinned := true
+	current := func() {
+		repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{TmdbId: 27205, State: models.StateTypeWatched, Pinned: pinned}, nil)
+	}
+
+	tests := []struct {
+		name   string
+		before func()
+		error  error
+	}{
+		{
+			name: "Moves the movie to the trash",
+			before: func() {
+				current()
+				repository.EXPECT().Trash(ctx, uint64(27205), userId).Return(nil)
+				repository.EXPECT().CreateEvent(ctx, &models.MovieEvent{
+					UserId:    userId,
+					TmdbId:    27205,
+					Action:    models.MovieEventDeleted,
+					OldState:  models.StateTypeWatched,
+					OldPinned: &pinned,
+					Source:    models.EventSourceApi,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Not in the library",
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(nil, pgx.ErrNoRows)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name: "Failure",
+			before: func() {
+				current()
+				repository.EXPECT().Trash(ctx, uint64(27205), userId).Return(assert.AnError)
+			},
+			error: errors.ErrFailedToDeleteMovie,
+		},
+		{
+			name: "History failure",
+			before: func() {
+				current()
+				repository.EXPECT().Trash(ctx, uint64(27205), userId).Return(nil)
+				repository.EXPECT().CreateEvent(ctx, gomock.Any()).Return(assert.AnError)
+			},
+			error: errors.ErrFailedToRecordEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTransaction(repository, 1)
+			tt.before()
+
+			err := service.DeleteByTmdbId(ctx, 27205, userId)
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Movies_ListTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+		LibraryConfig: config.LibraryConfig{
+			TrashRetention: 7 * 24 * time.Hour,
+		},
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	service := NewMovies(cfg, repository, NewMockTitles(ctrl), logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	deletedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	pagination := &Pagination{Page: 2, PerPage: 10}
+
+	repository.EXPECT().ListTrashed(ctx, userId, uint64(10), uint64(10)).Return([]models.Movie{{TmdbId: 27205, DeletedAt: &deletedAt}}, nil)
+	repository.EXPECT().CountTrashed(ctx, userId).Return(uint64(11), nil)
+
+	collection, info, err := service.ListTrash(ctx, userId, pagination)
+
+	assert.NoError(t, err)
+	assert.Len(t, collection, 1)
+	assert.Equal(t, deletedAt.Add(7*24*time.Hour), *collection[0].PurgeAt)
+	assert.Equal(t, uint64(11), *info.Total)
+
+	_, _, err = service.ListTrash(ctx, userId, &Pagination{Page: 1, PerPage: 10, Keyset: true})
+	assert.ErrorIs(t, err, errors.ErrCursorNotSupported)
+}
+
+func Test_Movies_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockMovieRepository(ctrl)
+	service := NewMovies(cfg, repository, NewMockTitles(ctrl), logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	pinned := true
+
+	tests := []struct {
+		name   string
+		before func()
+		error  error
+	}{
+		{
+			name: "Brings the movie back",
+			before: func() {
+				repository.EXPECT().Restore(ctx, uint64(27205), userId).Return(nil)
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205), userId).Return(&models.Movie{TmdbId: 27205, State: models.StateTypeWant, Pinned: true}, nil)
+				repository.EXPECT().CreateEvent(ctx, &models.MovieEvent{
+					UserId:    userId,
+					TmdbId:    27205,
+					Action:    models.MovieEventRestored,
+					NewState:  models.StateTypeWant,
+					NewPinned: &pinned,
+					Source:    models.EventSourceApi,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Not in the trash",
+			before: func() {
+				repository.EXPECT().Restore(ctx, uint64(27205), userId).Return(pgx.ErrNoRows)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name: "Failure",
+			before: func() {
+				repository.EXPECT().Restore(ctx, uint64(27205), userId).Return(assert.AnError)
+			},
+			error: errors.ErrFailedToRestoreMovie,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTransaction(repository, 1)
+			tt.before()
+
+			_, err := service.Restore(ctx, 27205, userId)
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
@@ -1,19 +1,40 @@
 package services
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"strconv"
+
+	"github.com/google/uuid"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
 )
 
 const (
 	DefaultPage    uint64 = 1
 	DefaultPerPage uint64 = 24
 	MaxPerPage     uint64 = 10000
+
+	// MaxCursorPerPage caps keyset pages, clients walk large lists with next cursors instead
+	MaxCursorPerPage uint64 = 100
 )
 
+// Pagination selects a page by number, or with Keyset after the row encoded in Cursor. An empty
+// Cursor starts from the first row. SkipTotal leaves out the count, the default for keyset pages
 type Pagination struct {
-	Page    uint64
-	PerPage uint64
+	Page      uint64
+	PerPage   uint64
+	Keyset    bool
+	Cursor    string
+	SkipTotal bool
+}
+
+// PageInfo describes a fetched page, NextCursor is empty on the last one
+type PageInfo struct {
+	Total      *uint64
+	NextCursor string
 }
 
 func NewPagination(r *http.Request) *Pagination {
@@ -32,10 +53,28 @@ func NewPagination(r *http.Request) *Pagination {
 		per = MaxPerPage
 	}
 
-	return &Pagination{
+	pagination := &Pagination{
 		Page:    page,
 		PerPage: per,
 	}
+
+	query := r.URL.Query()
+	if query.Has("cursor") {
+		pagination.Page = DefaultPage
+		pagination.PerPage = min(per, MaxCursorPerPage)
+		pagination.Keyset = true
+		pagination.Cursor = query.Get("cursor")
+		pagination.SkipTotal = true
+	}
+
+	if param := query.Get("total"); param != "" {
+		withTotal, err := strconv.ParseBool(param)
+		if err == nil {
+			pagination.SkipTotal = !withTotal
+		}
+	}
+
+	return pagination
 }
 
 func parseQueryParam(r *http.Request, key string, defaultValue uint64) uint64 {
@@ -57,5 +96,44 @@ func (p *Pagination) Limit() uint64 {
 }
 
 func (p *Pagination) Offset() uint64 {
+	if p.Keyset {
+		return 0
+	}
+
 	return (p.Page - 1) * p.PerPage
 }
+
+// movieCursor is the wire form of models.MovieCursor, kept short as it travels in URLs
+type movieCursor struct {
+	Sort      string    `json:"s"`
+	Direction string    `json:"d"`
+	Pinned    bool      `json:"p"`
+	Key       string    `json:"k"`
+	Id        uuid.UUID `json:"i"`
+}
+
+// encodeMovieCursor returns the opaque form of cursor handed out to clients
+func encodeMovieCursor(cursor *models.MovieCursor) string {
+	data, _ := json.Marshal(movieCursor(*cursor))
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeMovieCursor(value string) (*models.MovieCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor movieCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	if cursor.Id == uuid.Nil {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	result := models.MovieCursor(cursor)
+	return &result, nil
+}
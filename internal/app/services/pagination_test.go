@@ -53,6 +53,35 @@ func Test_NewPagination(t *testing.T) {
 				PerPage: MaxPerPage,
 			},
 		},
+		{
+			name: "First keyset page",
+			path: "/?cursor=&per=20",
+			expected: &Pagination{
+				Page:      DefaultPage,
+				PerPage:   20,
+				Keyset:    true,
+				SkipTotal: true,
+			},
+		},
+		{
+			name: "Keyset page with total",
+			path: "/?cursor=abc&page=3&per=500&total=true",
+			expected: &Pagination{
+				Page:    DefaultPage,
+				PerPage: MaxCursorPerPage,
+				Keyset:  true,
+				Cursor:  "abc",
+			},
+		},
+		{
+			name: "Page without total",
+			path: "/?page=2&total=false",
+			expected: &Pagination{
+				Page:      2,
+				PerPage:   DefaultPerPage,
+				SkipTotal: true,
+			},
+		},
 		{
 			name: "Invalid number format",
 			path: "/?page=abc&per=xyz",
@@ -109,6 +138,11 @@ func Test_Pagination_Offset(t *testing.T) {
 			path:     "/?page=abc&per=xyz",
 			expected: 0,
 		},
+		{
+			name:     "Keyset ignores page",
+			path:     "/?cursor=abc&page=10&per=20",
+			expected: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config/logger"
+)
+
+type Tags interface {
+	List(ctx context.Context, userId uuid.UUID) ([]models.Tag, error)
+	Create(ctx context.Context, userId uuid.UUID, name string) (*models.Tag, error)
+	Rename(ctx context.Context, id, userId uuid.UUID, name string) (*models.Tag, error)
+	Delete(ctx context.Context, id, userId uuid.UUID) error
+	SetMovieTags(ctx context.Context, userId uuid.UUID, tmdbId uint64, names []string) error
+}
+
+type tags struct {
+	repository repositories.TagRepository
+	log        *logger.Logger
+}
+
+func NewTags(repository repositories.TagRepository, log *logger.Logger) Tags {
+	return &tags{
+		repository: repository,
+		log:        log.WithComponent("TagsService"),
+	}
+}
+
+func (t *tags) List(ctx context.Context, userId uuid.UUID) ([]models.Tag, error) {
+	collection, err := t.repository.List(ctx, userId)
+	if err != nil {
+		t.log.Error().Err(err).Msg("Failed to fetch tags")
+		return nil, errors.ErrFailedToFetchTags
+	}
+
+	return collection, nil
+}
+
+func (t *tags) Create(ctx context.Context, userId uuid.UUID, name string) (*models.Tag, error) {
+	existing, err := t.repository.FindByName(ctx, userId, name)
+	if err == nil && existing != nil {
+		return nil, errors.ErrTagAlreadyExists
+	}
+
+	tag, err := t.repository.Create(ctx, &models.Tag{UserId: userId, Name: name})
+	if err != nil {
+		t.log.Error().Err(err).Msg("Failed to create tag")
+		return nil, errors.ErrFailedToSaveTag
+	}
+
+	return tag, nil
+}
+
+// Rename changes the name of a tag, changing only its case is allowed
+func (t *tags) Rename(ctx context.Context, id, userId uuid.UUID, name string) (*models.Tag, error) {
+	existing, err := t.repository.FindByName(ctx, userId, name)
+	if err == nil && existing != nil && existing.ID != id {
+		return nil, errors.ErrTagAlreadyExists
+	}
+
+	tag, err := t.repository.Rename(ctx, &models.Tag{ID: id, UserId: userId, Name: name})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.ErrTagNotFound
+	}
+	if err != nil {
+		t.log.Error().Err(err).Msg("Failed to rename tag")
+		return nil, errors.ErrFailedToSaveTag
+	}
+
+	return tag, nil
+}
+
+func (t *tags) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	err := t.repository.Delete(ctx, id, userId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.ErrTagNotFound
+	}
+	if err != nil {
+		t.log.Error().Err(err).Msg("Failed to delete tag")
+		return errors.ErrFailedToDeleteTag
+	}
+
+	return nil
+}
+
+// SetMovieTags replaces the tags of a movie of the library, creating the tags the user has not used yet
+func (t *tags) SetMovieTags(ctx context.Context, userId uuid.UUID, tmdbId uint64, names []string) error {
+	if len(names) > models.MaxMovieTags {
+		return errors.ErrTooManyTags
+	}
+
+	err := t.repository.SetMovieTags(ctx, userId, tmdbId, names)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.ErrMovieNotFound
+	}
+	if err != nil {
+		t.log.Error().Err(err).Msg("Failed to tag movie")
+		return errors.ErrFailedToTagMovie
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_Tags_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockTagRepository(ctrl)
+	service := NewTags(repository, logger.NewLogger(cfg))
+
+	userId := uuid.New()
+
+	tests := []struct {
+		name   string
+		before func()
+		error  error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				repository.EXPECT().FindByName(ctx, userId, "Cozy").Return(nil, pgx.ErrNoRows)
+				repository.EXPECT().Create(ctx, &models.Tag{UserId: userId, Name: "Cozy"}).Return(&models.Tag{Name: "Cozy"}, nil)
+			},
+		},
+		{
+			name: "Name taken regardless of case",
+			before: func() {
+				repository.EXPECT().FindByName(ctx, userId, "Cozy").Return(&models.Tag{ID: uuid.New(), Name: "cozy"}, nil)
+			},
+			error: errors.ErrTagAlreadyExists,
+		},
+		{
+			name: "Failure",
+			before: func() {
+				repository.EXPECT().FindByName(ctx, userId, "Cozy").Return(nil, pgx.ErrNoRows)
+				repository.EXPECT().Create(ctx, &models.Tag{UserId: userId, Name: "Cozy"}).Return(nil, assert.AnError)
+			},
+			error: errors.ErrFailedToSaveTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			tag, err := service.Create(ctx, userId, "Cozy")
+
+			assert.ErrorIs(t, err, tt.error)
+			if tt.error == nil {
+				assert.Equal(t, "Cozy", tag.Name)
+			}
+		})
+	}
+}
+
+func Test_Tags_Rename(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockTagRepository(ctrl)
+	service := NewTags(repository, logger.NewLogger(cfg))
+
+	userId := uuid.New()
+	id := uuid.New()
+
+	tests := []struct {
+		name   string
+		before func()
+		error  error
+	}{
+		{
+			name: "Changes the case of its own name",
+			before: func() {
+				repository.EXPECT().FindByName(ctx, userId, "Cozy").Return(&models.Tag{ID: id, Name: "cozy"}, nil)
+				repository.EXPECT().Rename(ctx, &models.Tag{ID: id, UserId: userId, Name: "Cozy"}).Return(&models.Tag{ID: id, Name: "Cozy"}, nil)
+			},
+		},
+		{
+			name: "Name taken by another tag",
+			before: func() {
+				repository.EXPECT().FindByName(ctx, userId, "Cozy").Return(&models.Tag{ID: uuid.New(), Name: "cozy"}, nil)
+			},
+			error: errors.ErrTagAlreadyExists,
+		},
+		{
+			name: "Unknown tag",
+			before: func() {
+				repository.EXPECT().FindByName(ctx, userId, "Cozy").Return(nil, pgx.ErrNoRows)
+				repository.EXPECT().Rename(ctx, &models.Tag{ID: id, UserId: userId, Name: "Cozy"}).Return(nil, pgx.ErrNoRows)
+			},
+			error: errors.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			tag, err := service.Rename(ctx, id, userId, "Cozy")
+
+			assert.ErrorIs(t, err, tt.error)
+			if tt.error == nil {
+				assert.Equal(t, "Cozy", tag.Name)
+			}
+		})
+	}
+}
+
+func Test_Tags_SetMovieTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockTagRepository(ctrl)
+	service := NewTags(repository, logger.NewLogger(cfg))
+
+	userId := uuid.New()
+
+	tests := []struct {
+		name   string
+		names  []string
+		before func()
+		error  error
+	}{
+		{
+			name:  "Success",
+			names: []string{"Cozy", "Rewatch"},
+			before: func() {
+				repository.EXPECT().SetMovieTags(ctx, userId, uint64(27205), []string{"Cozy", "Rewatch"}).Return(nil)
+			},
+		},
+		{
+			name:   "Too many tags",
+			names:  make([]string, models.MaxMovieTags+1),
+			before: func() {},
+			error:  errors.ErrTooManyTags,
+		},
+		{
+			name:  "Unknown movie",
+			names: []string{"Cozy"},
+			before: func() {
+				repository.EXPECT().SetMovieTags(ctx, userId, uint64(27205), []string{"Cozy"}).Return(pgx.ErrNoRows)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name:  "Failure",
+			names: []string{"Cozy"},
+			before: func() {
+				repository.EXPECT().SetMovieTags(ctx, userId, uint64(27205), []string{"Cozy"}).Return(assert.AnError)
+			},
+			error: errors.ErrFailedToTagMovie,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			err := service.SetMovieTags(ctx, userId, 27205, tt.names)
+
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+// DefaultTitlesMaxAge is how long catalog metadata is trusted before it is fetched again,
+// titles changed on TMDB are invalidated earlier by the title changes job
+const DefaultTitlesMaxAge = 30 * 24 * time.Hour
+
+type Titles interface {
+	Resolve(ctx context.Context, tmdbId uint64) (*models.Title, error)
+	Refresh(ctx context.Context, tmdbId uint64) error
+	Invalidate(ctx context.Context, tmdbIds []uint64) (uint64, error)
+	ChangesCursor(ctx context.Context, feed string) (time.Time, error)
+	SaveChangesCursor(ctx context.Context, feed string, syncedUntil time.Time) error
+	FindStaleIds(ctx context.Context, fetchedBefore time.Time, limit uint64) ([]uint64, error)
+}
+
+type titles struct {
+	repository repositories.TitleRepository
+	client     tmdb.Client
+	locale     tmdb.Locale
+	maxAge     time.Duration
+	now        func() time.Time
+	log        *logger.Logger
+}
+
+func NewTitles(cfg *config.Config, repository repositories.TitleRepository, client tmdb.Client, log *logger.Logger) Titles {
+	maxAge := cfg.JobsConfig.TitlesMaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultTitlesMaxAge
+	}
+
+	locale, ok := tmdb.ParseLocale(cfg.TMDBConfig.Locale)
+	if !ok {
+		locale = tmdb.DefaultLocale
+	}
+
+	return &titles{
+		repository: repository,
+		client:     client,
+		locale:     locale,
+		maxAge:     maxAge,
+		now:        time.Now,
+		log:        log.WithComponent("TitlesService"),
+	}
+}
+
+// Resolve returns the catalog entry of a movie, fetching it from TMDB when it is missing or stale.
+// A movie unknown to TMDB is reported as ErrMovieNotFound. On other TMDB failures a stale entry
+// is returned as is, without an entry the failure is returned
+func (t *titles) Resolve(ctx context.Context, tmdbId uint64) (*models.Title, error) {
+	cached, err := t.repository.FindByTmdbId(ctx, tmdbId)
+	if err != nil {
+		cached = nil
+	}
+	if cached != nil && cached.FetchedAt != nil && cached.FetchedAt.After(t.now().Add(-t.maxAge)) {
+		return cached, nil
+	}
+
+	response, err := t.client.FetchMovieDetails(t.catalogContext(ctx), tmdbId)
+	switch {
+	case errors.Is(err, tmdb.ErrNotFound):
+		return nil, errors.ErrMovieNotFound
+	case err != nil && cached != nil:
+		t.log.Warn().Err(err).Uint64("TmdbId", tmdbId).Msg("Failed to fetch title, serving the stale catalog entry")
+		return cached, nil
+	case err != nil:
+		return nil, err
+	}
+
+	title := transformTitle(tmdbId, response)
+	if err := t.repository.Refresh(ctx, title); err != nil {
+		t.log.Error().Err(err).Uint64("TmdbId", tmdbId).Msg("Failed to store title")
+		return nil, errors.ErrFailedToRefreshTitle
+	}
+
+	return title, nil
+}
+
+// Refresh re-fetches a title from TMDB and propagates its metadata to the library rows.
+// Rows of a title deleted on TMDB keep the last metadata and are flagged, rows of a merged
+// title are re-pointed to the id it was merged into
+func (t *titles) Refresh(ctx context.Context, tmdbId uint64) error {
+	response, err := t.client.FetchMovieSummary(t.catalogContext(ctx), tmdbId)
+	switch {
+	case errors.Is(err, tmdb.ErrUpstreamUnavailable), errors.Is(err, context.Canceled):
+		return err
+	case errors.Is(err, tmdb.ErrNotFound):
+		if err := t.repository.MarkMissing(ctx, tmdbId, EventSourceFromContext(ctx)); err != nil {
+			t.log.Error().Err(err).Uint64("TmdbId", tmdbId).Msg("Failed to flag title missing on TMDB")
+			return errors.ErrFailedToRefreshTitle
+		}
+		return nil
+	case err != nil:
+		t.log.Error().Err(err).Uint64("TmdbId", tmdbId).Msg("Failed to fetch title")
+		return errors.ErrFailedToRefreshTitle
+	}
+
+	title := transformTitle(tmdbId, response)
+
+	// NOTE: TMDB answers a merged id with the movie it was merged into
+	if title.TmdbId != tmdbId {
+		moved, err := t.repository.Merge(ctx, tmdbId, title.TmdbId, EventSourceFromContext(ctx))
+		if err != nil {
+			t.log.Error().Err(err).Uint64("TmdbId", tmdbId).Uint64("MergedInto", title.TmdbId).Msg("Failed to merge title")
+			return errors.ErrFailedToRefreshTitle
+		}
+
+		t.log.Info().
+			Uint64("TmdbId", tmdbId).
+			Uint64("MergedInto", title.TmdbId).
+			Uint64("movies", moved).
+			Msg("Re-pointed movies of merged title")
+	}
+
+	if err := t.repository.Refresh(ctx, title); err != nil {
+		t.log.Error().Err(err).Uint64("TmdbId", tmdbId).Msg("Failed to refresh title")
+		return errors.ErrFailedToRefreshTitle
+	}
+
+	return nil
+}
+
+// Invalidate marks the changed titles held in libraries for refresh and returns how many were held
+func (t *titles) Invalidate(ctx context.Context, tmdbIds []uint64) (uint64, error) {
+	count, err := t.repository.Invalidate(ctx, tmdbIds)
+	if err != nil {
+		t.log.Error().Err(err).Msg("Failed to invalidate titles")
+		return 0, errors.ErrFailedToRefreshTitle
+	}
+
+	return count, nil
+}
+
+func (t *titles) ChangesCursor(ctx context.Context, feed string) (time.Time, error) {
+	cursor, err := t.repository.FindChangesCursor(ctx, feed)
+	if err != nil {
+		t.log.Error().Err(err).Str("feed", feed).Msg("Failed to fetch changes cursor")
+		return time.Time{}, errors.ErrFailedToFetchTitles
+	}
+
+	return cursor, nil
+}
+
+func (t *titles) SaveChangesCursor(ctx context.Context, feed string, syncedUntil time.Time) error {
+	if err := t.repository.SaveChangesCursor(ctx, feed, syncedUntil); err != nil {
+		t.log.Error().Err(err).Str("feed", feed).Msg("Failed to save changes cursor")
+		return errors.ErrFailedToRefreshTitle
+	}
+
+	return nil
+}
+
+func (t *titles) FindStaleIds(ctx context.Context, fetchedBefore time.Time, limit uint64) ([]uint64, error) {
+	ids, err := t.repository.FindStaleIds(ctx, fetchedBefore, limit)
+	if err != nil {
+		t.log.Error().Err(err).Msg("Failed to fetch stale titles")
+		return nil, errors.ErrFailedToFetchTitles
+	}
+
+	return ids, nil
+}
+
+// catalogContext pins the TMDB locale to the server one, the catalog is shared by every user
+// and must not store the metadata localized for whoever happened to add a title first
+func (t *titles) catalogContext(ctx context.Context) context.Context {
+	return tmdb.WithLocale(ctx, t.locale)
+}
+
+func transformTitle(tmdbId uint64, movie *tmdb.MovieDetails) *models.Title {
+	genreIds := make([]int, 0, len(movie.Genres))
+	for _, genre := range movie.Genres {
+		genreIds = append(genreIds, genre.Id)
+	}
+
+	var runtime uint64
+	if movie.Runtime > 0 {
+		runtime = uint64(movie.Runtime)
+	}
+
+	// NOTE: a merged id resolves to the movie it was merged into
+	if movie.Id > 0 {
+		tmdbId = uint64(movie.Id)
+	}
+
+	return &models.Title{
+		TmdbId:        tmdbId,
+		Title:         movie.Title,
+		OriginalTitle: movie.OriginalTitle,
+		PosterPath:    movie.PosterPath,
+		BackdropPath:  movie.BackdropPath,
+		ReleaseDate:   movie.ReleaseDate,
+		Runtime:       runtime,
+		GenreIds:      genreIds,
+		Adult:         movie.Adult,
+	}
+}
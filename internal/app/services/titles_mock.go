@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/services/titles.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/app/services/titles.go -destination=internal/app/services/titles_mock.go -package=services
+//
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	models "biinge-api/internal/app/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTitles is a mock of Titles interface.
+type MockTitles struct {
+	ctrl     *gomock.Controller
+	recorder *MockTitlesMockRecorder
+	isgomock struct{}
+}
+
+// MockTitlesMockRecorder is the mock recorder for MockTitles.
+type MockTitlesMockRecorder struct {
+	mock *MockTitles
+}
+
+// NewMockTitles creates a new mock instance.
+func NewMockTitles(ctrl *gomock.Controller) *MockTitles {
+	mock := &MockTitles{ctrl: ctrl}
+	mock.recorder = &MockTitlesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTitles) EXPECT() *MockTitlesMockRecorder {
+	return m.recorder
+}
+
+// ChangesCursor mocks base method.
+func (m *MockTitles) ChangesCursor(ctx context.Context, feed string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangesCursor", ctx, feed)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangesCursor indicates an expected call of ChangesCursor.
+func (mr *MockTitlesMockRecorder) ChangesCursor(ctx, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesCursor", reflect.TypeOf((*MockTitles)(nil).ChangesCursor), ctx, feed)
+}
+
+// FindStaleIds mocks base method.
+func (m *MockTitles) FindStaleIds(ctx context.Context, fetchedBefore time.Time, limit uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStaleIds", ctx, fetchedBefore, limit)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStaleIds indicates an expected call of FindStaleIds.
+func (mr *MockTitlesMockRecorder) FindStaleIds(ctx, fetchedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStaleIds", reflect.TypeOf((*MockTitles)(nil).FindStaleIds), ctx, fetchedBefore, limit)
+}
+
+// Invalidate mocks base method.
+func (m *MockTitles) Invalidate(ctx context.Context, tmdbIds []uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", ctx, tmdbIds)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockTitlesMockRecorder) Invalidate(ctx, tmdbIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockTitles)(nil).Invalidate), ctx, tmdbIds)
+}
+
+// Refresh mocks base method.
+func (m *MockTitles) Refresh(ctx context.Context, tmdbId uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, tmdbId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockTitlesMockRecorder) Refresh(ctx, tmdbId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockTitles)(nil).Refresh), ctx, tmdbId)
+}
+
+// Resolve mocks base method.
+func (m *MockTitles) Resolve(ctx context.Context, tmdbId uint64) (*models.Title, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, tmdbId)
+	ret0, _ := ret[0].(*models.Title)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockTitlesMockRecorder) Resolve(ctx, tmdbId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockTitles)(nil).Resolve), ctx, tmdbId)
+}
+
+// SaveChangesCursor mocks base method.
+func (m *MockTitles) SaveChangesCursor(ctx context.Context, feed string, syncedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChangesCursor", ctx, feed, syncedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChangesCursor indicates an expected call of SaveChangesCursor.
+func (mr *MockTitlesMockRecorder) SaveChangesCursor(ctx, feed, syncedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChangesCursor", reflect.TypeOf((*MockTitles)(nil).SaveChangesCursor), ctx, feed, syncedUntil)
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/repositories"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_Titles_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithEventSource(context.Background(), models.EventSourceSync)
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockTitleRepository(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	service := NewTitles(cfg, repository, client, logger.NewLogger(cfg))
+
+	tests := []struct {
+		name   string
+		before func()
+		error  error
+	}{
+		{
+			name: "Stores the fetched metadata",
+			before: func() {
+				client.EXPECT().FetchMovieSummary(inServerLocale(), uint64(27205)).Return(&tmdb.MovieDetails{
+					Id:            27205,
+					Title:         "Inception",
+					OriginalTitle: "Inception",
+					PosterPath:    "/inception.jpg",
+					ReleaseDate:   "2010-07-15",
+					Runtime:       148,
+					Genres:        []tmdb.Genre{{Id: 28, Name: "Action"}, {Id: 878, Name: "Science Fiction"}},
+				}, nil)
+				repository.EXPECT().Refresh(ctx, &models.Title{
+					TmdbId:        27205,
+					Title:         "Inception",
+					OriginalTitle: "Inception",
+					PosterPath:    "/inception.jpg",
+					ReleaseDate:   "2010-07-15",
+					Runtime:       148,
+					GenreIds:      []int{28, 878},
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Flags a title deleted on TMDB",
+			before: func() {
+				client.EXPECT().FetchMovieSummary(inServerLocale(), uint64(27205)).Return(nil, tmdb.ErrNotFound)
+				repository.EXPECT().MarkMissing(ctx, uint64(27205), models.EventSourceSync).Return(nil)
+			},
+		},
+		{
+			name: "Re-points a title merged on TMDB",
+			before: func() {
+				client.EXPECT().FetchMovieSummary(inServerLocale(), uint64(27205)).Return(&tmdb.MovieDetails{Id: 27206, Title: "Inception"}, nil)
+				gomock.InOrder(
+					repository.EXPECT().Merge(ctx, uint64(27205), uint64(27206), models.EventSourceSync).Return(uint64(2), nil),
+					repository.EXPECT().Refresh(ctx, &models.Title{TmdbId: 27206, Title: "Inception", GenreIds: []int{}}).Return(nil),
+				)
+			},
+		},
+		{
+			name: "Upstream unavailable",
+			before: func() {
+				client.EXPECT().FetchMovieSummary(inServerLocale(), uint64(27205)).Return(nil, &tmdb.UnavailableError{RetryAfter: time.Second})
+			},
+			error: tmdb.ErrUpstreamUnavailable,
+		},
+		{
+			name: "Failure",
+			before: func() {
+				client.EXPECT().FetchMovieSummary(inServerLocale(), uint64(27205)).Return(&tmdb.MovieDetails{Id: 27205}, nil)
+				repository.EXPECT().Refresh(ctx, gomock.Any()).Return(errors.ErrFailedToUpdateMovie)
+			},
+			error: errors.ErrFailedToRefreshTitle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			err := service.Refresh(ctx, 27205)
+			assert.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+func Test_Titles_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockTitleRepository(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	service := NewTitles(cfg, repository, client, logger.NewLogger(cfg)).(*titles)
+
+	now := time.Date(2025, 7, 6, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	fresh := now.Add(-time.Hour)
+	stale := now.Add(-DefaultTitlesMaxAge - time.Hour)
+	fetched := &models.Title{TmdbId: 27205, Title: "Inception", PosterPath: "/inception.jpg", Runtime: 148, GenreIds: []int{}}
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected *models.Title
+		error    error
+	}{
+		{
+			name: "Returns a fresh catalog entry without fetching",
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Inception", FetchedAt: &fresh}, nil)
+			},
+			expected: &models.Title{TmdbId: 27205, Title: "Inception", FetchedAt: &fresh},
+		},
+		{
+			name: "Fetches a stale catalog entry",
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Old", FetchedAt: &stale}, nil)
+				client.EXPECT().FetchMovieDetails(inServerLocale(), uint64(27205)).Return(&tmdb.MovieDetails{Id: 27205, Title: "Inception", PosterPath: "/inception.jpg", Runtime: 148}, nil)
+				repository.EXPECT().Refresh(ctx, fetched).Return(nil)
+			},
+			expected: fetched,
+		},
+		{
+			name: "Fetches a title missing from the catalog",
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205)).Return(nil, errors.ErrMovieNotFound)
+				client.EXPECT().FetchMovieDetails(inServerLocale(), uint64(27205)).Return(&tmdb.MovieDetails{Id: 27205, Title: "Inception", PosterPath: "/inception.jpg", Runtime: 148}, nil)
+				repository.EXPECT().Refresh(ctx, fetched).Return(nil)
+			},
+			expected: fetched,
+		},
+		{
+			name: "Unknown TMDB id",
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205)).Return(nil, errors.ErrMovieNotFound)
+				client.EXPECT().FetchMovieDetails(inServerLocale(), uint64(27205)).Return(nil, tmdb.ErrNotFound)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+		{
+			name: "Upstream unavailable",
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205)).Return(nil, errors.ErrMovieNotFound)
+				client.EXPECT().FetchMovieDetails(inServerLocale(), uint64(27205)).Return(nil, &tmdb.UnavailableError{RetryAfter: time.Second})
+			},
+			error: tmdb.ErrUpstreamUnavailable,
+		},
+		{
+			name: "Upstream unavailable with a stale catalog entry",
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Old", FetchedAt: &stale}, nil)
+				client.EXPECT().FetchMovieDetails(inServerLocale(), uint64(27205)).Return(nil, &tmdb.UnavailableError{RetryAfter: time.Second})
+			},
+			expected: &models.Title{TmdbId: 27205, Title: "Old", FetchedAt: &stale},
+		},
+		{
+			name: "Unknown TMDB id with a stale catalog entry",
+			before: func() {
+				repository.EXPECT().FindByTmdbId(ctx, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Old", FetchedAt: &stale}, nil)
+				client.EXPECT().FetchMovieDetails(inServerLocale(), uint64(27205)).Return(nil, tmdb.ErrNotFound)
+			},
+			error: errors.ErrMovieNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := service.Resolve(ctx, 27205)
+			assert.ErrorIs(t, err, tt.error)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Titles_Resolve_UserLocales(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	repository := repositories.NewMockTitleRepository(ctrl)
+	client := tmdb.NewMockClient(ctrl)
+	service := NewTitles(cfg, repository, client, logger.NewLogger(cfg))
+
+	french := tmdb.WithLocale(context.Background(), tmdb.Locale{Language: "fr", Region: "FR"})
+	german := tmdb.WithLocale(context.Background(), tmdb.Locale{Language: "de", Region: "DE"})
+	fetchedAt := time.Now()
+	stored := &models.Title{TmdbId: 27205, Title: "Inception", GenreIds: []int{}}
+
+	gomock.InOrder(
+		repository.EXPECT().FindByTmdbId(french, uint64(27205)).Return(nil, errors.ErrMovieNotFound),
+		client.EXPECT().FetchMovieDetails(inServerLocale(), uint64(27205)).Return(&tmdb.MovieDetails{Id: 27205, Title: "Inception"}, nil),
+		repository.EXPECT().Refresh(french, stored).Return(nil),
+		repository.EXPECT().FindByTmdbId(german, uint64(27205)).Return(&models.Title{TmdbId: 27205, Title: "Inception", GenreIds: []int{}, FetchedAt: &fetchedAt}, nil),
+	)
+
+	first, err := service.Resolve(french, 27205)
+	assert.NoError(t, err)
+	assert.Equal(t, "Inception", first.Title)
+
+	second, err := service.Resolve(german, 27205)
+	assert.NoError(t, err)
+	assert.Equal(t, "Inception", second.Title)
+}
+
+// inServerLocale matches the contexts pinned to the server locale, whatever locale the user asked for
+func inServerLocale() gomock.Matcher {
+	return gomock.Cond(func(ctx context.Context) bool {
+		locale, ok := tmdb.LocaleFromContext(ctx)
+		return ok && locale == tmdb.DefaultLocale
+	})
+}
@@ -2,44 +2,64 @@ package services
 
 import (
 	"context"
+	"time"
 
-	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 
 	"biinge-api/internal/app/errors"
 	"biinge-api/internal/app/models"
 	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/config"
 	"biinge-api/internal/config/logger"
 	"biinge-api/pkg/tmdb"
 )
 
 type TmdbProvider interface {
-	FetchMovieDetails(ctx context.Context, id uint64, userId uuid.UUID) (*serializers.MovieDetailsSerializer, error)
+	FetchMovieDetails(ctx context.Context, id uint64, user *models.User) (*serializers.MovieDetailsSerializer, error)
 	// FetchTvDetails(ctx context.Context, id uint64, userId uuid.UUID) (*serializers.SeriesDetailsSerializer, error)
-	FetchPersonDetails(ctx context.Context, id uint64, userId uuid.UUID) (*serializers.PersonDetailsSerializer, error)
+	FetchPersonDetails(ctx context.Context, id uint64, user *models.User, creditType string) (*serializers.PersonDetailsSerializer, error)
+	FetchWatchProviders(ctx context.Context, region string, user *models.User) ([]serializers.WatchProviderSerializer, error)
+	FetchCollectionDetails(ctx context.Context, id uint64, user *models.User) (*serializers.CollectionDetailsSerializer, error)
 }
 
+// collectionRuntimeConcurrency caps the runtime lookups of a single collection request
+const collectionRuntimeConcurrency = 4
+
 type tmdbProvider struct {
-	client tmdb.Client
-	movies Movies
+	content config.ContentConfig
+	client  tmdb.Client
+	images  tmdb.Images
+	movies  Movies
 	// series Series
+	now func() time.Time
 	log *logger.Logger
 }
 
 func NewTmdbProvider(
+	cfg *config.Config,
 	client tmdb.Client,
+	images tmdb.Images,
 	movies Movies,
-// series Series,
+	// series Series,
 	log *logger.Logger,
 ) TmdbProvider {
+	content := cfg.ContentConfig
+	if len(content.ExcludedGenreIds) == 0 {
+		content.ExcludedGenreIds = tmdb.DefaultContentFilter().ExcludedGenreIds
+	}
+
 	return &tmdbProvider{
-		client: client,
-		movies: movies,
+		content: content,
+		client:  client,
+		images:  images,
+		movies:  movies,
 		//series: series,
+		now: time.Now,
 		log: log.WithComponent("TmdbProvider"),
 	}
 }
 
-func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, userId uuid.UUID) (*serializers.MovieDetailsSerializer, error) {
+func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, user *models.User) (*serializers.MovieDetailsSerializer, error) {
 	p.log.Debug().Uint64("Id", id).Msg("Fetching movie details")
 
 	response, err := p.client.FetchMovieDetails(ctx, id)
@@ -48,10 +68,21 @@ func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, userId
 			Err(err).
 			Uint64("Id", id).
 			Msg("Failed to fetch movie details")
+
+		if errors.Is(err, tmdb.ErrUpstreamUnavailable) {
+			return nil, err
+		}
 		return nil, tmdb.ErrFailedToFetchMovieDetails
 	}
 
-	details := tmdb.TransformMovieDetails(response)
+	filter := p.contentFilter(user)
+	if response.Adult && !filter.IncludeAdult {
+		p.log.Debug().Uint64("Id", id).Msg("Adult movie hidden by content filter")
+		return nil, errors.ErrMovieNotFound
+	}
+
+	region := p.client.Locale(ctx).Region
+	details := tmdb.TransformMovieDetails(response, region, filter)
 
 	p.log.Debug().
 		Uint64("Id", id).
@@ -62,7 +93,7 @@ func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, userId
 		recommendationIds = append(recommendationIds, item.Id)
 	}
 
-	moviesList, err := p.movies.FindMoviesByTmdbIds(ctx, recommendationIds, userId)
+	moviesList, err := p.movies.FindMoviesByTmdbIds(ctx, recommendationIds, user.ID)
 	if err != nil {
 		p.log.Error().
 			Err(err).
@@ -86,6 +117,7 @@ func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, userId
 			Id:         item.Id,
 			Title:      item.Title,
 			PosterPath: item.PosterPath,
+			Images:     serializers.NewImagesSerializer(p.images.Variants(ctx, tmdb.ImageKindPoster, item.PosterPath)),
 			State:      state,
 		})
 	}
@@ -97,6 +129,7 @@ func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, userId
 			Name:        item.Name,
 			Description: item.Description,
 			ProfilePath: item.ProfilePath,
+			Images:      serializers.NewImagesSerializer(p.images.Variants(ctx, tmdb.ImageKindProfile, item.ProfilePath)),
 		})
 	}
 
@@ -108,28 +141,99 @@ func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, userId
 		})
 	}
 
-	movie, err := p.movies.FindByTmdbId(ctx, id, userId)
+	genres := make([]serializers.GenreSerializer, 0, len(details.Genres))
+	for _, item := range details.Genres {
+		genres = append(genres, serializers.GenreSerializer{
+			Id:   item.Id,
+			Name: item.Name,
+		})
+	}
+
+	countries := make([]serializers.CountrySerializer, 0, len(details.ProductionCountries))
+	for _, item := range details.ProductionCountries {
+		countries = append(countries, serializers.CountrySerializer{
+			Code: item.Code,
+			Name: item.Name,
+		})
+	}
+
+	languages := make([]serializers.LanguageSerializer, 0, len(details.SpokenLanguages))
+	for _, item := range details.SpokenLanguages {
+		languages = append(languages, serializers.LanguageSerializer{
+			Code: item.Code,
+			Name: item.Name,
+		})
+	}
+
+	releaseDates := make([]serializers.ReleaseDateSerializer, 0, len(details.ReleaseDates))
+	for _, item := range details.ReleaseDates {
+		releaseDates = append(releaseDates, serializers.ReleaseDateSerializer{
+			Type:          item.Type,
+			Date:          item.Date,
+			Certification: item.Certification,
+			Note:          item.Note,
+		})
+	}
+
+	var collection *serializers.CollectionSummarySerializer
+	if details.Collection != nil {
+		collection = &serializers.CollectionSummarySerializer{
+			Id:           details.Collection.Id,
+			Name:         details.Collection.Name,
+			PosterPath:   details.Collection.PosterPath,
+			BackdropPath: details.Collection.BackdropPath,
+		}
+	}
+
+	watchProviders, availableOnMine := transformWatchProviders(details.WatchProviders, region, user.WatchProviders)
+
+	result := &serializers.MovieDetailsSerializer{
+		Id:                  id,
+		ImdbId:              details.ImdbId,
+		Pinned:              false,
+		State:               models.StateTypeNone,
+		Status:              details.Status,
+		Title:               details.Title,
+		OriginalTitle:       details.OriginalTitle,
+		OriginalLanguage:    details.OriginalLanguage,
+		Tagline:             details.Tagline,
+		PosterPath:          details.PosterPath,
+		Images:              serializers.NewImagesSerializer(p.images.Variants(ctx, tmdb.ImageKindPoster, details.PosterPath)),
+		BackdropPath:        details.BackdropPath,
+		Overview:            details.Overview,
+		ReleaseDate:         details.ReleaseDate,
+		Runtime:             details.Runtime,
+		Rating:              details.Rating,
+		Budget:              details.Budget,
+		Revenue:             details.Revenue,
+		Certification:       details.Certification,
+		Genres:              genres,
+		ProductionCountries: countries,
+		SpokenLanguages:     languages,
+		Collection:          collection,
+		ExternalIds: &serializers.ExternalIdsSerializer{
+			ImdbId:      details.ExternalIds.ImdbId,
+			WikidataId:  details.ExternalIds.WikidataId,
+			FacebookId:  details.ExternalIds.FacebookId,
+			InstagramId: details.ExternalIds.InstagramId,
+			TwitterId:   details.ExternalIds.TwitterId,
+		},
+		ReleaseDates:    releaseDates,
+		WatchProviders:  watchProviders,
+		AvailableOnMine: availableOnMine,
+		Credits:         credits,
+		Recommendations: recommendations,
+		Videos:          videos,
+	}
+
+	movie, err := p.movies.FindByTmdbId(ctx, id, user.ID)
 	if err != nil {
 		if errors.Is(err, errors.ErrMovieNotFound) {
 			p.log.Debug().
 				Err(err).
 				Uint64("Id", id).
 				Msg("Movie not found in database")
-			return &serializers.MovieDetailsSerializer{
-				Id:              id,
-				Pinned:          false,
-				State:           models.StateTypeNone,
-				Status:          details.Status,
-				Title:           details.Title,
-				PosterPath:      details.PosterPath,
-				Overview:        details.Overview,
-				ReleaseDate:     details.ReleaseDate,
-				Runtime:         details.Runtime,
-				Rating:          details.Rating,
-				Credits:         credits,
-				Recommendations: recommendations,
-				Videos:          videos,
-			}, nil
+			return result, nil
 		}
 		p.log.Error().
 			Err(err).
@@ -138,21 +242,13 @@ func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, userId
 		return nil, errors.ErrFailedToFetchMovie
 	}
 
-	return &serializers.MovieDetailsSerializer{
-		Id:              id,
-		Pinned:          movie.Pinned,
-		State:           movie.State,
-		Status:          details.Status,
-		Title:           details.Title,
-		PosterPath:      details.PosterPath,
-		Overview:        details.Overview,
-		ReleaseDate:     details.ReleaseDate,
-		Runtime:         details.Runtime,
-		Rating:          details.Rating,
-		Credits:         credits,
-		Recommendations: recommendations,
-		Videos:          videos,
-	}, nil
+	result.Pinned = movie.Pinned
+	result.State = movie.State
+	result.Progress = movie.Progress
+	result.Tags = movie.Tags
+	result.Notes = movie.Notes
+
+	return result, nil
 }
 
 // func (p *tmdbProvider) FetchTvDetails(ctx context.Context, id uint64, userId uuid.UUID) (*serializers.SeriesDetailsSerializer, error) {
@@ -269,7 +365,8 @@ func (p *tmdbProvider) FetchMovieDetails(ctx context.Context, id uint64, userId
 //	}, nil
 //}
 
-func (p *tmdbProvider) FetchPersonDetails(ctx context.Context, id uint64, userId uuid.UUID) (*serializers.PersonDetailsSerializer, error) {
+// FetchPersonDetails returns the person with credits of creditType, tv credits carry no state until series are tracked
+func (p *tmdbProvider) FetchPersonDetails(ctx context.Context, id uint64, user *models.User, creditType string) (*serializers.PersonDetailsSerializer, error) {
 	p.log.Debug().Uint64("Id", id).Msg("Fetching person details")
 
 	response, err := p.client.FetchPersonDetails(ctx, id)
@@ -278,21 +375,32 @@ func (p *tmdbProvider) FetchPersonDetails(ctx context.Context, id uint64, userId
 			Err(err).
 			Uint64("Id", id).
 			Msg("Failed to fetch person details")
+
+		if errors.Is(err, tmdb.ErrUpstreamUnavailable) {
+			return nil, err
+		}
 		return nil, tmdb.ErrFailedToFetchPersonDetails
 	}
 
-	details := tmdb.TransformPersonDetails(response)
+	filter := p.contentFilter(user)
+	details := tmdb.TransformPersonDetails(response, creditType, filter)
 
 	p.log.Debug().
 		Uint64("Id", id).
 		Msg("Successfully fetched and transformed person details")
 
-	movieCreditIds := make([]uint64, 0, len(details.MovieCredits))
-	for _, item := range details.MovieCredits {
-		movieCreditIds = append(movieCreditIds, item.Id)
+	// NOTE: completion covers the whole filmography whatever credit type is listed
+	castCredits := tmdb.FilmographyCredits(response, tmdb.CreditTypeCast, filter)
+	directorCredits := tmdb.FilmographyCredits(response, tmdb.CreditTypeDirector, filter)
+
+	movieCreditIds := make([]uint64, 0, len(details.MovieCredits)+len(castCredits)+len(directorCredits))
+	for _, items := range [][]tmdb.MovieCreditItem{details.MovieCredits, castCredits, directorCredits} {
+		for _, item := range items {
+			movieCreditIds = append(movieCreditIds, item.Id)
+		}
 	}
 
-	moviesList, err := p.movies.FindMoviesByTmdbIds(ctx, movieCreditIds, userId)
+	moviesList, err := p.movies.FindMoviesByTmdbIds(ctx, movieCreditIds, user.ID)
 	if err != nil {
 		p.log.Error().
 			Err(err).
@@ -316,17 +424,323 @@ func (p *tmdbProvider) FetchPersonDetails(ctx context.Context, id uint64, userId
 			Id:         item.Id,
 			Title:      item.Title,
 			PosterPath: item.PosterPath,
+			Images:     serializers.NewImagesSerializer(p.images.Variants(ctx, tmdb.ImageKindPoster, item.PosterPath)),
 			State:      state,
 			Type:       item.Type,
 		})
 	}
 
+	tvCredits := make([]serializers.TvCreditSerializer, 0, len(details.TvCredits))
+	for _, item := range details.TvCredits {
+		tvCredits = append(tvCredits, serializers.TvCreditSerializer{
+			Id:            item.Id,
+			Title:         item.Title,
+			PosterPath:    item.PosterPath,
+			Images:        serializers.NewImagesSerializer(p.images.Variants(ctx, tmdb.ImageKindPoster, item.PosterPath)),
+			Type:          item.Type,
+			EpisodesCount: item.EpisodesCount,
+		})
+	}
+
+	profileImages := make([]serializers.ImageSerializer, 0, len(details.ProfileImages))
+	for _, item := range details.ProfileImages {
+		profileImages = append(profileImages, serializers.ImageSerializer{
+			FilePath: item.FilePath,
+			Width:    item.Width,
+			Height:   item.Height,
+		})
+	}
+
 	return &serializers.PersonDetailsSerializer{
-		Id:           id,
-		Name:         details.Name,
-		Birthday:     details.Birthday,
-		ProfilePath:  details.ProfilePath,
-		Gender:       details.Gender,
+		Id:                 id,
+		Name:               details.Name,
+		Biography:          details.Biography,
+		Birthday:           details.Birthday,
+		Deathday:           details.Deathday,
+		PlaceOfBirth:       details.PlaceOfBirth,
+		KnownForDepartment: details.KnownForDepartment,
+		ProfilePath:        details.ProfilePath,
+		Images:             serializers.NewImagesSerializer(p.images.Variants(ctx, tmdb.ImageKindProfile, details.ProfilePath)),
+		Gender:             details.Gender,
+		ExternalIds: &serializers.ExternalIdsSerializer{
+			ImdbId:      details.ExternalIds.ImdbId,
+			WikidataId:  details.ExternalIds.WikidataId,
+			FacebookId:  details.ExternalIds.FacebookId,
+			InstagramId: details.ExternalIds.InstagramId,
+			TwitterId:   details.ExternalIds.TwitterId,
+		},
+		ProfileImages: profileImages,
+		Completion: &serializers.FilmographyCompletionSerializer{
+			Cast:     p.filmographyCompletion(castCredits, movieCreditStatesMap),
+			Director: p.filmographyCompletion(directorCredits, movieCreditStatesMap),
+		},
 		MovieCredits: movieCredits,
+		TvCredits:    tvCredits,
 	}, nil
 }
+
+// filmographyCompletion counts the released credits the user watched
+func (p *tmdbProvider) filmographyCompletion(credits []tmdb.MovieCreditItem, states map[uint64]string) serializers.CompletionSerializer {
+	today := p.now().Format("2006-01-02")
+
+	var result serializers.CompletionSerializer
+	for _, item := range credits {
+		if item.ReleaseDate > today {
+			continue
+		}
+
+		result.Total++
+		if states[item.Id] == models.StateTypeWatched {
+			result.Watched++
+		}
+	}
+
+	if result.Total > 0 {
+		result.Percentage = result.Watched * 100 / result.Total
+	}
+
+	return result
+}
+
+func (p *tmdbProvider) FetchWatchProviders(ctx context.Context, region string, user *models.User) ([]serializers.WatchProviderSerializer, error) {
+	if region == "" {
+		region = p.client.Locale(ctx).Region
+	}
+
+	p.log.Debug().Str("Region", region).Msg("Fetching watch providers")
+
+	response, err := p.client.FetchWatchProviders(ctx, region)
+	if err != nil {
+		p.log.Error().
+			Err(err).
+			Str("Region", region).
+			Msg("Failed to fetch watch providers")
+
+		if errors.Is(err, tmdb.ErrUpstreamUnavailable) {
+			return nil, err
+		}
+		return nil, tmdb.ErrFailedToFetchProviders
+	}
+
+	subscribed := subscribedProviders(user.WatchProviders)
+
+	providers := make([]serializers.WatchProviderSerializer, 0, len(response.Results))
+	for _, item := range response.Results {
+		_, ok := subscribed[item.ProviderId]
+		providers = append(providers, serializers.WatchProviderSerializer{
+			Id:         item.ProviderId,
+			Name:       item.ProviderName,
+			LogoPath:   item.LogoPath,
+			Subscribed: ok,
+		})
+	}
+
+	return providers, nil
+}
+
+// FetchCollectionDetails returns the parts of a collection annotated with the user's state,
+// completion and remaining runtime only account for parts already released
+func (p *tmdbProvider) FetchCollectionDetails(ctx context.Context, id uint64, user *models.User) (*serializers.CollectionDetailsSerializer, error) {
+	p.log.Debug().Uint64("Id", id).Msg("Fetching collection details")
+
+	response, err := p.client.FetchCollectionDetails(ctx, id)
+	if err != nil {
+		p.log.Error().
+			Err(err).
+			Uint64("Id", id).
+			Msg("Failed to fetch collection details")
+
+		if errors.Is(err, tmdb.ErrUpstreamUnavailable) {
+			return nil, err
+		}
+		return nil, tmdb.ErrFailedToFetchCollectionDetails
+	}
+
+	details := tmdb.TransformCollectionDetails(response, p.contentFilter(user))
+
+	partIds := make([]uint64, 0, len(details.Parts))
+	for _, item := range details.Parts {
+		partIds = append(partIds, item.Id)
+	}
+
+	moviesList, err := p.movies.FindMoviesByTmdbIds(ctx, partIds, user.ID)
+	if err != nil {
+		p.log.Error().
+			Err(err).
+			Msg("Failed to fetch collection part states")
+		return nil, errors.ErrFailedToFetchResults
+	}
+
+	moviesMap := make(map[uint64]models.Movie, len(moviesList))
+	for _, movie := range moviesList {
+		moviesMap[movie.TmdbId] = movie
+	}
+
+	today := p.now().Format("2006-01-02")
+
+	parts := make([]serializers.CollectionPartSerializer, 0, len(details.Parts))
+	for _, item := range details.Parts {
+		part := serializers.CollectionPartSerializer{
+			Id:          item.Id,
+			Title:       item.Title,
+			PosterPath:  item.PosterPath,
+			Images:      serializers.NewImagesSerializer(p.images.Variants(ctx, tmdb.ImageKindPoster, item.PosterPath)),
+			ReleaseDate: item.ReleaseDate,
+			Rating:      item.Rating,
+			Released:    item.ReleaseDate != "" && item.ReleaseDate <= today,
+			State:       models.StateTypeNone,
+		}
+
+		if movie, exists := moviesMap[item.Id]; exists {
+			part.State = movie.State
+			part.Runtime = int(movie.Runtime)
+		}
+
+		parts = append(parts, part)
+	}
+
+	if err := p.fillCollectionRuntimes(ctx, parts); err != nil {
+		return nil, err
+	}
+
+	result := &serializers.CollectionDetailsSerializer{
+		Id:           details.Id,
+		Name:         details.Name,
+		Overview:     details.Overview,
+		PosterPath:   details.PosterPath,
+		Images:       serializers.NewImagesSerializer(p.images.Variants(ctx, tmdb.ImageKindPoster, details.PosterPath)),
+		BackdropPath: details.BackdropPath,
+		Parts:        parts,
+	}
+
+	for _, part := range parts {
+		if !part.Released {
+			continue
+		}
+
+		result.ReleasedCount++
+		if part.State == models.StateTypeWatched {
+			result.WatchedCount++
+			continue
+		}
+		result.RemainingRuntime += part.Runtime
+	}
+
+	if result.ReleasedCount > 0 {
+		result.Completion = result.WatchedCount * 100 / result.ReleasedCount
+	}
+
+	return result, nil
+}
+
+// fillCollectionRuntimes looks up the runtime of released parts missing from the user's list,
+// a part failing to load keeps a zero runtime rather than failing the whole collection
+func (p *tmdbProvider) fillCollectionRuntimes(ctx context.Context, parts []serializers.CollectionPartSerializer) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(collectionRuntimeConcurrency)
+
+	for i := range parts {
+		if !parts[i].Released || parts[i].Runtime > 0 {
+			continue
+		}
+
+		group.Go(func() error {
+			movie, err := p.client.FetchMovieSummary(groupCtx, parts[i].Id)
+			if err != nil {
+				if errors.Is(err, tmdb.ErrUpstreamUnavailable) {
+					return err
+				}
+
+				p.log.Warn().
+					Err(err).
+					Uint64("Id", parts[i].Id).
+					Msg("Failed to fetch collection part runtime")
+				return nil
+			}
+
+			parts[i].Runtime = movie.Runtime
+
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
+
+// contentFilter merges the server defaults with the user overrides, adult titles need the server to allow them
+// and the user to opt in with a confirmed age
+func (p *tmdbProvider) contentFilter(user *models.User) tmdb.ContentFilter {
+	filter := tmdb.ContentFilter{
+		ExcludedGenreIds:      p.content.ExcludedGenreIds,
+		IncludeDocumentaries:  p.content.IncludeDocumentaries,
+		IncludeWithoutArtwork: p.content.IncludeWithoutArtwork,
+	}
+
+	if user == nil {
+		return filter
+	}
+
+	if user.IncludeDocumentaries != nil {
+		filter.IncludeDocumentaries = *user.IncludeDocumentaries
+	}
+
+	if user.IncludeWithoutArtwork != nil {
+		filter.IncludeWithoutArtwork = *user.IncludeWithoutArtwork
+	}
+
+	filter.IncludeAdult = p.content.AllowAdult && user.AdultConfirmed()
+
+	return filter
+}
+
+func subscribedProviders(ids []int32) map[int]struct{} {
+	subscribed := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		subscribed[int(id)] = struct{}{}
+	}
+
+	return subscribed
+}
+
+// transformWatchProviders marks the providers the user subscribes to,
+// reporting whether the title can be streamed on one of them
+func transformWatchProviders(
+	item *tmdb.WatchProvidersItem,
+	region string,
+	subscriptions []int32,
+) (*serializers.WatchProvidersSerializer, bool) {
+	if item == nil {
+		return nil, false
+	}
+
+	subscribed := subscribedProviders(subscriptions)
+
+	convert := func(providers []tmdb.WatchProviderItem) ([]serializers.WatchProviderSerializer, bool) {
+		result := make([]serializers.WatchProviderSerializer, 0, len(providers))
+		matched := false
+		for _, provider := range providers {
+			_, ok := subscribed[provider.Id]
+			matched = matched || ok
+
+			result = append(result, serializers.WatchProviderSerializer{
+				Id:         provider.Id,
+				Name:       provider.Name,
+				LogoPath:   provider.LogoPath,
+				Subscribed: ok,
+			})
+		}
+		return result, matched
+	}
+
+	flatrate, available := convert(item.Flatrate)
+	rent, _ := convert(item.Rent)
+	buy, _ := convert(item.Buy)
+
+	return &serializers.WatchProvidersSerializer{
+		Region:   region,
+		Link:     item.Link,
+		Flatrate: flatrate,
+		Rent:     rent,
+		Buy:      buy,
+	}, available
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_TransformWatchProviders(t *testing.T) {
+	item := &tmdb.WatchProvidersItem{
+		Link:     "https://www.themoviedb.org/movie/438631/watch?locale=US",
+		Flatrate: []tmdb.WatchProviderItem{{Id: 8, Name: "Netflix"}, {Id: 1899, Name: "Max"}},
+		Rent:     []tmdb.WatchProviderItem{{Id: 2, Name: "Apple TV"}},
+	}
+
+	tests := []struct {
+		name          string
+		subscriptions []int32
+		available     bool
+	}{
+		{name: "Streamable on subscribed service", subscriptions: []int32{1899}, available: true},
+		{name: "Only rentable on subscribed service", subscriptions: []int32{2}, available: false},
+		{name: "No subscriptions", subscriptions: nil, available: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, available := transformWatchProviders(item, "US", tt.subscriptions)
+
+			assert.Equal(t, tt.available, available)
+			assert.Equal(t, "US", result.Region)
+			assert.Len(t, result.Flatrate, 2)
+			assert.Equal(t, []serializers.WatchProviderSerializer{}, result.Buy)
+		})
+	}
+
+	result, available := transformWatchProviders(nil, "US", []int32{8})
+	assert.Nil(t, result)
+	assert.False(t, available)
+}
+
+func Test_TmdbProvider_FetchCollectionDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+	client := tmdb.NewMockClient(ctrl)
+	movies := NewMockMovies(ctrl)
+	provider := NewTmdbProvider(cfg, client, tmdb.NewImages(cfg, client, logger.NewLogger(cfg)), movies, logger.NewLogger(cfg)).(*tmdbProvider)
+	provider.now = func() time.Time { return time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC) }
+
+	userId := uuid.New()
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected *serializers.CollectionDetailsSerializer
+		error    error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				client.EXPECT().FetchCollectionDetails(ctx, uint64(10)).Return(&tmdb.CollectionDetails{
+					Id:   10,
+					Name: "Star Wars Collection",
+					Parts: []tmdb.CollectionPart{
+						{Id: 11, Title: "A New Hope", ReleaseDate: "1977-05-25"},
+						{Id: 1891, Title: "The Empire Strikes Back", ReleaseDate: "1980-05-20"},
+						{Id: 1892, Title: "Return of the Jedi", ReleaseDate: "1983-05-25"},
+						{Id: 99999, Title: "Untitled", ReleaseDate: "2099-12-18"},
+					},
+				}, nil)
+				movies.EXPECT().
+					FindMoviesByTmdbIds(ctx, []uint64{11, 1891, 1892, 99999}, userId).
+					Return([]models.Movie{
+						{TmdbId: 11, State: models.StateTypeWatched, Runtime: 121},
+						{TmdbId: 1891, State: models.StateTypeWant, Runtime: 124},
+					}, nil)
+				client.EXPECT().FetchMovieSummary(gomock.Any(), uint64(1892)).Return(&tmdb.MovieDetails{Runtime: 132}, nil)
+			},
+			expected: &serializers.CollectionDetailsSerializer{
+				Id:   10,
+				Name: "Star Wars Collection",
+				Parts: []serializers.CollectionPartSerializer{
+					{Id: 11, Title: "A New Hope", ReleaseDate: "1977-05-25", Runtime: 121, Released: true, State: models.StateTypeWatched},
+					{Id: 1891, Title: "The Empire Strikes Back", ReleaseDate: "1980-05-20", Runtime: 124, Released: true, State: models.StateTypeWant},
+					{Id: 1892, Title: "Return of the Jedi", ReleaseDate: "1983-05-25", Runtime: 132, Released: true, State: models.StateTypeNone},
+					{Id: 99999, Title: "Untitled", ReleaseDate: "2099-12-18", State: models.StateTypeNone},
+				},
+				WatchedCount:     1,
+				ReleasedCount:    3,
+				Completion:       33,
+				RemainingRuntime: 256,
+			},
+		},
+		{
+			name: "Upstream unavailable",
+			before: func() {
+				client.EXPECT().FetchCollectionDetails(ctx, uint64(10)).Return(nil, &tmdb.UnavailableError{})
+			},
+			error: tmdb.ErrUpstreamUnavailable,
+		},
+		{
+			name: "Not found",
+			before: func() {
+				client.EXPECT().FetchCollectionDetails(ctx, uint64(10)).Return(nil, tmdb.ErrNotFound)
+			},
+			error: tmdb.ErrFailedToFetchCollectionDetails,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := provider.FetchCollectionDetails(ctx, 10, &models.User{ID: userId})
+
+			assert.ErrorIs(t, err, tt.error)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_TmdbProvider_FetchPersonDetails_Completion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+	client := tmdb.NewMockClient(ctrl)
+	movies := NewMockMovies(ctrl)
+	provider := NewTmdbProvider(cfg, client, tmdb.NewImages(cfg, client, logger.NewLogger(cfg)), movies, logger.NewLogger(cfg)).(*tmdbProvider)
+	provider.now = func() time.Time { return time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC) }
+
+	userId := uuid.New()
+
+	client.EXPECT().FetchPersonDetails(ctx, uint64(137427)).Return(&tmdb.PersonDetails{
+		Id:   137427,
+		Name: "Denis Villeneuve",
+		Credits: tmdb.PersonMovieCredits{
+			Cast: []tmdb.MovieCredit{
+				{Id: 1, Title: "Cameo", PosterPath: "/1.jpg", ReleaseDate: "2000-01-01", Character: "Himself"},
+			},
+			Crew: []tmdb.MovieCredit{
+				{Id: 2, Title: "Arrival", PosterPath: "/2.jpg", ReleaseDate: "2016-11-10", Job: "Director"},
+				{Id: 3, Title: "Dune", PosterPath: "/3.jpg", ReleaseDate: "2021-09-15", Job: "Director"},
+				{Id: 4, Title: "Sicario", PosterPath: "/4.jpg", ReleaseDate: "2015-09-17", Job: "Director"},
+				{Id: 5, Title: "Dune: Part Three", PosterPath: "/5.jpg", ReleaseDate: "2099-12-18", Job: "Director"},
+			},
+		},
+	}, nil)
+	movies.EXPECT().FindMoviesByTmdbIds(ctx, gomock.Any(), userId).Return([]models.Movie{
+		{TmdbId: 2, State: models.StateTypeWatched},
+		{TmdbId: 3, State: models.StateTypeWant},
+	}, nil)
+
+	result, err := provider.FetchPersonDetails(ctx, 137427, &models.User{ID: userId}, tmdb.CreditTypeDirector)
+	assert.NoError(t, err)
+
+	assert.Len(t, result.MovieCredits, 4)
+	assert.Equal(t, &serializers.FilmographyCompletionSerializer{
+		Cast:     serializers.CompletionSerializer{Watched: 0, Total: 1, Percentage: 0},
+		Director: serializers.CompletionSerializer{Watched: 1, Total: 3, Percentage: 33},
+	}, result.Completion)
+}
+
+func Test_TmdbProvider_ContentFilter(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+		ContentConfig: config.ContentConfig{
+			ExcludedGenreIds: []int{10767},
+			AllowAdult:       true,
+		},
+	}
+	provider := NewTmdbProvider(cfg, nil, nil, nil, logger.NewLogger(cfg)).(*tmdbProvider)
+
+	enabled := true
+	confirmedAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		allowAdult bool
+		user       *models.User
+		expected   tmdb.ContentFilter
+	}{
+		{
+			name:       "Server defaults",
+			allowAdult: true,
+			user:       &models.User{},
+			expected:   tmdb.ContentFilter{ExcludedGenreIds: []int{10767}},
+		},
+		{
+			name:       "User overrides",
+			allowAdult: true,
+			user:       &models.User{IncludeDocumentaries: &enabled, IncludeWithoutArtwork: &enabled},
+			expected:   tmdb.ContentFilter{ExcludedGenreIds: []int{10767}, IncludeDocumentaries: true, IncludeWithoutArtwork: true},
+		},
+		{
+			name:       "Adult with confirmed age",
+			allowAdult: true,
+			user:       &models.User{IncludeAdult: true, AdultConfirmedAt: &confirmedAt},
+			expected:   tmdb.ContentFilter{ExcludedGenreIds: []int{10767}, IncludeAdult: true},
+		},
+		{
+			name:       "Adult without confirmed age",
+			allowAdult: true,
+			user:       &models.User{IncludeAdult: true},
+			expected:   tmdb.ContentFilter{ExcludedGenreIds: []int{10767}},
+		},
+		{
+			name:       "Adult disabled on server",
+			allowAdult: false,
+			user:       &models.User{IncludeAdult: true, AdultConfirmedAt: &confirmedAt},
+			expected:   tmdb.ContentFilter{ExcludedGenreIds: []int{10767}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider.content.AllowAdult = tt.allowAdult
+
+			assert.Equal(t, tt.expected, provider.contentFilter(tt.user))
+		})
+	}
+}
+
+func Test_TmdbProvider_ContentFilter_DefaultGenres(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+	provider := NewTmdbProvider(cfg, nil, nil, nil, logger.NewLogger(cfg)).(*tmdbProvider)
+
+	assert.Equal(t, tmdb.DefaultContentFilter(), provider.contentFilter(nil))
+}
+
+func Test_TmdbProvider_FetchMovieDetails_HidesAdult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+	client := tmdb.NewMockClient(ctrl)
+	movies := NewMockMovies(ctrl)
+	provider := NewTmdbProvider(cfg, client, tmdb.NewImages(cfg, client, logger.NewLogger(cfg)), movies, logger.NewLogger(cfg))
+
+	client.EXPECT().FetchMovieDetails(ctx, uint64(1)).Return(&tmdb.MovieDetails{Id: 1, Title: "Adult", Adult: true}, nil)
+
+	result, err := provider.FetchMovieDetails(ctx, 1, &models.User{ID: uuid.New(), IncludeAdult: true})
+
+	assert.ErrorIs(t, err, errors.ErrMovieNotFound)
+	assert.Nil(t, result)
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"biinge-api/internal/app/models"
 	"biinge-api/internal/app/repositories"
@@ -49,10 +51,18 @@ func (u *users) Create(ctx context.Context, params *models.User) (*models.User,
 
 func (u *users) Update(ctx context.Context, params *models.User) (*models.User, error) {
 	user, err := u.repository.Update(ctx, db.UpdateUserParams{
-		ID:         params.ID,
-		FirstName:  params.FirstName,
-		LastName:   params.LastName,
-		Appearance: db.AppearanceType(params.Appearance),
+		ID:             params.ID,
+		FirstName:      params.FirstName,
+		LastName:       params.LastName,
+		Appearance:     db.AppearanceType(params.Appearance),
+		Language:       params.Language,
+		Region:         params.Region,
+		WatchProviders: params.WatchProviders,
+
+		IncludeDocumentaries:  nullableBool(params.IncludeDocumentaries),
+		IncludeWithoutArtwork: nullableBool(params.IncludeWithoutArtwork),
+		IncludeAdult:          params.IncludeAdult,
+		AdultConfirmedAt:      nullableTimestamp(params.AdultConfirmedAt),
 	})
 	if err != nil {
 		return nil, err
@@ -87,3 +97,17 @@ func (u *users) FindByEmail(ctx context.Context, email string) (*models.User, er
 
 	return user, nil
 }
+
+func nullableBool(v *bool) pgtype.Bool {
+	if v == nil {
+		return pgtype.Bool{}
+	}
+	return pgtype.Bool{Bool: *v, Valid: true}
+}
+
+func nullableTimestamp(v *time.Time) pgtype.Timestamp {
+	if v == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: *v, Valid: true}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,6 +20,54 @@ type TMDBConfig struct {
 
 	Locale  string
 	Timeout time.Duration
+
+	RateLimit        float64
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	ImageSizes     []int
+	ImageConfigTTL time.Duration
+}
+
+type JobsConfig struct {
+	WatchProvidersInterval   time.Duration
+	WatchProvidersMaxAge     time.Duration
+	WatchProvidersBatch      int
+	MovieCreditsInterval     time.Duration
+	MovieCreditsBatch        int
+	TitlesInterval           time.Duration
+	TitlesMaxAge             time.Duration
+	TitlesBatch              int
+	TitleChangesInterval     time.Duration
+	TrashPurgeInterval       time.Duration
+	IdempotencyPurgeInterval time.Duration
+}
+
+type ImageProxyConfig struct {
+	Enabled       bool
+	URL           string
+	CacheDir      string
+	CacheMaxBytes int
+}
+
+type ContentConfig struct {
+	ExcludedGenreIds      []int
+	IncludeDocumentaries  bool
+	IncludeWithoutArtwork bool
+	AllowAdult            bool
+}
+
+// LibraryConfig tunes library bookkeeping, WatchedThreshold is the share of the runtime in percent
+// after which a movie in progress counts as watched and TrashRetention how long deleted movies are kept
+type LibraryConfig struct {
+	WatchedThreshold int
+	TrashRetention   time.Duration
+}
+
+// IdempotencyConfig tunes the replay of mutating requests sent with an Idempotency-Key, KeyTTL is how long
+// the response to a key is stored and replayed to retries
+type IdempotencyConfig struct {
+	KeyTTL time.Duration
 }
 
 type Config struct {
@@ -31,6 +81,11 @@ type Config struct {
 	LogLevel      string
 
 	TMDBConfig
+	JobsConfig
+	ContentConfig
+	ImageProxyConfig
+	LibraryConfig
+	IdempotencyConfig
 }
 
 func LoadConfig() *Config {
@@ -63,6 +118,49 @@ func LoadConfig() *Config {
 			BaseImageURL:       getEnvString("TMDB_BASE_IMAGE_URL"),
 			APIReadAccessToken: getEnvString("TMDB_API_READ_ACCESS_TOKEN"),
 			Locale:             getEnvString("TMDB_LOCALE"),
+			Timeout:            getEnvDuration("TMDB_TIMEOUT"),
+			RateLimit:          getEnvFloat("TMDB_RATE_LIMIT"),
+			BreakerThreshold:   getEnvInt("TMDB_BREAKER_THRESHOLD"),
+			BreakerCooldown:    getEnvDuration("TMDB_BREAKER_COOLDOWN"),
+			ImageSizes:         getEnvIntSlice("TMDB_IMAGE_SIZES"),
+			ImageConfigTTL:     getEnvDuration("TMDB_IMAGE_CONFIG_TTL"),
+		},
+
+		JobsConfig: JobsConfig{
+			WatchProvidersInterval:   getEnvDuration("JOBS_WATCH_PROVIDERS_INTERVAL"),
+			WatchProvidersMaxAge:     getEnvDuration("JOBS_WATCH_PROVIDERS_MAX_AGE"),
+			WatchProvidersBatch:      getEnvInt("JOBS_WATCH_PROVIDERS_BATCH"),
+			MovieCreditsInterval:     getEnvDuration("JOBS_MOVIE_CREDITS_INTERVAL"),
+			MovieCreditsBatch:        getEnvInt("JOBS_MOVIE_CREDITS_BATCH"),
+			TitlesInterval:           getEnvDuration("JOBS_TITLES_INTERVAL"),
+			TitlesMaxAge:             getEnvDuration("JOBS_TITLES_MAX_AGE"),
+			TitlesBatch:              getEnvInt("JOBS_TITLES_BATCH"),
+			TitleChangesInterval:     getEnvDuration("JOBS_TITLE_CHANGES_INTERVAL"),
+			TrashPurgeInterval:       getEnvDuration("JOBS_TRASH_PURGE_INTERVAL"),
+			IdempotencyPurgeInterval: getEnvDuration("JOBS_IDEMPOTENCY_PURGE_INTERVAL"),
+		},
+
+		ContentConfig: ContentConfig{
+			ExcludedGenreIds:      getEnvIntSlice("CONTENT_EXCLUDED_GENRES"),
+			IncludeDocumentaries:  getEnvBool("CONTENT_INCLUDE_DOCUMENTARIES"),
+			IncludeWithoutArtwork: getEnvBool("CONTENT_INCLUDE_WITHOUT_ARTWORK"),
+			AllowAdult:            getEnvBool("CONTENT_ALLOW_ADULT"),
+		},
+
+		ImageProxyConfig: ImageProxyConfig{
+			Enabled:       getEnvBool("IMAGE_PROXY_ENABLED"),
+			URL:           getEnvString("IMAGE_PROXY_URL"),
+			CacheDir:      getEnvString("IMAGE_CACHE_DIR"),
+			CacheMaxBytes: getEnvInt("IMAGE_CACHE_MAX_BYTES"),
+		},
+
+		LibraryConfig: LibraryConfig{
+			WatchedThreshold: getEnvInt("LIBRARY_WATCHED_THRESHOLD"),
+			TrashRetention:   getEnvDuration("LIBRARY_TRASH_RETENTION"),
+		},
+
+		IdempotencyConfig: IdempotencyConfig{
+			KeyTTL: getEnvDuration("IDEMPOTENCY_KEY_TTL"),
 		},
 	}
 }
@@ -74,3 +172,53 @@ func getEnvString(envVar string) string {
 
 	return ""
 }
+
+func getEnvInt(envVar string) int {
+	value, err := strconv.Atoi(getEnvString(envVar))
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+func getEnvBool(envVar string) bool {
+	value, err := strconv.ParseBool(getEnvString(envVar))
+	if err != nil {
+		return false
+	}
+
+	return value
+}
+
+func getEnvIntSlice(envVar string) []int {
+	values := make([]int, 0)
+	for _, item := range strings.Split(getEnvString(envVar), ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func getEnvFloat(envVar string) float64 {
+	value, err := strconv.ParseFloat(getEnvString(envVar), 64)
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+func getEnvDuration(envVar string) time.Duration {
+	value, err := time.ParseDuration(getEnvString(envVar))
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
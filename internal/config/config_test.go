@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -47,6 +48,44 @@ func Test_LoadConfig(t *testing.T) {
 					BaseImageURL:       "https://image.tmdb.org/t/p",
 					APIReadAccessToken: "SECRET",
 					Locale:             "en-US",
+					Timeout:            10 * time.Second,
+					RateLimit:          40,
+					BreakerThreshold:   5,
+					BreakerCooldown:    30 * time.Second,
+					ImageSizes:         []int{185, 342, 780},
+					ImageConfigTTL:     24 * time.Hour,
+				},
+				JobsConfig: JobsConfig{
+					WatchProvidersInterval:   time.Hour,
+					WatchProvidersMaxAge:     24 * time.Hour,
+					WatchProvidersBatch:      100,
+					MovieCreditsInterval:     15 * time.Minute,
+					MovieCreditsBatch:        50,
+					TitlesInterval:           time.Hour,
+					TitlesMaxAge:             720 * time.Hour,
+					TitlesBatch:              50,
+					TitleChangesInterval:     time.Hour,
+					TrashPurgeInterval:       time.Hour,
+					IdempotencyPurgeInterval: time.Hour,
+				},
+				ContentConfig: ContentConfig{
+					ExcludedGenreIds:      []int{10767, 10763, 10764},
+					IncludeDocumentaries:  false,
+					IncludeWithoutArtwork: false,
+					AllowAdult:            true,
+				},
+				ImageProxyConfig: ImageProxyConfig{
+					Enabled:       false,
+					URL:           "http://localhost:8080/images",
+					CacheDir:      "tmp/images-test",
+					CacheMaxBytes: 536870912,
+				},
+				LibraryConfig: LibraryConfig{
+					WatchedThreshold: 90,
+					TrashRetention:   720 * time.Hour,
+				},
+				IdempotencyConfig: IdempotencyConfig{
+					KeyTTL: 24 * time.Hour,
 				},
 			},
 		},
@@ -72,6 +111,16 @@ func Test_LoadConfig(t *testing.T) {
 			assert.Equal(t, tt.expected.TMDBConfig.APIReadAccessToken, result.TMDBConfig.APIReadAccessToken)
 			assert.Equal(t, tt.expected.TMDBConfig.Locale, result.TMDBConfig.Locale)
 			assert.Equal(t, tt.expected.TMDBConfig.Timeout, result.TMDBConfig.Timeout)
+			assert.Equal(t, tt.expected.TMDBConfig.RateLimit, result.TMDBConfig.RateLimit)
+			assert.Equal(t, tt.expected.TMDBConfig.BreakerThreshold, result.TMDBConfig.BreakerThreshold)
+			assert.Equal(t, tt.expected.TMDBConfig.BreakerCooldown, result.TMDBConfig.BreakerCooldown)
+			assert.Equal(t, tt.expected.TMDBConfig.ImageSizes, result.TMDBConfig.ImageSizes)
+			assert.Equal(t, tt.expected.TMDBConfig.ImageConfigTTL, result.TMDBConfig.ImageConfigTTL)
+			assert.Equal(t, tt.expected.JobsConfig, result.JobsConfig)
+			assert.Equal(t, tt.expected.ContentConfig, result.ContentConfig)
+			assert.Equal(t, tt.expected.ImageProxyConfig, result.ImageProxyConfig)
+			assert.Equal(t, tt.expected.LibraryConfig, result.LibraryConfig)
+			assert.Equal(t, tt.expected.IdempotencyConfig, result.IdempotencyConfig)
 
 			t.Cleanup(func() {
 				for key := range tt.env {
@@ -0,0 +1,163 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/serializers"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config/logger"
+)
+
+const (
+	IdempotencyKey     = "Idempotency-Key"
+	IdempotentReplayed = "Idempotent-Replayed"
+)
+
+type IdempotencyMiddleware interface {
+	Idempotent(next http.Handler) http.Handler
+}
+
+type idempotencyMiddleware struct {
+	idempotency services.Idempotency
+	log         *logger.Logger
+}
+
+func NewIdempotencyMiddleware(idempotency services.Idempotency, log *logger.Logger) IdempotencyMiddleware {
+	return &idempotencyMiddleware{
+		idempotency: idempotency,
+		log:         log.WithComponent("IdempotencyMiddleware"),
+	}
+}
+
+// Idempotent answers retries of a mutating request sent with an Idempotency-Key with the response of the
+// first request, flagged with the Idempotent-Replayed header, instead of applying it again. Responses to
+// server failures are not stored so retrying runs the request again. Must run after Authenticate
+func (m *idempotencyMiddleware) Idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKey)
+		user, ok := CurrentUserFromContext(r.Context())
+		if key == "" || !ok || !isMutating(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !isValidIdempotencyKey(key) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidIdempotencyKey.Error()})
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		claim, err := m.idempotency.Claim(r.Context(), user.ID, key, fingerprint(r, body))
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, errors.ErrIdempotencyKeyReused):
+				status = http.StatusUnprocessableEntity
+			case errors.Is(err, errors.ErrIdempotencyKeyInProgress):
+				status = http.StatusConflict
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+			return
+		}
+
+		if claim.Completed() {
+			if claim.ContentType != "" {
+				w.Header().Set("Content-Type", claim.ContentType)
+			}
+			w.Header().Set(IdempotentReplayed, strconv.FormatBool(true))
+			w.WriteHeader(claim.Status)
+			_, _ = w.Write(claim.Body)
+			return
+		}
+
+		// NOTE: the outcome is stored even when the client went away, that is when it retries
+		ctx := context.WithoutCancel(r.Context())
+		completed := false
+		defer func() {
+			if !completed {
+				_ = m.idempotency.Release(ctx, claim)
+			}
+		}()
+
+		var response bytes.Buffer
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Tee(&response)
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		claim.Status = status
+		claim.ContentType = ww.Header().Get("Content-Type")
+		claim.Body = response.Bytes()
+
+		if err := m.idempotency.Complete(ctx, claim); err != nil {
+			m.log.Warn().Err(err).Str("key", key).Msg("Failed to store idempotent response")
+			return
+		}
+		completed = true
+	})
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isValidIdempotencyKey accepts keys of printable ASCII characters, such as UUIDs, up to the stored length
+func isValidIdempotencyKey(key string) bool {
+	if len(key) > models.MaxIdempotencyKeyLength {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fingerprint identifies a request by its method, URI and body, so a key is not replayed for another request
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: idempotency.go
+//
+// Generated by this command:
+//
+//	mockgen -source=idempotency.go -destination=idempotency_mock.go -package=middlewares
+//
+
+// Package middlewares is a generated GoMock package.
+package middlewares
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyMiddleware is a mock of IdempotencyMiddleware interface.
+type MockIdempotencyMiddleware struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMiddlewareMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyMiddlewareMockRecorder is the mock recorder for MockIdempotencyMiddleware.
+type MockIdempotencyMiddlewareMockRecorder struct {
+	mock *MockIdempotencyMiddleware
+}
+
+// NewMockIdempotencyMiddleware creates a new mock instance.
+func NewMockIdempotencyMiddleware(ctrl *gomock.Controller) *MockIdempotencyMiddleware {
+	mock := &MockIdempotencyMiddleware{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMiddlewareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyMiddleware) EXPECT() *MockIdempotencyMiddlewareMockRecorder {
+	return m.recorder
+}
+
+// Idempotent mocks base method.
+func (m *MockIdempotencyMiddleware) Idempotent(next http.Handler) http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Idempotent", next)
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// Idempotent indicates an expected call of Idempotent.
+func (mr *MockIdempotencyMiddlewareMockRecorder) Idempotent(next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Idempotent", reflect.TypeOf((*MockIdempotencyMiddleware)(nil).Idempotent), next)
+}
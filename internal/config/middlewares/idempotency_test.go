@@ -0,0 +1,195 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/app/errors"
+	"biinge-api/internal/app/models"
+	"biinge-api/internal/app/services"
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_IdempotencyMiddleware_Idempotent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+
+	idempotency := services.NewMockIdempotency(ctrl)
+	middleware := NewIdempotencyMiddleware(idempotency, logger.NewLogger(cfg))
+
+	user := &models.User{ID: uuid.New()}
+	claimId := uuid.New()
+	body := `{ "id": 27205, "state": "want" }`
+
+	type result struct {
+		code     int
+		body     string
+		replayed string
+		handled  bool
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		key      string
+		status   int
+		before   func()
+		expected result
+	}{
+		{
+			name:     "Without a key",
+			method:   http.MethodPost,
+			status:   http.StatusOK,
+			before:   func() {},
+			expected: result{code: http.StatusOK, body: "created", handled: true},
+		},
+		{
+			name:     "Read only request",
+			method:   http.MethodGet,
+			key:      "key",
+			status:   http.StatusOK,
+			before:   func() {},
+			expected: result{code: http.StatusOK, body: "created", handled: true},
+		},
+		{
+			name:   "Stores the response",
+			method: http.MethodPost,
+			key:    "key",
+			status: http.StatusOK,
+			before: func() {
+				idempotency.EXPECT().Claim(gomock.Any(), user.ID, "key", gomock.Any()).Return(&models.IdempotencyKey{ID: claimId}, nil)
+				idempotency.EXPECT().Complete(gomock.Any(), &models.IdempotencyKey{
+					ID:          claimId,
+					Status:      http.StatusOK,
+					ContentType: "application/json",
+					Body:        []byte("created"),
+				}).Return(nil)
+			},
+			expected: result{code: http.StatusOK, body: "created", handled: true},
+		},
+		{
+			name:   "Stores client errors",
+			method: http.MethodPost,
+			key:    "key",
+			status: http.StatusConflict,
+			before: func() {
+				idempotency.EXPECT().Claim(gomock.Any(), user.ID, "key", gomock.Any()).Return(&models.IdempotencyKey{ID: claimId}, nil)
+				idempotency.EXPECT().Complete(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expected: result{code: http.StatusConflict, body: "created", handled: true},
+		},
+		{
+			name:   "Releases the key on server errors",
+			method: http.MethodPost,
+			key:    "key",
+			status: http.StatusServiceUnavailable,
+			before: func() {
+				idempotency.EXPECT().Claim(gomock.Any(), user.ID, "key", gomock.Any()).Return(&models.IdempotencyKey{ID: claimId}, nil)
+				idempotency.EXPECT().Release(gomock.Any(), &models.IdempotencyKey{ID: claimId}).Return(nil)
+			},
+			expected: result{code: http.StatusServiceUnavailable, body: "created", handled: true},
+		},
+		{
+			name:   "Replays the stored response",
+			method: http.MethodPost,
+			key:    "key",
+			before: func() {
+				idempotency.EXPECT().Claim(gomock.Any(), user.ID, "key", gomock.Any()).Return(&models.IdempotencyKey{
+					ID:          claimId,
+					Status:      http.StatusCreated,
+					ContentType: "application/json",
+					Body:        []byte("stored"),
+				}, nil)
+			},
+			expected: result{code: http.StatusCreated, body: "stored", replayed: "true"},
+		},
+		{
+			name:   "Key in progress",
+			method: http.MethodPut,
+			key:    "key",
+			before: func() {
+				idempotency.EXPECT().Claim(gomock.Any(), user.ID, "key", gomock.Any()).Return(nil, errors.ErrIdempotencyKeyInProgress)
+			},
+			expected: result{code: http.StatusConflict, body: `{"error":"a request with this idempotency key is in progress"}`},
+		},
+		{
+			name:   "Key used for another request",
+			method: http.MethodPatch,
+			key:    "key",
+			before: func() {
+				idempotency.EXPECT().Claim(gomock.Any(), user.ID, "key", gomock.Any()).Return(nil, errors.ErrIdempotencyKeyReused)
+			},
+			expected: result{code: http.StatusUnprocessableEntity, body: `{"error":"idempotency key already used for another request"}`},
+		},
+		{
+			name:     "Key too long",
+			method:   http.MethodDelete,
+			key:      strings.Repeat("k", models.MaxIdempotencyKeyLength+1),
+			before:   func() {},
+			expected: result{code: http.StatusBadRequest, body: `{"error":"invalid idempotency key"}`},
+		},
+		{
+			name:   "Failure",
+			method: http.MethodPost,
+			key:    "key",
+			before: func() {
+				idempotency.EXPECT().Claim(gomock.Any(), user.ID, "key", gomock.Any()).Return(nil, errors.ErrFailedToSaveIdempotencyKey)
+			},
+			expected: result{code: http.StatusInternalServerError, body: `{"error":"failed to save idempotency key"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			handled := false
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handled = true
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("created"))
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/v1/movies", strings.NewReader(body))
+			if tt.key != "" {
+				req.Header.Set(IdempotencyKey, tt.key)
+			}
+			ctx := NewContextModifier(req.Context()).
+				WithCurrentUser(user).
+				Context()
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			middleware.Idempotent(handler).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected.code, rr.Code)
+			assert.Equal(t, tt.expected.body, strings.TrimSpace(rr.Body.String()))
+			assert.Equal(t, tt.expected.replayed, rr.Header().Get(IdempotentReplayed))
+			assert.Equal(t, tt.expected.handled, handled)
+		})
+	}
+}
+
+func Test_Fingerprint(t *testing.T) {
+	post := httptest.NewRequest(http.MethodPost, "/api/v1/movies", nil)
+	put := httptest.NewRequest(http.MethodPut, "/api/v1/movies/27205", nil)
+
+	assert.Equal(t, fingerprint(post, []byte(`{}`)), fingerprint(post, []byte(`{}`)))
+	assert.Len(t, fingerprint(post, []byte(`{}`)), 64)
+	assert.NotEqual(t, fingerprint(post, []byte(`{}`)), fingerprint(post, []byte(`{ "id": 1 }`)))
+	assert.NotEqual(t, fingerprint(post, []byte(`{}`)), fingerprint(put, []byte(`{}`)))
+}
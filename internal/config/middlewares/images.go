@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const (
+	ImagesParam = "images"
+)
+
+type ImagesMiddleware interface {
+	Variants(next http.Handler) http.Handler
+}
+
+type imagesMiddleware struct{}
+
+func NewImagesMiddleware() ImagesMiddleware {
+	return &imagesMiddleware{}
+}
+
+// Variants lets clients opt in to full image URLs in size presets with the images query parameter
+func (m *imagesMiddleware) Variants(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enabled, _ := strconv.ParseBool(r.URL.Query().Get(ImagesParam))
+
+		ctx := NewContextModifier(r.Context()).
+			WithImageVariants(enabled).
+			Context()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
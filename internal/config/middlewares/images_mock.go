@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/middlewares/images.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/middlewares/images.go -destination=internal/config/middlewares/images_mock.go -package=middlewares
+//
+
+// Package middlewares is a generated GoMock package.
+package middlewares
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImagesMiddleware is a mock of ImagesMiddleware interface.
+type MockImagesMiddleware struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagesMiddlewareMockRecorder
+	isgomock struct{}
+}
+
+// MockImagesMiddlewareMockRecorder is the mock recorder for MockImagesMiddleware.
+type MockImagesMiddlewareMockRecorder struct {
+	mock *MockImagesMiddleware
+}
+
+// NewMockImagesMiddleware creates a new mock instance.
+func NewMockImagesMiddleware(ctrl *gomock.Controller) *MockImagesMiddleware {
+	mock := &MockImagesMiddleware{ctrl: ctrl}
+	mock.recorder = &MockImagesMiddlewareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImagesMiddleware) EXPECT() *MockImagesMiddlewareMockRecorder {
+	return m.recorder
+}
+
+// Variants mocks base method.
+func (m *MockImagesMiddleware) Variants(next http.Handler) http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Variants", next)
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// Variants indicates an expected call of Variants.
+func (mr *MockImagesMiddlewareMockRecorder) Variants(next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Variants", reflect.TypeOf((*MockImagesMiddleware)(nil).Variants), next)
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_NewImagesMiddleware(t *testing.T) {
+	middleware := NewImagesMiddleware()
+	assert.NotNil(t, middleware)
+}
+
+func Test_ImagesMiddleware_Variants(t *testing.T) {
+	middleware := NewImagesMiddleware()
+
+	tests := []struct {
+		name     string
+		target   string
+		expected bool
+	}{
+		{name: "Enabled", target: "/test?images=true", expected: true},
+		{name: "Enabled with number", target: "/test?images=1", expected: true},
+		{name: "Disabled", target: "/test?images=false", expected: false},
+		{name: "Missing", target: "/test", expected: false},
+		{name: "Invalid", target: "/test?images=yes", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual bool
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actual = tmdb.ImageVariantsFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rr := httptest.NewRecorder()
+			middleware.Variants(handler).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"biinge-api/pkg/tmdb"
+)
+
+const (
+	AcceptLanguage = "Accept-Language"
+)
+
+type LocaleMiddleware interface {
+	Localize(next http.Handler) http.Handler
+}
+
+type localeMiddleware struct{}
+
+func NewLocaleMiddleware() LocaleMiddleware {
+	return &localeMiddleware{}
+}
+
+// Localize resolves the TMDB locale from the user preferences and the Accept-Language header,
+// fields left empty fall back to the TMDB client defaults
+func (m *localeMiddleware) Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := parseAcceptLanguage(r.Header.Get(AcceptLanguage))
+
+		if user, ok := CurrentUserFromContext(r.Context()); ok {
+			locale = tmdb.Locale{
+				Language: user.Language,
+				Region:   user.Region,
+			}.Merge(locale)
+		}
+
+		ctx := NewContextModifier(r.Context()).
+			WithLocale(locale).
+			Context()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// parseAcceptLanguage returns the most preferred valid locale of an Accept-Language header
+func parseAcceptLanguage(header string) tmdb.Locale {
+	type candidate struct {
+		locale  tmdb.Locale
+		quality float64
+	}
+
+	candidates := make([]candidate, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		locale, ok := tmdb.ParseLocale(tag)
+		if !ok || quality <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, candidate{locale: locale, quality: quality})
+	}
+
+	if len(candidates) == 0 {
+		return tmdb.Locale{}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].locale
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/config/middlewares/locale.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/config/middlewares/locale.go -destination=internal/config/middlewares/locale_mock.go -package=middlewares
+//
+
+// Package middlewares is a generated GoMock package.
+package middlewares
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLocaleMiddleware is a mock of LocaleMiddleware interface.
+type MockLocaleMiddleware struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocaleMiddlewareMockRecorder
+	isgomock struct{}
+}
+
+// MockLocaleMiddlewareMockRecorder is the mock recorder for MockLocaleMiddleware.
+type MockLocaleMiddlewareMockRecorder struct {
+	mock *MockLocaleMiddleware
+}
+
+// NewMockLocaleMiddleware creates a new mock instance.
+func NewMockLocaleMiddleware(ctrl *gomock.Controller) *MockLocaleMiddleware {
+	mock := &MockLocaleMiddleware{ctrl: ctrl}
+	mock.recorder = &MockLocaleMiddlewareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocaleMiddleware) EXPECT() *MockLocaleMiddlewareMockRecorder {
+	return m.recorder
+}
+
+// Localize mocks base method.
+func (m *MockLocaleMiddleware) Localize(next http.Handler) http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Localize", next)
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// Localize indicates an expected call of Localize.
+func (mr *MockLocaleMiddlewareMockRecorder) Localize(next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Localize", reflect.TypeOf((*MockLocaleMiddleware)(nil).Localize), next)
+}
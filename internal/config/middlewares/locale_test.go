@@ -0,0 +1,81 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/app/models"
+	"biinge-api/pkg/tmdb"
+)
+
+func Test_NewLocaleMiddleware(t *testing.T) {
+	middleware := NewLocaleMiddleware()
+	assert.NotNil(t, middleware)
+}
+
+func Test_LocaleMiddleware_Localize(t *testing.T) {
+	middleware := NewLocaleMiddleware()
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		currentUser    *models.User
+		expected       tmdb.Locale
+	}{
+		{
+			name:           "Accept-Language",
+			acceptLanguage: "fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5",
+			expected:       tmdb.Locale{Language: "fr", Region: "CH"},
+		},
+		{
+			name:           "Accept-Language with quality order",
+			acceptLanguage: "en;q=0.5, de-de;q=0.8",
+			expected:       tmdb.Locale{Language: "de", Region: "DE"},
+		},
+		{
+			name:           "User preferences",
+			acceptLanguage: "fr-CH",
+			currentUser:    &models.User{Language: "et", Region: "EE"},
+			expected:       tmdb.Locale{Language: "et", Region: "EE"},
+		},
+		{
+			name:           "User language with header region",
+			acceptLanguage: "fr-CH",
+			currentUser:    &models.User{Language: "de"},
+			expected:       tmdb.Locale{Language: "de", Region: "CH"},
+		},
+		{
+			name:           "Invalid header",
+			acceptLanguage: "*",
+			expected:       tmdb.Locale{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual tmdb.Locale
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actual, _ = tmdb.LocaleFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set(AcceptLanguage, tt.acceptLanguage)
+			if tt.currentUser != nil {
+				ctx := NewContextModifier(req.Context()).
+					WithCurrentUser(tt.currentUser).
+					Context()
+				req = req.WithContext(ctx)
+			}
+
+			rr := httptest.NewRecorder()
+			middleware.Localize(handler).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
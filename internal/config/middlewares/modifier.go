@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"biinge-api/internal/app/models"
+	"biinge-api/pkg/tmdb"
 )
 
 type Claim struct{}
@@ -14,6 +15,8 @@ type CurrentUser struct{}
 type Modifier interface {
 	WithTraceId(traceId string) Modifier
 	WithCurrentUser(user *models.User) Modifier
+	WithLocale(locale tmdb.Locale) Modifier
+	WithImageVariants(enabled bool) Modifier
 	Context() context.Context
 }
 
@@ -35,6 +38,16 @@ func (m *modifier) WithCurrentUser(user *models.User) Modifier {
 	return m
 }
 
+func (m *modifier) WithLocale(locale tmdb.Locale) Modifier {
+	m.ctx = tmdb.WithLocale(m.ctx, locale)
+	return m
+}
+
+func (m *modifier) WithImageVariants(enabled bool) Modifier {
+	m.ctx = tmdb.WithImageVariants(m.ctx, enabled)
+	return m
+}
+
 func (m *modifier) Context() context.Context {
 	return m.ctx
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"biinge-api/internal/app/models"
+	"biinge-api/pkg/tmdb"
 )
 
 func Test_NewContextModifier(t *testing.T) {
@@ -83,3 +84,23 @@ func Test_Modifier_WithCurrentUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_Modifier_WithLocale(t *testing.T) {
+	ctx := context.Background()
+	locale := tmdb.Locale{Language: "et", Region: "EE"}
+
+	ctxModifier := NewContextModifier(ctx).WithLocale(locale)
+
+	result, ok := tmdb.LocaleFromContext(ctxModifier.Context())
+	assert.True(t, ok)
+	assert.Equal(t, locale, result)
+}
+
+func Test_Modifier_WithImageVariants(t *testing.T) {
+	ctx := context.Background()
+
+	ctxModifier := NewContextModifier(ctx).WithImageVariants(true)
+
+	assert.True(t, tmdb.ImageVariantsFromContext(ctxModifier.Context()))
+	assert.False(t, tmdb.ImageVariantsFromContext(ctx))
+}
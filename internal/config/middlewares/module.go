@@ -4,6 +4,9 @@ import "go.uber.org/fx"
 
 var Module = fx.Options(
 	fx.Provide(NewAuthenticationMiddleware),
+	fx.Provide(NewIdempotencyMiddleware),
+	fx.Provide(NewImagesMiddleware),
+	fx.Provide(NewLocaleMiddleware),
 	fx.Provide(NewLoggerMiddleware),
 	fx.Provide(NewTraceMiddleware),
 )
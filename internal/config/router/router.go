@@ -15,13 +15,23 @@ import (
 func NewRouter(
 	cfg *config.Config,
 	authentication middlewares.AuthenticationMiddleware,
+	locale middlewares.LocaleMiddleware,
+	imageVariants middlewares.ImagesMiddleware,
+	idempotency middlewares.IdempotencyMiddleware,
 	tracer middlewares.TraceMiddleware,
 	logger middlewares.LoggerMiddleware,
 	health controllers.HealthController,
 	sessions controllers.AuthenticationController,
 	accounts controllers.AccountsController,
 	movies controllers.MoviesController,
+	tags controllers.TagsController,
+	trash controllers.TrashController,
+	history controllers.HistoryController,
 	people controllers.PeopleController,
+	watchProviders controllers.WatchProvidersController,
+	collections controllers.CollectionsController,
+	stats controllers.StatsController,
+	images controllers.ImagesController,
 ) http.Handler {
 	r := chi.NewRouter()
 
@@ -37,8 +47,8 @@ func NewRouter(
 		cors.Handler(cors.Options{
 			AllowedOrigins: []string{"http://*", cfg.ClientURL},
 			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-			AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "X-Trace-ID"},
-			ExposedHeaders: []string{"X-Request-ID", "X-Trace-ID"},
+			AllowedHeaders: []string{"Accept", "Accept-Language", "Authorization", "Content-Type", "Idempotency-Key", "X-Request-ID", "X-Trace-ID"},
+			ExposedHeaders: []string{"Idempotent-Replayed", "X-Request-ID", "X-Trace-ID"},
 			MaxAge:         300,
 		}),
 	)
@@ -49,6 +59,9 @@ func NewRouter(
 	r.Get("/live", health.HandleLiveness)
 	r.Get("/ready", health.HandleReadiness)
 
+	// NOTE: public so <img> tags can load images without a bearer token
+	r.Get("/images/{size}/{path}", images.HandleShow)
+
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Route("/users", func(r chi.Router) {
 			r.Post("/registrations", sessions.HandleRegistration)
@@ -57,6 +70,9 @@ func NewRouter(
 
 		r.Group(func(r chi.Router) {
 			r.Use(authentication.Authenticate)
+			r.Use(locale.Localize)
+			r.Use(imageVariants.Variants)
+			r.Use(idempotency.Idempotent)
 
 			r.Route("/accounts", func(r chi.Router) {
 				r.Get("/me", accounts.Me)
@@ -65,15 +81,47 @@ func NewRouter(
 
 			r.Route("/movies", func(r chi.Router) {
 				r.Get("/", movies.HandleList)
+				r.Get("/continue-watching", movies.HandleContinueWatching)
 				r.Get("/{id}", movies.HandleDetails)
 				r.Post("/", movies.HandleCreate)
+				r.Post("/bulk", movies.HandleBulk)
+				r.Put("/{id}", movies.HandleUpsert)
 				r.Patch("/{id}", movies.HandleUpdate)
 				r.Delete("/{id}", movies.HandleDelete)
+				r.Post("/{id}/move", movies.HandleMove)
+				r.Put("/{id}/notes", movies.HandleNotes)
+				r.Put("/{id}/tags", tags.HandleSetMovieTags)
+				r.Get("/{id}/history", history.HandleMovie)
+			})
+
+			r.Route("/tags", func(r chi.Router) {
+				r.Get("/", tags.HandleList)
+				r.Post("/", tags.HandleCreate)
+				r.Patch("/{id}", tags.HandleUpdate)
+				r.Delete("/{id}", tags.HandleDelete)
+			})
+
+			r.Get("/history", history.HandleList)
+
+			r.Route("/trash", func(r chi.Router) {
+				r.Get("/", trash.HandleList)
+				r.Delete("/", trash.HandleEmpty)
+				r.Post("/{id}/restore", trash.HandleRestore)
 			})
 
 			r.Route("/people", func(r chi.Router) {
 				r.Get("/{id}", people.HandleDetails)
 			})
+
+			r.Route("/collections", func(r chi.Router) {
+				r.Get("/{id}", collections.HandleDetails)
+			})
+
+			r.Route("/stats", func(r chi.Router) {
+				r.Get("/people", stats.HandlePeople)
+			})
+
+			r.Get("/watch-providers", watchProviders.HandleList)
 		})
 	})
 
@@ -25,11 +25,21 @@ func Test_HealthCheck(t *testing.T) {
 	mockTraceMiddleware := middlewares.NewMockTraceMiddleware(ctrl)
 	mockLoggerMiddleware := middlewares.NewMockLoggerMiddleware(ctrl)
 	mockAuthenticationMiddleware := middlewares.NewMockAuthenticationMiddleware(ctrl)
+	mockLocaleMiddleware := middlewares.NewMockLocaleMiddleware(ctrl)
+	mockImagesMiddleware := middlewares.NewMockImagesMiddleware(ctrl)
+	mockIdempotencyMiddleware := middlewares.NewMockIdempotencyMiddleware(ctrl)
 	mockHealthController := controllers.NewMockHealthController(ctrl)
 	mockSessionsController := controllers.NewMockAuthenticationController(ctrl)
 	mockAccountsController := controllers.NewMockAccountsController(ctrl)
 	mockMoviesController := controllers.NewMockMoviesController(ctrl)
+	mockTagsController := controllers.NewMockTagsController(ctrl)
+	mockTrashController := controllers.NewMockTrashController(ctrl)
+	mockHistoryController := controllers.NewMockHistoryController(ctrl)
 	mockPeopleController := controllers.NewMockPeopleController(ctrl)
+	mockWatchProvidersController := controllers.NewMockWatchProvidersController(ctrl)
+	mockCollectionsController := controllers.NewMockCollectionsController(ctrl)
+	mockStatsController := controllers.NewMockStatsController(ctrl)
+	mockImagesController := controllers.NewMockImagesController(ctrl)
 
 	mockAuthenticationMiddleware.EXPECT().
 		Authenticate(gomock.Any()).
@@ -37,6 +47,24 @@ func Test_HealthCheck(t *testing.T) {
 		DoAndReturn(func(next http.Handler) http.Handler {
 			return next
 		})
+	mockLocaleMiddleware.EXPECT().
+		Localize(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(next http.Handler) http.Handler {
+			return next
+		})
+	mockImagesMiddleware.EXPECT().
+		Variants(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(next http.Handler) http.Handler {
+			return next
+		})
+	mockIdempotencyMiddleware.EXPECT().
+		Idempotent(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(next http.Handler) http.Handler {
+			return next
+		})
 	mockTraceMiddleware.EXPECT().
 		Trace(gomock.Any()).
 		AnyTimes().
@@ -53,13 +81,23 @@ func Test_HealthCheck(t *testing.T) {
 	router := NewRouter(
 		cfg,
 		mockAuthenticationMiddleware,
+		mockLocaleMiddleware,
+		mockImagesMiddleware,
+		mockIdempotencyMiddleware,
 		mockTraceMiddleware,
 		mockLoggerMiddleware,
 		mockHealthController,
 		mockSessionsController,
 		mockAccountsController,
 		mockMoviesController,
+		mockTagsController,
+		mockTrashController,
+		mockHistoryController,
 		mockPeopleController,
+		mockWatchProvidersController,
+		mockCollectionsController,
+		mockStatsController,
+		mockImagesController,
 	)
 
 	req := httptest.NewRequest(http.MethodHead, "/health", nil)
@@ -27,11 +27,21 @@ func Test_NewServer(t *testing.T) {
 	mockTraceMiddleware := middlewares.NewMockTraceMiddleware(ctrl)
 	mockLoggerMiddleware := middlewares.NewMockLoggerMiddleware(ctrl)
 	mockAuthenticationMiddleware := middlewares.NewMockAuthenticationMiddleware(ctrl)
+	mockLocaleMiddleware := middlewares.NewMockLocaleMiddleware(ctrl)
+	mockImagesMiddleware := middlewares.NewMockImagesMiddleware(ctrl)
+	mockIdempotencyMiddleware := middlewares.NewMockIdempotencyMiddleware(ctrl)
 	mockHealthController := controllers.NewMockHealthController(ctrl)
 	mockSessionsController := controllers.NewMockAuthenticationController(ctrl)
 	mockAccountsController := controllers.NewMockAccountsController(ctrl)
 	mockMoviesController := controllers.NewMockMoviesController(ctrl)
+	mockTagsController := controllers.NewMockTagsController(ctrl)
+	mockTrashController := controllers.NewMockTrashController(ctrl)
+	mockHistoryController := controllers.NewMockHistoryController(ctrl)
 	mockPeopleController := controllers.NewMockPeopleController(ctrl)
+	mockWatchProvidersController := controllers.NewMockWatchProvidersController(ctrl)
+	mockCollectionsController := controllers.NewMockCollectionsController(ctrl)
+	mockStatsController := controllers.NewMockStatsController(ctrl)
+	mockImagesController := controllers.NewMockImagesController(ctrl)
 
 	mockAuthenticationMiddleware.EXPECT().
 		Authenticate(gomock.Any()).
@@ -39,6 +49,24 @@ func Test_NewServer(t *testing.T) {
 		DoAndReturn(func(next http.Handler) http.Handler {
 			return next
 		})
+	mockLocaleMiddleware.EXPECT().
+		Localize(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(next http.Handler) http.Handler {
+			return next
+		})
+	mockImagesMiddleware.EXPECT().
+		Variants(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(next http.Handler) http.Handler {
+			return next
+		})
+	mockIdempotencyMiddleware.EXPECT().
+		Idempotent(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(next http.Handler) http.Handler {
+			return next
+		})
 	mockTraceMiddleware.EXPECT().
 		Trace(gomock.Any()).
 		AnyTimes().
@@ -55,13 +83,23 @@ func Test_NewServer(t *testing.T) {
 	appRouter := router.NewRouter(
 		cfg,
 		mockAuthenticationMiddleware,
+		mockLocaleMiddleware,
+		mockImagesMiddleware,
+		mockIdempotencyMiddleware,
 		mockTraceMiddleware,
 		mockLoggerMiddleware,
 		mockHealthController,
 		mockSessionsController,
 		mockAccountsController,
 		mockMoviesController,
+		mockTagsController,
+		mockTrashController,
+		mockHistoryController,
 		mockPeopleController,
+		mockWatchProvidersController,
+		mockCollectionsController,
+		mockStatsController,
+		mockImagesController,
 	)
 
 	srv := NewServer(cfg, appRouter)
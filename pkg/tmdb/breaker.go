@@ -0,0 +1,132 @@
+package tmdb
+
+import (
+	"sync"
+	"time"
+)
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// CircuitBreaker stops calling TMDB after consecutive failures and lets a
+// single probe request through once the cooldown has passed. Every state change
+// starts a new generation, the outcome of a request admitted in an earlier one
+// is ignored so only the probe decides on a half-open breaker
+type CircuitBreaker struct {
+	mu         sync.Mutex
+	state      BreakerState
+	generation uint64
+	failures   int
+	threshold  int
+	cooldown   time.Duration
+	openedAt   time.Time
+	probing    bool
+	now        func() time.Time
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &CircuitBreaker{
+		state:     BreakerClosed,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a request may be sent, returning an *UnavailableError otherwise.
+// The generation it returns is handed back to Record or Release along the outcome
+func (b *CircuitBreaker) Allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return 0, &UnavailableError{RetryAfter: b.retryAfter()}
+		}
+
+		b.transition(BreakerHalfOpen)
+		b.probing = true
+		return b.generation, nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return 0, &UnavailableError{RetryAfter: b.cooldown}
+		}
+
+		b.probing = true
+		return b.generation, nil
+	default:
+		return b.generation, nil
+	}
+}
+
+// Record stores the outcome of a request allowed in generation, outcomes of earlier generations are ignored
+func (b *CircuitBreaker) Record(generation uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	b.probing = false
+
+	if success {
+		if b.state != BreakerClosed {
+			b.transition(BreakerClosed)
+		}
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.transition(BreakerOpen)
+		b.openedAt = b.now()
+	}
+}
+
+// Release frees the probe slot of a request allowed in generation and abandoned by its caller
+func (b *CircuitBreaker) Release(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation == b.generation {
+		b.probing = false
+	}
+}
+
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+
+	return b.state
+}
+
+// transition must be called with the lock held
+func (b *CircuitBreaker) transition(state BreakerState) {
+	b.state = state
+	b.generation++
+}
+
+func (b *CircuitBreaker) retryAfter() time.Duration {
+	remaining := b.cooldown - b.now().Sub(b.openedAt)
+	if remaining < time.Second {
+		return time.Second
+	}
+
+	return remaining
+}
@@ -0,0 +1,114 @@
+package tmdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CircuitBreaker(t *testing.T) {
+	now := time.Now()
+
+	newBreaker := func() *CircuitBreaker {
+		breaker := NewCircuitBreaker(2, 30*time.Second)
+		breaker.now = func() time.Time { return now }
+		return breaker
+	}
+
+	allowed := func(t *testing.T, breaker *CircuitBreaker) uint64 {
+		generation, err := breaker.Allow()
+		assert.NoError(t, err)
+		return generation
+	}
+
+	open := func(t *testing.T, breaker *CircuitBreaker) {
+		breaker.Record(allowed(t, breaker), false)
+		breaker.Record(allowed(t, breaker), false)
+	}
+
+	t.Run("Opens after threshold failures", func(t *testing.T) {
+		breaker := newBreaker()
+
+		breaker.Record(allowed(t, breaker), false)
+		assert.Equal(t, BreakerClosed, breaker.State())
+
+		breaker.Record(allowed(t, breaker), false)
+		assert.Equal(t, BreakerOpen, breaker.State())
+
+		_, err := breaker.Allow()
+		assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+
+		var unavailable *UnavailableError
+		assert.ErrorAs(t, err, &unavailable)
+		assert.Equal(t, 30*time.Second, unavailable.RetryAfter)
+	})
+
+	t.Run("Success resets failures", func(t *testing.T) {
+		breaker := newBreaker()
+
+		breaker.Record(allowed(t, breaker), false)
+		breaker.Record(allowed(t, breaker), true)
+		breaker.Record(allowed(t, breaker), false)
+
+		assert.Equal(t, BreakerClosed, breaker.State())
+	})
+
+	t.Run("Half-open allows a single probe", func(t *testing.T) {
+		breaker := newBreaker()
+		open(t, breaker)
+
+		now = now.Add(31 * time.Second)
+		assert.Equal(t, BreakerHalfOpen, breaker.State())
+
+		probe := allowed(t, breaker)
+		_, err := breaker.Allow()
+		assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+
+		breaker.Record(probe, true)
+		assert.Equal(t, BreakerClosed, breaker.State())
+		allowed(t, breaker)
+	})
+
+	t.Run("Failed probe reopens", func(t *testing.T) {
+		breaker := newBreaker()
+		open(t, breaker)
+
+		now = now.Add(31 * time.Second)
+		breaker.Record(allowed(t, breaker), false)
+
+		assert.Equal(t, BreakerOpen, breaker.State())
+		_, err := breaker.Allow()
+		assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+	})
+
+	t.Run("Released probe frees the slot", func(t *testing.T) {
+		breaker := newBreaker()
+		open(t, breaker)
+
+		now = now.Add(31 * time.Second)
+		breaker.Release(allowed(t, breaker))
+		allowed(t, breaker)
+	})
+
+	t.Run("Ignores requests allowed before the breaker opened", func(t *testing.T) {
+		breaker := newBreaker()
+		late := allowed(t, breaker)
+		open(t, breaker)
+
+		breaker.Record(late, true)
+		assert.Equal(t, BreakerOpen, breaker.State())
+
+		now = now.Add(31 * time.Second)
+		probe := allowed(t, breaker)
+
+		breaker.Record(late, true)
+		breaker.Release(late)
+		assert.Equal(t, BreakerHalfOpen, breaker.State())
+		_, err := breaker.Allow()
+		assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+
+		breaker.Record(probe, false)
+		assert.Equal(t, BreakerOpen, breaker.State())
+	})
+}
@@ -2,52 +2,87 @@ package tmdb
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"golang.org/x/sync/singleflight"
 
 	"biinge-api/internal/config"
 	"biinge-api/internal/config/logger"
 )
 
+var DefaultLocale = Locale{Language: "en", Region: "US"}
+
 const (
 	DefaultTimeout = 10 * time.Second
 
+	DefaultRateLimit        = 40
+	DefaultRateBurst        = 20
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+
 	MaxIdleConnections        = 10000
 	MaxIdleConnectionsPerHost = 10000
 	IdleConnTimeout           = 90 * time.Second
 	TLSHandshakeTimeout       = 10 * time.Second
+
+	// ChangesDateLayout is the date format of the /changes endpoints, one query spans at most MaxChangesWindow
+	ChangesDateLayout = "2006-01-02"
+	MaxChangesWindow  = 14 * 24 * time.Hour
 )
 
 type Client interface {
 	FetchMovieDetails(ctx context.Context, id uint64) (*MovieDetails, error)
+	FetchMovieSummary(ctx context.Context, id uint64) (*MovieDetails, error)
+	FetchMovieCredits(ctx context.Context, id uint64) (*Credits, error)
+	FetchCollectionDetails(ctx context.Context, id uint64) (*CollectionDetails, error)
 	FetchTvDetails(ctx context.Context, id uint64) (*TvDetails, error)
 	FetchTvSeasonDetails(ctx context.Context, id uint64, seasonNumber uint64) (*SeasonDetails, error)
 	FetchTvEpisodeDetails(ctx context.Context, id uint64, seasonNumber uint64, episodeNumber uint64) (*EpisodeDetails, error)
 	FetchPersonDetails(ctx context.Context, id uint64) (*PersonDetails, error)
-
-	WithApiReadAccessToken(apiReadAccessToken string) Client
-	WithLocale(lang string) Client
-	WithTimeout(timeout time.Duration) Client
+	FetchWatchProviders(ctx context.Context, region string) (*WatchProviderList, error)
+	FetchMovieWatchProviders(ctx context.Context, id uint64) (*WatchProviders, error)
+	FetchConfiguration(ctx context.Context) (*Configuration, error)
+	FetchMovieChanges(ctx context.Context, start time.Time, end time.Time, page int) (*Changes, error)
+	FetchTvChanges(ctx context.Context, start time.Time, end time.Time, page int) (*Changes, error)
+
+	Locale(ctx context.Context) Locale
+	BreakerState() BreakerState
+	Metrics() []EndpointMetrics
 }
 
 type client struct {
-	cfg       *config.Config
+	baseURL   string
+	locale    Locale
 	apiClient *resty.Client
+	limiter   *RateLimiter
+	breaker   *CircuitBreaker
+	timeout   time.Duration
+	flights   singleflight.Group
+	metrics   *metrics
 	log       *logger.Logger
 }
 
 func NewClient(cfg *config.Config, log *logger.Logger) Client {
+	timeout := cfg.TMDBConfig.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	locale, ok := ParseLocale(cfg.TMDBConfig.Locale)
+	if !ok {
+		locale = DefaultLocale
+	}
+
 	apiClient := resty.New()
 
 	apiClient.
 		SetHeader("Accept", "application/json").
 		SetHeader("Content-Type", "application/json").
 		SetHeader("Authorization", fmt.Sprintf("Bearer %s", cfg.TMDBConfig.APIReadAccessToken)).
-		SetTimeout(DefaultTimeout)
+		SetTimeout(timeout)
 
 	apiClient.SetTransport(&http.Transport{
 		MaxIdleConns:        MaxIdleConnections,
@@ -56,320 +91,179 @@ func NewClient(cfg *config.Config, log *logger.Logger) Client {
 		TLSHandshakeTimeout: TLSHandshakeTimeout,
 	})
 
+	rateLimit := cfg.TMDBConfig.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = DefaultRateLimit
+	}
+
+	threshold := cfg.TMDBConfig.BreakerThreshold
+	if threshold <= 0 {
+		threshold = DefaultBreakerThreshold
+	}
+
+	cooldown := cfg.TMDBConfig.BreakerCooldown
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+
 	return &client{
-		cfg:       cfg,
+		baseURL:   cfg.TMDBConfig.BaseURL,
+		locale:    locale,
 		apiClient: apiClient,
+		limiter:   NewRateLimiter(rateLimit, DefaultRateBurst),
+		breaker:   NewCircuitBreaker(threshold, cooldown),
+		timeout:   timeout,
+		metrics:   newMetrics(),
 		log:       log.WithComponent("TmdbClient"),
 	}
 }
 
-//nolint:dupl
-func (c *client) FetchMovieDetails(ctx context.Context, id uint64) (*MovieDetails, error) {
-	endpoint := fmt.Sprintf("%s/movie/%d", c.cfg.TMDBConfig.BaseURL, id)
-
-	c.log.Debug().
-		Str("endpoint", endpoint).
-		Uint64("Id", id).
-		Msg("Fetching movie details")
-
-	response, err := c.apiClient.R().
-		SetContext(ctx).
-		SetQueryParam("language", c.cfg.TMDBConfig.Locale).
-		SetQueryParam("append_to_response", "credits,recommendations,videos").
-		Get(endpoint)
-	if err != nil {
-		c.log.Error().
-			Err(err).
-			Uint64("Id", id).
-			Msg("Failed to fetch movie details")
-		return nil, err
-	}
+func (c *client) BreakerState() BreakerState {
+	return c.breaker.State()
+}
 
-	switch response.StatusCode() {
-	case http.StatusOK:
-		var result MovieDetails
-		if err = json.Unmarshal(response.Body(), &result); err != nil {
-			c.log.Error().
-				Err(err).
-				Uint64("Id", id).
-				Msg("Failed to parse movie details response")
-			return nil, err
-		}
+func (c *client) Metrics() []EndpointMetrics {
+	return c.metrics.snapshot()
+}
 
-		return &result, nil
-	case http.StatusUnauthorized, http.StatusForbidden:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Access forbidden to TMDB API")
-		return nil, ErrAccessForbidden
-	case http.StatusNotFound:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Movie not found in TMDB API")
-		return nil, ErrNotFound
-	default:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Unexpected response from TMDB API")
-		return nil, ErrUnexpectedResponse
-	}
+// Locale returns the locale requests made with ctx are localized for, missing parts come from the configured locale
+func (c *client) Locale(ctx context.Context) Locale {
+	locale, _ := LocaleFromContext(ctx)
+	return locale.Merge(c.locale)
 }
 
-//nolint:dupl
-func (c *client) FetchTvDetails(ctx context.Context, id uint64) (*TvDetails, error) {
-	endpoint := fmt.Sprintf("%s/tv/%d", c.cfg.TMDBConfig.BaseURL, id)
-
-	c.log.Debug().
-		Str("endpoint", endpoint).
-		Uint64("Id", id).
-		Msg("Fetching tv show details")
-
-	response, err := c.apiClient.R().
-		SetContext(ctx).
-		SetQueryParam("language", c.cfg.TMDBConfig.Locale).
-		SetQueryParam("append_to_response", "credits,recommendations,videos").
-		Get(endpoint)
-	if err != nil {
-		c.log.Error().
-			Err(err).
-			Uint64("Id", id).
-			Msg("Failed to fetch tv details")
-		return nil, err
+// languageFor returns the language parameter, the configured region is not mixed into a requested language
+func (c *client) languageFor(ctx context.Context) string {
+	if locale, ok := LocaleFromContext(ctx); ok && locale.Language != "" {
+		return locale.Tag()
 	}
 
-	switch response.StatusCode() {
-	case http.StatusOK:
-		var result TvDetails
-		if err = json.Unmarshal(response.Body(), &result); err != nil {
-			c.log.Error().
-				Err(err).
-				Uint64("Id", id).
-				Msg("Failed to parse tv details response")
-			return nil, err
+	return c.locale.Tag()
+}
+
+// execute coalesces concurrent identical requests into a single upstream call,
+// the shared call is detached from the callers so one of them cancelling does not affect the others
+func (c *client) execute(ctx context.Context, request *resty.Request, endpoint string) (*resty.Response, error) {
+	key := fmt.Sprintf("%s?%s", endpoint, request.QueryParam.Encode())
+
+	result := c.flights.DoChan(key, func() (interface{}, error) {
+		return c.send(request.SetContext(context.WithoutCancel(ctx)), endpoint)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
 		}
 
-		return &result, nil
-	case http.StatusUnauthorized, http.StatusForbidden:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Access forbidden to TMDB API")
-		return nil, ErrAccessForbidden
-	case http.StatusNotFound:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Tv show not found in TMDB API")
-		return nil, ErrNotFound
-	default:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Unexpected response from TMDB API")
-		return nil, ErrUnexpectedResponse
+		if res.Shared {
+			c.log.Debug().
+				Str("endpoint", endpoint).
+				Msg("Shared in-flight request to TMDB API")
+		}
+
+		return res.Val.(*resty.Response), nil
 	}
 }
 
-//nolint:dupl
-func (c *client) FetchTvSeasonDetails(ctx context.Context, tvId uint64, seasonNumber uint64) (*SeasonDetails, error) {
-	endpoint := fmt.Sprintf("%s/tv/%d/season/%d", c.cfg.TMDBConfig.BaseURL, tvId, seasonNumber)
+// send passes the request through the circuit breaker and rate limiter. The request is detached from its
+// callers, so it waits for the rate limiter at most as long as the request may take and is abandoned then
+func (c *client) send(request *resty.Request, endpoint string) (*resty.Response, error) {
+	generation, err := c.breaker.Allow()
+	if err != nil {
+		c.log.Warn().
+			Str("endpoint", endpoint).
+			Msg("Circuit breaker is open, skipping request to TMDB API")
+		return nil, err
+	}
 
-	c.log.Debug().
-		Str("endpoint", endpoint).
-		Uint64("TvId", tvId).
-		Uint64("SeasonNumber", seasonNumber).
-		Msg("Fetching TV season details")
+	wait, cancel := context.WithTimeout(request.Context(), c.timeout)
+	defer cancel()
 
-	response, err := c.apiClient.R().
-		SetContext(ctx).
-		SetQueryParam("language", c.cfg.TMDBConfig.Locale).
-		Get(endpoint)
+	if err := c.limiter.Wait(wait); err != nil {
+		c.log.Warn().
+			Str("endpoint", endpoint).
+			Msg("Rate limit wait timed out, skipping request to TMDB API")
+		c.breaker.Release(generation)
+		return nil, err
+	}
 
+	response, err := request.Get(endpoint)
 	if err != nil {
-		c.log.Error().
-			Err(err).
-			Uint64("TvId", tvId).
-			Uint64("SeasonNumber", seasonNumber).
-			Msg("Failed to fetch TV season details")
+		c.breaker.Record(generation, false)
 		return nil, err
 	}
 
-	switch response.StatusCode() {
-	case http.StatusOK:
-		var result SeasonDetails
-		if err = json.Unmarshal(response.Body(), &result); err != nil {
-			c.log.Error().
-				Err(err).
-				Uint64("TvId", tvId).
-				Uint64("SeasonNumber", seasonNumber).
-				Msg("Failed to parse TV season details response")
-			return nil, err
-		}
-
-		return &result, nil
-	case http.StatusUnauthorized, http.StatusForbidden:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("TvId", tvId).
-			Uint64("SeasonNumber", seasonNumber).
-			Msg("Access forbidden to TMDB API")
-		return nil, ErrAccessForbidden
-	case http.StatusNotFound:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("TvId", tvId).
-			Uint64("SeasonNumber", seasonNumber).
-			Msg("TV season not found in TMDB API")
-		return nil, ErrNotFound
+	switch {
+	case response.StatusCode() == http.StatusTooManyRequests, response.StatusCode() >= http.StatusInternalServerError:
+		c.breaker.Record(generation, false)
 	default:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("TvId", tvId).
-			Uint64("SeasonNumber", seasonNumber).
-			Msg("Unexpected response from TMDB API")
-		return nil, ErrUnexpectedResponse
+		c.breaker.Record(generation, true)
 	}
+
+	return response, nil
 }
 
-// nolint:dupl
-func (c *client) FetchTvEpisodeDetails(ctx context.Context, tvId uint64, seasonNumber uint64, episodeNumber uint64) (*EpisodeDetails, error) {
-	endpoint := fmt.Sprintf("%s/tv/%d/season/%d/episode/%d", c.cfg.TMDBConfig.BaseURL, tvId, seasonNumber, episodeNumber)
+func (c *client) FetchMovieDetails(ctx context.Context, id uint64) (*MovieDetails, error) {
+	return get[MovieDetails](ctx, c, fmt.Sprintf("/movie/%d", id), newQuery().
+		appendToResponse("credits", "recommendations", "videos", "external_ids", "release_dates", "watch/providers"))
+}
 
-	c.log.Debug().
-		Str("endpoint", endpoint).
-		Uint64("TvId", tvId).
-		Uint64("SeasonNumber", seasonNumber).
-		Uint64("EpisodeNumber", episodeNumber).
-		Msg("Fetching TV episode details")
+// FetchMovieSummary returns the movie without any appended sub-resources
+func (c *client) FetchMovieSummary(ctx context.Context, id uint64) (*MovieDetails, error) {
+	return get[MovieDetails](ctx, c, fmt.Sprintf("/movie/%d", id), nil)
+}
 
-	response, err := c.apiClient.R().
-		SetContext(ctx).
-		SetQueryParam("language", c.cfg.TMDBConfig.Locale).
-		Get(endpoint)
+func (c *client) FetchMovieCredits(ctx context.Context, id uint64) (*Credits, error) {
+	return get[Credits](ctx, c, fmt.Sprintf("/movie/%d/credits", id), nil)
+}
 
-	if err != nil {
-		c.log.Error().
-			Err(err).
-			Uint64("TvId", tvId).
-			Uint64("SeasonNumber", seasonNumber).
-			Uint64("EpisodeNumber", episodeNumber).
-			Msg("Failed to fetch TV episode details")
-		return nil, err
-	}
+func (c *client) FetchCollectionDetails(ctx context.Context, id uint64) (*CollectionDetails, error) {
+	return get[CollectionDetails](ctx, c, fmt.Sprintf("/collection/%d", id), nil)
+}
 
-	switch response.StatusCode() {
-	case http.StatusOK:
-		var result EpisodeDetails
-		if err = json.Unmarshal(response.Body(), &result); err != nil {
-			c.log.Error().
-				Err(err).
-				Uint64("TvId", tvId).
-				Uint64("SeasonNumber", seasonNumber).
-				Uint64("EpisodeNumber", episodeNumber).
-				Msg("Failed to parse TV episode details response")
-			return nil, err
-		}
+func (c *client) FetchTvDetails(ctx context.Context, id uint64) (*TvDetails, error) {
+	return get[TvDetails](ctx, c, fmt.Sprintf("/tv/%d", id), newQuery().
+		appendToResponse("credits", "recommendations", "videos", "watch/providers"))
+}
 
-		return &result, nil
-	case http.StatusUnauthorized, http.StatusForbidden:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("TvId", tvId).
-			Uint64("SeasonNumber", seasonNumber).
-			Uint64("EpisodeNumber", episodeNumber).
-			Msg("Access forbidden to TMDB API")
-		return nil, ErrAccessForbidden
-	case http.StatusNotFound:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("TvId", tvId).
-			Uint64("SeasonNumber", seasonNumber).
-			Uint64("EpisodeNumber", episodeNumber).
-			Msg("TV episode not found in TMDB API")
-		return nil, ErrNotFound
-	default:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("TvId", tvId).
-			Uint64("SeasonNumber", seasonNumber).
-			Uint64("EpisodeNumber", episodeNumber).
-			Msg("Unexpected response from TMDB API")
-		return nil, ErrUnexpectedResponse
-	}
+func (c *client) FetchTvSeasonDetails(ctx context.Context, tvId uint64, seasonNumber uint64) (*SeasonDetails, error) {
+	return get[SeasonDetails](ctx, c, fmt.Sprintf("/tv/%d/season/%d", tvId, seasonNumber), nil)
+}
+
+func (c *client) FetchTvEpisodeDetails(ctx context.Context, tvId uint64, seasonNumber uint64, episodeNumber uint64) (*EpisodeDetails, error) {
+	return get[EpisodeDetails](ctx, c, fmt.Sprintf("/tv/%d/season/%d/episode/%d", tvId, seasonNumber, episodeNumber), nil)
 }
 
-//nolint:dupl
 func (c *client) FetchPersonDetails(ctx context.Context, id uint64) (*PersonDetails, error) {
-	endpoint := fmt.Sprintf("%s/person/%d", c.cfg.TMDBConfig.BaseURL, id)
-
-	c.log.Debug().
-		Str("endpoint", endpoint).
-		Uint64("Id", id).
-		Msg("Fetching person details")
-
-	response, err := c.apiClient.R().
-		SetContext(ctx).
-		SetQueryParam("language", c.cfg.TMDBConfig.Locale).
-		SetQueryParam("append_to_response", "credits,tv_credits").
-		Get(endpoint)
-	if err != nil {
-		c.log.Error().
-			Err(err).
-			Uint64("Id", id).
-			Msg("Failed to fetch person details")
-		return nil, err
-	}
+	return get[PersonDetails](ctx, c, fmt.Sprintf("/person/%d", id), newQuery().
+		appendToResponse("credits", "tv_credits", "external_ids", "images"))
+}
 
-	switch response.StatusCode() {
-	case http.StatusOK:
-		var result PersonDetails
-		if err = json.Unmarshal(response.Body(), &result); err != nil {
-			c.log.Error().
-				Err(err).
-				Uint64("Id", id).
-				Msg("Failed to parse person details response")
-			return nil, err
-		}
+// FetchWatchProviders returns the catalogue of movie streaming services available in region
+func (c *client) FetchWatchProviders(ctx context.Context, region string) (*WatchProviderList, error) {
+	return get[WatchProviderList](ctx, c, "/watch/providers/movie", newQuery().
+		set("watch_region", region))
+}
 
-		return &result, nil
-	case http.StatusUnauthorized, http.StatusForbidden:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Access forbidden to TMDB API")
-		return nil, ErrAccessForbidden
-	case http.StatusNotFound:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Person not found in TMDB API")
-		return nil, ErrNotFound
-	default:
-		c.log.Error().
-			Int("statusCode", response.StatusCode()).
-			Uint64("Id", id).
-			Msg("Unexpected response from TMDB API")
-		return nil, ErrUnexpectedResponse
-	}
+// FetchMovieWatchProviders returns where a movie can be watched in every region
+func (c *client) FetchMovieWatchProviders(ctx context.Context, id uint64) (*WatchProviders, error) {
+	return get[WatchProviders](ctx, c, fmt.Sprintf("/movie/%d/watch/providers", id), nil)
 }
 
-func (c *client) WithApiReadAccessToken(apiReadAccessToken string) Client {
-	c.cfg.TMDBConfig.APIReadAccessToken = apiReadAccessToken
-	c.apiClient.SetHeader("Authorization", fmt.Sprintf("Bearer %s", apiReadAccessToken))
-	return c
+// FetchConfiguration returns the image base URL and the valid image sizes
+func (c *client) FetchConfiguration(ctx context.Context) (*Configuration, error) {
+	return get[Configuration](ctx, c, "/configuration", nil)
 }
 
-func (c *client) WithLocale(lang string) Client {
-	c.cfg.TMDBConfig.Locale = lang
-	c.apiClient.SetQueryParam("language", lang)
-	return c
+// FetchMovieChanges returns a page of the movies edited between the start and end dates
+func (c *client) FetchMovieChanges(ctx context.Context, start time.Time, end time.Time, page int) (*Changes, error) {
+	return get[Changes](ctx, c, "/movie/changes", newQuery().changes(start, end, page))
 }
 
-func (c *client) WithTimeout(timeout time.Duration) Client {
-	c.cfg.TMDBConfig.Timeout = timeout
-	c.apiClient.SetTimeout(timeout)
-	return c
+// FetchTvChanges returns a page of the series edited between the start and end dates
+func (c *client) FetchTvChanges(ctx context.Context, start time.Time, end time.Time, page int) (*Changes, error) {
+	return get[Changes](ctx, c, "/tv/changes", newQuery().changes(start, end, page))
 }
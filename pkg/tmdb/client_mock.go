@@ -0,0 +1,279 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/tmdb/client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/tmdb/client.go -destination=pkg/tmdb/client_mock.go -package=tmdb
+//
+
+// Package tmdb is a generated GoMock package.
+package tmdb
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+	isgomock struct{}
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// BreakerState mocks base method.
+func (m *MockClient) BreakerState() BreakerState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BreakerState")
+	ret0, _ := ret[0].(BreakerState)
+	return ret0
+}
+
+// BreakerState indicates an expected call of BreakerState.
+func (mr *MockClientMockRecorder) BreakerState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BreakerState", reflect.TypeOf((*MockClient)(nil).BreakerState))
+}
+
+// FetchCollectionDetails mocks base method.
+func (m *MockClient) FetchCollectionDetails(ctx context.Context, id uint64) (*CollectionDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCollectionDetails", ctx, id)
+	ret0, _ := ret[0].(*CollectionDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCollectionDetails indicates an expected call of FetchCollectionDetails.
+func (mr *MockClientMockRecorder) FetchCollectionDetails(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCollectionDetails", reflect.TypeOf((*MockClient)(nil).FetchCollectionDetails), ctx, id)
+}
+
+// FetchConfiguration mocks base method.
+func (m *MockClient) FetchConfiguration(ctx context.Context) (*Configuration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchConfiguration", ctx)
+	ret0, _ := ret[0].(*Configuration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchConfiguration indicates an expected call of FetchConfiguration.
+func (mr *MockClientMockRecorder) FetchConfiguration(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchConfiguration", reflect.TypeOf((*MockClient)(nil).FetchConfiguration), ctx)
+}
+
+// FetchMovieChanges mocks base method.
+func (m *MockClient) FetchMovieChanges(ctx context.Context, start, end time.Time, page int) (*Changes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMovieChanges", ctx, start, end, page)
+	ret0, _ := ret[0].(*Changes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMovieChanges indicates an expected call of FetchMovieChanges.
+func (mr *MockClientMockRecorder) FetchMovieChanges(ctx, start, end, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMovieChanges", reflect.TypeOf((*MockClient)(nil).FetchMovieChanges), ctx, start, end, page)
+}
+
+// FetchMovieCredits mocks base method.
+func (m *MockClient) FetchMovieCredits(ctx context.Context, id uint64) (*Credits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMovieCredits", ctx, id)
+	ret0, _ := ret[0].(*Credits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMovieCredits indicates an expected call of FetchMovieCredits.
+func (mr *MockClientMockRecorder) FetchMovieCredits(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMovieCredits", reflect.TypeOf((*MockClient)(nil).FetchMovieCredits), ctx, id)
+}
+
+// FetchMovieDetails mocks base method.
+func (m *MockClient) FetchMovieDetails(ctx context.Context, id uint64) (*MovieDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMovieDetails", ctx, id)
+	ret0, _ := ret[0].(*MovieDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMovieDetails indicates an expected call of FetchMovieDetails.
+func (mr *MockClientMockRecorder) FetchMovieDetails(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMovieDetails", reflect.TypeOf((*MockClient)(nil).FetchMovieDetails), ctx, id)
+}
+
+// FetchMovieSummary mocks base method.
+func (m *MockClient) FetchMovieSummary(ctx context.Context, id uint64) (*MovieDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMovieSummary", ctx, id)
+	ret0, _ := ret[0].(*MovieDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMovieSummary indicates an expected call of FetchMovieSummary.
+func (mr *MockClientMockRecorder) FetchMovieSummary(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMovieSummary", reflect.TypeOf((*MockClient)(nil).FetchMovieSummary), ctx, id)
+}
+
+// FetchMovieWatchProviders mocks base method.
+func (m *MockClient) FetchMovieWatchProviders(ctx context.Context, id uint64) (*WatchProviders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMovieWatchProviders", ctx, id)
+	ret0, _ := ret[0].(*WatchProviders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMovieWatchProviders indicates an expected call of FetchMovieWatchProviders.
+func (mr *MockClientMockRecorder) FetchMovieWatchProviders(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMovieWatchProviders", reflect.TypeOf((*MockClient)(nil).FetchMovieWatchProviders), ctx, id)
+}
+
+// FetchPersonDetails mocks base method.
+func (m *MockClient) FetchPersonDetails(ctx context.Context, id uint64) (*PersonDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPersonDetails", ctx, id)
+	ret0, _ := ret[0].(*PersonDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPersonDetails indicates an expected call of FetchPersonDetails.
+func (mr *MockClientMockRecorder) FetchPersonDetails(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPersonDetails", reflect.TypeOf((*MockClient)(nil).FetchPersonDetails), ctx, id)
+}
+
+// FetchTvChanges mocks base method.
+func (m *MockClient) FetchTvChanges(ctx context.Context, start, end time.Time, page int) (*Changes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTvChanges", ctx, start, end, page)
+	ret0, _ := ret[0].(*Changes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTvChanges indicates an expected call of FetchTvChanges.
+func (mr *MockClientMockRecorder) FetchTvChanges(ctx, start, end, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTvChanges", reflect.TypeOf((*MockClient)(nil).FetchTvChanges), ctx, start, end, page)
+}
+
+// FetchTvDetails mocks base method.
+func (m *MockClient) FetchTvDetails(ctx context.Context, id uint64) (*TvDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTvDetails", ctx, id)
+	ret0, _ := ret[0].(*TvDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTvDetails indicates an expected call of FetchTvDetails.
+func (mr *MockClientMockRecorder) FetchTvDetails(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTvDetails", reflect.TypeOf((*MockClient)(nil).FetchTvDetails), ctx, id)
+}
+
+// FetchTvEpisodeDetails mocks base method.
+func (m *MockClient) FetchTvEpisodeDetails(ctx context.Context, id, seasonNumber, episodeNumber uint64) (*EpisodeDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTvEpisodeDetails", ctx, id, seasonNumber, episodeNumber)
+	ret0, _ := ret[0].(*EpisodeDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTvEpisodeDetails indicates an expected call of FetchTvEpisodeDetails.
+func (mr *MockClientMockRecorder) FetchTvEpisodeDetails(ctx, id, seasonNumber, episodeNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTvEpisodeDetails", reflect.TypeOf((*MockClient)(nil).FetchTvEpisodeDetails), ctx, id, seasonNumber, episodeNumber)
+}
+
+// FetchTvSeasonDetails mocks base method.
+func (m *MockClient) FetchTvSeasonDetails(ctx context.Context, id, seasonNumber uint64) (*SeasonDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTvSeasonDetails", ctx, id, seasonNumber)
+	ret0, _ := ret[0].(*SeasonDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTvSeasonDetails indicates an expected call of FetchTvSeasonDetails.
+func (mr *MockClientMockRecorder) FetchTvSeasonDetails(ctx, id, seasonNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTvSeasonDetails", reflect.TypeOf((*MockClient)(nil).FetchTvSeasonDetails), ctx, id, seasonNumber)
+}
+
+// FetchWatchProviders mocks base method.
+func (m *MockClient) FetchWatchProviders(ctx context.Context, region string) (*WatchProviderList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWatchProviders", ctx, region)
+	ret0, _ := ret[0].(*WatchProviderList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWatchProviders indicates an expected call of FetchWatchProviders.
+func (mr *MockClientMockRecorder) FetchWatchProviders(ctx, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWatchProviders", reflect.TypeOf((*MockClient)(nil).FetchWatchProviders), ctx, region)
+}
+
+// Locale mocks base method.
+func (m *MockClient) Locale(ctx context.Context) Locale {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Locale", ctx)
+	ret0, _ := ret[0].(Locale)
+	return ret0
+}
+
+// Locale indicates an expected call of Locale.
+func (mr *MockClientMockRecorder) Locale(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Locale", reflect.TypeOf((*MockClient)(nil).Locale), ctx)
+}
+
+// Metrics mocks base method.
+func (m *MockClient) Metrics() []EndpointMetrics {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metrics")
+	ret0, _ := ret[0].([]EndpointMetrics)
+	return ret0
+}
+
+// Metrics indicates an expected call of Metrics.
+func (mr *MockClientMockRecorder) Metrics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metrics", reflect.TypeOf((*MockClient)(nil).Metrics))
+}
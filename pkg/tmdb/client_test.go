@@ -0,0 +1,106 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_Client_FetchMovieDetails_Coalescing(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 550, "title": "Fight Club"}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		TMDBConfig: config.TMDBConfig{
+			BaseURL: server.URL,
+			Locale:  "en-US",
+		},
+	}
+	client := NewClient(cfg, logger.NewLogger(cfg))
+
+	cancelled, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	results := make([]*MovieDetails, 5)
+	errs := make([]error, 5)
+
+	for i := range results {
+		ctx := context.Background()
+		if i == 0 {
+			ctx = cancelled
+		}
+
+		wg.Add(1)
+		go func(i int, ctx context.Context) {
+			defer wg.Done()
+			results[i], errs[i] = client.FetchMovieDetails(ctx, 550)
+		}(i, ctx)
+	}
+
+	assert.Eventually(t, func() bool { return hits.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), hits.Load())
+	assert.ErrorIs(t, errs[0], context.Canceled)
+
+	for i := 1; i < len(results); i++ {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, "Fight Club", results[i].Title)
+	}
+}
+
+func Test_Client_FetchMovieDetails_RateLimitWait(t *testing.T) {
+	var hits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 550, "title": "Fight Club"}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		TMDBConfig: config.TMDBConfig{
+			BaseURL: server.URL,
+			Locale:  "en-US",
+			Timeout: 50 * time.Millisecond,
+		},
+	}
+	c := NewClient(cfg, logger.NewLogger(cfg)).(*client)
+	c.limiter = NewRateLimiter(0.1, 1)
+
+	_, err := c.FetchMovieDetails(context.Background(), 550)
+	assert.NoError(t, err)
+
+	started := time.Now()
+	_, err = c.FetchMovieDetails(context.Background(), 551)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+	assert.Equal(t, int32(1), hits.Load())
+}
@@ -1,14 +1,35 @@
 package tmdb
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 var (
 	ErrAccessForbidden = fmt.Errorf("access forbidden")
 	ErrNotFound        = fmt.Errorf("not found")
 
-	ErrUnexpectedResponse = fmt.Errorf("unexpected response from TMDB API")
+	ErrInvalidImage = fmt.Errorf("invalid image size or path")
+
+	ErrUnexpectedResponse  = fmt.Errorf("unexpected response from TMDB API")
+	ErrUpstreamUnavailable = fmt.Errorf("TMDB API is temporarily unavailable")
 
-	ErrFailedToFetchMovieDetails  = fmt.Errorf("failed to fetch movie details")
-	ErrFailedToFetchTvDetails     = fmt.Errorf("failed to fetch tv details")
-	ErrFailedToFetchPersonDetails = fmt.Errorf("failed to fetch person details")
+	ErrFailedToFetchMovieDetails      = fmt.Errorf("failed to fetch movie details")
+	ErrFailedToFetchTvDetails         = fmt.Errorf("failed to fetch tv details")
+	ErrFailedToFetchPersonDetails     = fmt.Errorf("failed to fetch person details")
+	ErrFailedToFetchProviders         = fmt.Errorf("failed to fetch watch providers")
+	ErrFailedToFetchCollectionDetails = fmt.Errorf("failed to fetch collection details")
 )
+
+// UnavailableError is returned while the circuit breaker is open
+type UnavailableError struct {
+	RetryAfter time.Duration
+}
+
+func (e *UnavailableError) Error() string {
+	return ErrUpstreamUnavailable.Error()
+}
+
+func (e *UnavailableError) Is(target error) bool {
+	return target == ErrUpstreamUnavailable
+}
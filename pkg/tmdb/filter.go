@@ -0,0 +1,53 @@
+package tmdb
+
+const (
+	GenreDocumentary = 99
+	GenreNews        = 10763
+	GenreReality     = 10764
+	GenreTalk        = 10767
+)
+
+// ContentFilter decides which titles and people are listed in responses
+type ContentFilter struct {
+	ExcludedGenreIds      []int
+	IncludeDocumentaries  bool
+	IncludeWithoutArtwork bool
+	IncludeAdult          bool
+}
+
+// DefaultContentFilter hides adult titles, items without artwork, talk, news, reality shows and documentaries
+func DefaultContentFilter() ContentFilter {
+	return ContentFilter{
+		ExcludedGenreIds: []int{GenreTalk, GenreNews, GenreReality},
+	}
+}
+
+// AllowsTitle reports whether a title with the given flags passes the filter
+func (f ContentFilter) AllowsTitle(adult bool, posterPath string, genreIds []int) bool {
+	if adult && !f.IncludeAdult {
+		return false
+	}
+
+	if !f.AllowsArtwork(posterPath) {
+		return false
+	}
+
+	for _, genreId := range genreIds {
+		if genreId == GenreDocumentary && !f.IncludeDocumentaries {
+			return false
+		}
+
+		for _, excludedId := range f.ExcludedGenreIds {
+			if genreId == excludedId {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// AllowsArtwork reports whether an item with the given image path may be shown
+func (f ContentFilter) AllowsArtwork(path string) bool {
+	return path != "" || f.IncludeWithoutArtwork
+}
@@ -0,0 +1,33 @@
+package tmdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ContentFilter_AllowsTitle(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     ContentFilter
+		adult      bool
+		posterPath string
+		genreIds   []int
+		expected   bool
+	}{
+		{name: "Regular title", filter: DefaultContentFilter(), posterPath: "/1.jpg", genreIds: []int{18}, expected: true},
+		{name: "Adult hidden by default", filter: DefaultContentFilter(), adult: true, posterPath: "/1.jpg", expected: false},
+		{name: "Adult opted in", filter: ContentFilter{IncludeAdult: true}, adult: true, posterPath: "/1.jpg", expected: true},
+		{name: "Without artwork hidden by default", filter: DefaultContentFilter(), expected: false},
+		{name: "Without artwork opted in", filter: ContentFilter{IncludeWithoutArtwork: true}, expected: true},
+		{name: "Documentary hidden by default", filter: DefaultContentFilter(), posterPath: "/1.jpg", genreIds: []int{GenreDocumentary}, expected: false},
+		{name: "Documentary opted in", filter: ContentFilter{IncludeDocumentaries: true}, posterPath: "/1.jpg", genreIds: []int{GenreDocumentary}, expected: true},
+		{name: "Excluded genre", filter: DefaultContentFilter(), posterPath: "/1.jpg", genreIds: []int{18, GenreTalk}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.AllowsTitle(tt.adult, tt.posterPath, tt.genreIds))
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package tmdb
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+type ImageKind string
+
+const (
+	ImageKindPoster   ImageKind = "poster"
+	ImageKindBackdrop ImageKind = "backdrop"
+	ImageKindProfile  ImageKind = "profile"
+	ImageKindStill    ImageKind = "still"
+)
+
+const (
+	OriginalImageSize = "original"
+
+	DefaultImageConfigTTL = 24 * time.Hour
+	imageConfigRetry      = time.Minute
+)
+
+// DefaultImageSizes are the target widths of the small, medium and large presets
+var DefaultImageSizes = []int{185, 342, 780}
+
+// defaultImagesConfiguration mirrors TMDB's /configuration and is used until it can be fetched
+var defaultImagesConfiguration = ImagesConfiguration{
+	SecureBaseURL: "https://image.tmdb.org/t/p/",
+	BackdropSizes: []string{"w300", "w780", "w1280", OriginalImageSize},
+	PosterSizes:   []string{"w92", "w154", "w185", "w342", "w500", "w780", OriginalImageSize},
+	ProfileSizes:  []string{"w45", "w185", "h632", OriginalImageSize},
+	StillSizes:    []string{"w92", "w185", "w300", OriginalImageSize},
+}
+
+// ImageSet holds the full URLs of an image in the size presets
+type ImageSet struct {
+	Small    string
+	Medium   string
+	Large    string
+	Original string
+}
+
+type imageVariantsKey struct{}
+
+// WithImageVariants returns a copy of ctx asking for image URLs in responses
+func WithImageVariants(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, imageVariantsKey{}, enabled)
+}
+
+func ImageVariantsFromContext(ctx context.Context) bool {
+	enabled, _ := ctx.Value(imageVariantsKey{}).(bool)
+	return enabled
+}
+
+type Images interface {
+	// Variants builds the size presets of an image path, nil when the path is empty or ctx does not ask for them
+	Variants(ctx context.Context, kind ImageKind, path string) *ImageSet
+}
+
+type images struct {
+	client  Client
+	baseURL string
+	presets []int
+	ttl     time.Duration
+	now     func() time.Time
+	log     *logger.Logger
+
+	mu            sync.Mutex
+	configuration *ImagesConfiguration
+	expiresAt     time.Time
+}
+
+func NewImages(cfg *config.Config, client Client, log *logger.Logger) Images {
+	presets := cfg.TMDBConfig.ImageSizes
+	if len(presets) != len(DefaultImageSizes) {
+		presets = DefaultImageSizes
+	}
+
+	ttl := cfg.TMDBConfig.ImageConfigTTL
+	if ttl <= 0 {
+		ttl = DefaultImageConfigTTL
+	}
+
+	// NOTE: with the proxy enabled clients never talk to the TMDB image CDN directly
+	baseURL := cfg.TMDBConfig.BaseImageURL
+	if cfg.ImageProxyConfig.Enabled {
+		baseURL = cfg.ImageProxyConfig.URL
+		if baseURL == "" {
+			baseURL = DefaultImageProxyPath
+		}
+	}
+
+	return &images{
+		client:  client,
+		baseURL: baseURL,
+		presets: presets,
+		ttl:     ttl,
+		now:     time.Now,
+		log:     log.WithComponent("TmdbImages"),
+	}
+}
+
+func (i *images) Variants(ctx context.Context, kind ImageKind, path string) *ImageSet {
+	if path == "" || !ImageVariantsFromContext(ctx) {
+		return nil
+	}
+
+	configuration := i.loadConfiguration(ctx)
+
+	baseURL := i.baseURL
+	if baseURL == "" {
+		baseURL = configuration.SecureBaseURL
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	sizes := configuration.sizes(kind)
+	url := func(size string) string {
+		return baseURL + "/" + size + path
+	}
+
+	return &ImageSet{
+		Small:    url(pickImageSize(sizes, i.presets[0])),
+		Medium:   url(pickImageSize(sizes, i.presets[1])),
+		Large:    url(pickImageSize(sizes, i.presets[2])),
+		Original: url(OriginalImageSize),
+	}
+}
+
+// loadConfiguration returns the cached image configuration, refreshing it once expired.
+// A failed refresh falls back to the defaults and is retried shortly after
+func (i *images) loadConfiguration(ctx context.Context) ImagesConfiguration {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := i.now()
+	if i.configuration != nil && now.Before(i.expiresAt) {
+		return *i.configuration
+	}
+
+	response, err := i.client.FetchConfiguration(ctx)
+	if err != nil || len(response.Images.PosterSizes) == 0 {
+		i.log.Warn().Err(err).Msg("Failed to fetch image configuration, using defaults")
+
+		if i.configuration == nil {
+			i.configuration = &defaultImagesConfiguration
+		}
+		i.expiresAt = now.Add(imageConfigRetry)
+
+		return *i.configuration
+	}
+
+	i.configuration = &response.Images
+	i.expiresAt = now.Add(i.ttl)
+
+	return *i.configuration
+}
+
+func (c ImagesConfiguration) sizes(kind ImageKind) []string {
+	switch kind {
+	case ImageKindBackdrop:
+		return c.BackdropSizes
+	case ImageKindProfile:
+		return c.ProfileSizes
+	case ImageKindStill:
+		return c.StillSizes
+	default:
+		return c.PosterSizes
+	}
+}
+
+// pickImageSize returns the smallest size of at least target pixels, the largest one when none is big enough
+func pickImageSize(sizes []string, target int) string {
+	largest := OriginalImageSize
+	for _, size := range sizes {
+		if size == OriginalImageSize || len(size) < 2 {
+			continue
+		}
+
+		pixels, err := strconv.Atoi(size[1:])
+		if err != nil {
+			continue
+		}
+
+		if pixels >= target {
+			return size
+		}
+		largest = size
+	}
+
+	return largest
+}
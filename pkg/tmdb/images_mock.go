@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/tmdb/images.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/tmdb/images.go -destination=pkg/tmdb/images_mock.go -package=tmdb
+//
+
+// Package tmdb is a generated GoMock package.
+package tmdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImages is a mock of Images interface.
+type MockImages struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagesMockRecorder
+	isgomock struct{}
+}
+
+// MockImagesMockRecorder is the mock recorder for MockImages.
+type MockImagesMockRecorder struct {
+	mock *MockImages
+}
+
+// NewMockImages creates a new mock instance.
+func NewMockImages(ctrl *gomock.Controller) *MockImages {
+	mock := &MockImages{ctrl: ctrl}
+	mock.recorder = &MockImagesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImages) EXPECT() *MockImagesMockRecorder {
+	return m.recorder
+}
+
+// Variants mocks base method.
+func (m *MockImages) Variants(ctx context.Context, kind ImageKind, path string) *ImageSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Variants", ctx, kind, path)
+	ret0, _ := ret[0].(*ImageSet)
+	return ret0
+}
+
+// Variants indicates an expected call of Variants.
+func (mr *MockImagesMockRecorder) Variants(ctx, kind, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Variants", reflect.TypeOf((*MockImages)(nil).Variants), ctx, kind, path)
+}
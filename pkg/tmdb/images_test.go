@@ -0,0 +1,151 @@
+package tmdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_Images_Variants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+		TMDBConfig: config.TMDBConfig{
+			BaseImageURL: "https://image.tmdb.org/t/p",
+		},
+	}
+	client := NewMockClient(ctrl)
+	builder := NewImages(cfg, client, logger.NewLogger(cfg)).(*images)
+
+	now := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+	builder.now = func() time.Time { return now }
+
+	ctx := WithImageVariants(context.Background(), true)
+
+	client.EXPECT().FetchConfiguration(ctx).Return(&Configuration{
+		Images: ImagesConfiguration{
+			SecureBaseURL: "https://cdn.example.com/",
+			PosterSizes:   []string{"w92", "w185", "w500", OriginalImageSize},
+			ProfileSizes:  []string{"w45", "w185", "h632", OriginalImageSize},
+		},
+	}, nil).Times(1)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		kind     ImageKind
+		path     string
+		expected *ImageSet
+	}{
+		{
+			name: "Poster",
+			ctx:  ctx,
+			kind: ImageKindPoster,
+			path: "/poster.jpg",
+			expected: &ImageSet{
+				Small:    "https://image.tmdb.org/t/p/w185/poster.jpg",
+				Medium:   "https://image.tmdb.org/t/p/w500/poster.jpg",
+				Large:    "https://image.tmdb.org/t/p/w500/poster.jpg",
+				Original: "https://image.tmdb.org/t/p/original/poster.jpg",
+			},
+		},
+		{
+			name: "Profile",
+			ctx:  ctx,
+			kind: ImageKindProfile,
+			path: "/profile.jpg",
+			expected: &ImageSet{
+				Small:    "https://image.tmdb.org/t/p/w185/profile.jpg",
+				Medium:   "https://image.tmdb.org/t/p/h632/profile.jpg",
+				Large:    "https://image.tmdb.org/t/p/h632/profile.jpg",
+				Original: "https://image.tmdb.org/t/p/original/profile.jpg",
+			},
+		},
+		{name: "Empty path", ctx: ctx, kind: ImageKindPoster, path: "", expected: nil},
+		{name: "Not requested", ctx: context.Background(), kind: ImageKindPoster, path: "/poster.jpg", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, builder.Variants(tt.ctx, tt.kind, tt.path))
+		})
+	}
+}
+
+func Test_Images_Variants_FallsBackToDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+	}
+	client := NewMockClient(ctrl)
+	builder := NewImages(cfg, client, logger.NewLogger(cfg)).(*images)
+
+	now := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+	builder.now = func() time.Time { return now }
+
+	ctx := WithImageVariants(context.Background(), true)
+
+	client.EXPECT().FetchConfiguration(ctx).Return(nil, ErrUpstreamUnavailable).Times(2)
+
+	expected := &ImageSet{
+		Small:    "https://image.tmdb.org/t/p/w185/still.jpg",
+		Medium:   "https://image.tmdb.org/t/p/w300/still.jpg",
+		Large:    "https://image.tmdb.org/t/p/w300/still.jpg",
+		Original: "https://image.tmdb.org/t/p/original/still.jpg",
+	}
+
+	assert.Equal(t, expected, builder.Variants(ctx, ImageKindStill, "/still.jpg"))
+	assert.Equal(t, expected, builder.Variants(ctx, ImageKindStill, "/still.jpg"))
+
+	now = now.Add(2 * imageConfigRetry)
+	assert.Equal(t, expected, builder.Variants(ctx, ImageKindStill, "/still.jpg"))
+}
+
+func Test_PickImageSize(t *testing.T) {
+	sizes := []string{"w92", "w185", "h632", OriginalImageSize}
+
+	assert.Equal(t, "w92", pickImageSize(sizes, 50))
+	assert.Equal(t, "w185", pickImageSize(sizes, 185))
+	assert.Equal(t, "h632", pickImageSize(sizes, 342))
+	assert.Equal(t, "h632", pickImageSize(sizes, 2000))
+	assert.Equal(t, OriginalImageSize, pickImageSize(nil, 185))
+}
+
+func Test_Images_Variants_ThroughProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+		TMDBConfig: config.TMDBConfig{
+			BaseImageURL: "https://image.tmdb.org/t/p",
+		},
+		ImageProxyConfig: config.ImageProxyConfig{
+			Enabled: true,
+			URL:     "https://api.example.com/images/",
+		},
+	}
+	client := NewMockClient(ctrl)
+	builder := NewImages(cfg, client, logger.NewLogger(cfg))
+
+	ctx := WithImageVariants(context.Background(), true)
+	client.EXPECT().FetchConfiguration(ctx).Return(nil, ErrUpstreamUnavailable)
+
+	result := builder.Variants(ctx, ImageKindPoster, "/poster.jpg")
+
+	assert.Equal(t, "https://api.example.com/images/w185/poster.jpg", result.Small)
+	assert.Equal(t, "https://api.example.com/images/original/poster.jpg", result.Original)
+}
@@ -0,0 +1,51 @@
+package tmdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RateLimiter_Wait(t *testing.T) {
+	t.Run("Burst is available immediately", func(t *testing.T) {
+		limiter := NewRateLimiter(1, 3)
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, time.Duration(0), limiter.reserve())
+		}
+		assert.Greater(t, limiter.reserve(), time.Duration(0))
+	})
+
+	t.Run("Refills over time", func(t *testing.T) {
+		now := time.Now()
+		limiter := NewRateLimiter(10, 1)
+		limiter.now = func() time.Time { return now }
+		limiter.last = now
+
+		assert.Equal(t, time.Duration(0), limiter.reserve())
+		assert.Equal(t, 100*time.Millisecond, limiter.reserve())
+
+		now = now.Add(time.Second)
+		assert.Equal(t, time.Duration(0), limiter.reserve())
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		limiter := NewRateLimiter(0.001, 1)
+		assert.NoError(t, limiter.Wait(context.Background()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		limiter := NewRateLimiter(0, 1)
+
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package tmdb
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+var (
+	languagePattern = regexp.MustCompile(`^[a-z]{2}$`)
+	regionPattern   = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// Locale holds the language and region TMDB responses are localized for
+type Locale struct {
+	Language string
+	Region   string
+}
+
+type localeKey struct{}
+
+// ParseLocale parses a language tag such as "en-US" or "fr", returning false for malformed tags
+func ParseLocale(tag string) (Locale, bool) {
+	parts := strings.Split(strings.TrimSpace(tag), "-")
+
+	language := strings.ToLower(parts[0])
+	if !languagePattern.MatchString(language) {
+		return Locale{}, false
+	}
+
+	switch len(parts) {
+	case 1:
+		return Locale{Language: language}, true
+	case 2:
+		region := strings.ToUpper(parts[1])
+		if !regionPattern.MatchString(region) {
+			return Locale{}, false
+		}
+		return Locale{Language: language, Region: region}, true
+	default:
+		return Locale{}, false
+	}
+}
+
+func IsValidLanguage(language string) bool {
+	return languagePattern.MatchString(language)
+}
+
+func IsValidRegion(region string) bool {
+	return regionPattern.MatchString(region)
+}
+
+// Tag returns the locale as TMDB language parameter, e.g. "en-US"
+func (l Locale) Tag() string {
+	if l.Region == "" {
+		return l.Language
+	}
+
+	return l.Language + "-" + l.Region
+}
+
+// Merge fills empty fields of the locale from fallback
+func (l Locale) Merge(fallback Locale) Locale {
+	if l.Language == "" {
+		l.Language = fallback.Language
+	}
+
+	if l.Region == "" {
+		l.Region = fallback.Region
+	}
+
+	return l
+}
+
+// WithLocale returns a copy of ctx carrying the locale for TMDB requests
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func LocaleFromContext(ctx context.Context) (Locale, bool) {
+	locale, ok := ctx.Value(localeKey{}).(Locale)
+	return locale, ok
+}
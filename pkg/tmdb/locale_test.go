@@ -0,0 +1,46 @@
+package tmdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseLocale(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected Locale
+		ok       bool
+	}{
+		{tag: "en-US", expected: Locale{Language: "en", Region: "US"}, ok: true},
+		{tag: "fr-ch", expected: Locale{Language: "fr", Region: "CH"}, ok: true},
+		{tag: "ET", expected: Locale{Language: "et"}, ok: true},
+		{tag: "*", ok: false},
+		{tag: "zh-Hant-TW", ok: false},
+		{tag: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			result, ok := ParseLocale(tt.tag)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Locale_Tag(t *testing.T) {
+	assert.Equal(t, "en-US", Locale{Language: "en", Region: "US"}.Tag())
+	assert.Equal(t, "en", Locale{Language: "en"}.Tag())
+}
+
+func Test_LocaleFromContext(t *testing.T) {
+	_, ok := LocaleFromContext(context.Background())
+	assert.False(t, ok)
+
+	locale := Locale{Language: "de", Region: "AT"}
+	result, ok := LocaleFromContext(WithLocale(context.Background(), locale))
+	assert.True(t, ok)
+	assert.Equal(t, locale, result)
+}
@@ -0,0 +1,57 @@
+package tmdb
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type EndpointMetrics struct {
+	Endpoint string        `json:"endpoint"`
+	Requests uint64        `json:"requests"`
+	Errors   uint64        `json:"errors"`
+	Duration time.Duration `json:"duration"`
+}
+
+// metrics collects per-endpoint request counters of the TMDB client
+type metrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointMetrics
+}
+
+func newMetrics() *metrics {
+	return &metrics{endpoints: make(map[string]*EndpointMetrics)}
+}
+
+func (m *metrics) observe(endpoint string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.endpoints[endpoint]
+	if !ok {
+		item = &EndpointMetrics{Endpoint: endpoint}
+		m.endpoints[endpoint] = item
+	}
+
+	item.Requests++
+	item.Duration += duration
+	if failed {
+		item.Errors++
+	}
+}
+
+func (m *metrics) snapshot() []EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]EndpointMetrics, 0, len(m.endpoints))
+	for _, item := range m.endpoints {
+		result = append(result, *item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Endpoint < result[j].Endpoint
+	})
+
+	return result
+}
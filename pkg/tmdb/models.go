@@ -39,6 +39,7 @@ type Recommendation struct {
 	OriginalLanguage string  `json:"original_language"`
 	OriginalTitle    string  `json:"original_title,omitempty"`
 	OriginalName     string  `json:"original_name,omitempty"`
+	GenreIds         []int   `json:"genre_ids"`
 	Popularity       float64 `json:"popularity"`
 	VoteAverage      float64 `json:"vote_average"`
 	VoteCount        int     `json:"vote_count"`
@@ -68,20 +69,104 @@ type Videos struct {
 	Results []Video `json:"results"`
 }
 
+type Genre struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ProductionCountry struct {
+	ISO31661 string `json:"iso_3166_1"`
+	Name     string `json:"name"`
+}
+
+type SpokenLanguage struct {
+	ISO6391     string `json:"iso_639_1"`
+	Name        string `json:"name"`
+	EnglishName string `json:"english_name"`
+}
+
+type CollectionSummary struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	PosterPath   string `json:"poster_path"`
+	BackdropPath string `json:"backdrop_path"`
+}
+
+type ExternalIds struct {
+	ImdbId      string `json:"imdb_id"`
+	WikidataId  string `json:"wikidata_id"`
+	FacebookId  string `json:"facebook_id"`
+	InstagramId string `json:"instagram_id"`
+	TwitterId   string `json:"twitter_id"`
+}
+
+type ReleaseDate struct {
+	Certification string `json:"certification"`
+	ISO6391       string `json:"iso_639_1"`
+	Note          string `json:"note"`
+	ReleaseDate   string `json:"release_date"`
+	Type          int    `json:"type"`
+}
+
+type CountryReleaseDates struct {
+	ISO31661     string        `json:"iso_3166_1"`
+	ReleaseDates []ReleaseDate `json:"release_dates"`
+}
+
+type ReleaseDates struct {
+	Results []CountryReleaseDates `json:"results"`
+}
+
+type WatchProvider struct {
+	ProviderId      int    `json:"provider_id"`
+	ProviderName    string `json:"provider_name"`
+	LogoPath        string `json:"logo_path"`
+	DisplayPriority int    `json:"display_priority"`
+}
+
+type RegionWatchProviders struct {
+	Link     string          `json:"link"`
+	Flatrate []WatchProvider `json:"flatrate"`
+	Rent     []WatchProvider `json:"rent"`
+	Buy      []WatchProvider `json:"buy"`
+}
+
+// WatchProviders holds the availability of a title keyed by ISO 3166-1 region
+type WatchProviders struct {
+	Results map[string]RegionWatchProviders `json:"results"`
+}
+
+type WatchProviderList struct {
+	Results []WatchProvider `json:"results"`
+}
+
 type MovieDetails struct {
-	Id              int             `json:"id"`
-	Title           string          `json:"title"`
-	Overview        string          `json:"overview"`
-	PosterPath      string          `json:"poster_path"`
-	BackdropPath    string          `json:"backdrop_path"`
-	Status          string          `json:"status"`
-	ImdbId          string          `json:"imdb_id"`
-	ReleaseDate     string          `json:"release_date"`
-	Runtime         int             `json:"runtime"`
-	VoteAverage     float64         `json:"vote_average"`
-	Credits         Credits         `json:"credits"`
-	Recommendations Recommendations `json:"recommendations"`
-	Videos          Videos          `json:"videos"`
+	Id                  int                 `json:"id"`
+	Title               string              `json:"title"`
+	Adult               bool                `json:"adult"`
+	OriginalTitle       string              `json:"original_title"`
+	OriginalLanguage    string              `json:"original_language"`
+	Tagline             string              `json:"tagline"`
+	Overview            string              `json:"overview"`
+	PosterPath          string              `json:"poster_path"`
+	BackdropPath        string              `json:"backdrop_path"`
+	Status              string              `json:"status"`
+	ImdbId              string              `json:"imdb_id"`
+	ReleaseDate         string              `json:"release_date"`
+	Runtime             int                 `json:"runtime"`
+	Budget              int64               `json:"budget"`
+	Revenue             int64               `json:"revenue"`
+	VoteAverage         float64             `json:"vote_average"`
+	Genres              []Genre             `json:"genres"`
+	ProductionCountries []ProductionCountry `json:"production_countries"`
+	SpokenLanguages     []SpokenLanguage    `json:"spoken_languages"`
+	BelongsToCollection *CollectionSummary  `json:"belongs_to_collection"`
+	ExternalIds         ExternalIds         `json:"external_ids"`
+	ReleaseDates        ReleaseDates        `json:"release_dates"`
+	WatchProviders      WatchProviders      `json:"watch/providers"`
+	Credits             Credits             `json:"credits"`
+	Recommendations     Recommendations     `json:"recommendations"`
+	Videos              Videos              `json:"videos"`
 }
 
 type MovieCredit struct {
@@ -95,6 +180,7 @@ type MovieCredit struct {
 	Character    string `json:"character,omitempty"`
 	Job          string `json:"job,omitempty"`
 	Department   string `json:"department,omitempty"`
+	GenreIds     []int  `json:"genre_ids"`
 }
 
 type TvCredit struct {
@@ -122,15 +208,53 @@ type PersonTvCredits struct {
 	Crew []TvCredit `json:"crew"`
 }
 
+type Image struct {
+	FilePath    string  `json:"file_path"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	AspectRatio float64 `json:"aspect_ratio"`
+	VoteAverage float64 `json:"vote_average"`
+}
+
+type PersonImages struct {
+	Profiles []Image `json:"profiles"`
+}
+
 type PersonDetails struct {
-	Id          int                `json:"id"`
-	ImdbId      string             `json:"imdb_id,omitempty"`
-	Name        string             `json:"name"`
-	Birthday    string             `json:"birthday,omitempty"`
-	ProfilePath string             `json:"profile_path"`
-	Gender      int                `json:"gender"`
-	Credits     PersonMovieCredits `json:"credits"`
-	TvCredits   PersonTvCredits    `json:"tv_credits"`
+	Id                 int                `json:"id"`
+	ImdbId             string             `json:"imdb_id,omitempty"`
+	Name               string             `json:"name"`
+	Biography          string             `json:"biography"`
+	Birthday           string             `json:"birthday,omitempty"`
+	Deathday           string             `json:"deathday,omitempty"`
+	PlaceOfBirth       string             `json:"place_of_birth,omitempty"`
+	KnownForDepartment string             `json:"known_for_department"`
+	ProfilePath        string             `json:"profile_path"`
+	Gender             int                `json:"gender"`
+	ExternalIds        ExternalIds        `json:"external_ids"`
+	Images             PersonImages       `json:"images"`
+	Credits            PersonMovieCredits `json:"credits"`
+	TvCredits          PersonTvCredits    `json:"tv_credits"`
+}
+
+type CollectionPart struct {
+	Id           uint64  `json:"id"`
+	Title        string  `json:"title"`
+	Overview     string  `json:"overview"`
+	Adult        bool    `json:"adult"`
+	BackdropPath string  `json:"backdrop_path"`
+	PosterPath   string  `json:"poster_path"`
+	ReleaseDate  string  `json:"release_date"`
+	VoteAverage  float64 `json:"vote_average"`
+}
+
+type CollectionDetails struct {
+	Id           int              `json:"id"`
+	Name         string           `json:"name"`
+	Overview     string           `json:"overview"`
+	PosterPath   string           `json:"poster_path"`
+	BackdropPath string           `json:"backdrop_path"`
+	Parts        []CollectionPart `json:"parts"`
 }
 
 type CreditItem struct {
@@ -151,44 +275,139 @@ type VideoItem struct {
 	Key string `json:"key"`
 }
 
+type GenreItem struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type CountryItem struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type LanguageItem struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type CollectionItem struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	PosterPath   string `json:"posterPath"`
+	BackdropPath string `json:"backdropPath"`
+}
+
+type ExternalIdsItem struct {
+	ImdbId      string `json:"imdbId,omitempty"`
+	WikidataId  string `json:"wikidataId,omitempty"`
+	FacebookId  string `json:"facebookId,omitempty"`
+	InstagramId string `json:"instagramId,omitempty"`
+	TwitterId   string `json:"twitterId,omitempty"`
+}
+
+type ReleaseDateItem struct {
+	Type          int    `json:"type"`
+	Date          string `json:"date"`
+	Certification string `json:"certification,omitempty"`
+	Note          string `json:"note,omitempty"`
+}
+
+type WatchProviderItem struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	LogoPath string `json:"logoPath"`
+}
+
+type WatchProvidersItem struct {
+	Link     string              `json:"link"`
+	Flatrate []WatchProviderItem `json:"flatrate"`
+	Rent     []WatchProviderItem `json:"rent"`
+	Buy      []WatchProviderItem `json:"buy"`
+}
+
 type MovieResponse struct {
-	Id              int                  `json:"id"`
-	Title           string               `json:"title"`
-	Overview        string               `json:"overview"`
-	PosterPath      string               `json:"posterPath"`
-	Status          string               `json:"status"`
-	ImdbId          string               `json:"imdbId"`
-	ReleaseDate     string               `json:"releaseDate"`
-	Runtime         int                  `json:"runtime"`
-	Rating          float64              `json:"rating"`
-	Credits         []CreditItem         `json:"credits"`
-	Recommendations []RecommendationItem `json:"recommendations"`
-	Videos          []VideoItem          `json:"videos"`
+	Id                  int                  `json:"id"`
+	Title               string               `json:"title"`
+	OriginalTitle       string               `json:"originalTitle"`
+	OriginalLanguage    string               `json:"originalLanguage"`
+	Tagline             string               `json:"tagline"`
+	Overview            string               `json:"overview"`
+	PosterPath          string               `json:"posterPath"`
+	BackdropPath        string               `json:"backdropPath"`
+	Status              string               `json:"status"`
+	ImdbId              string               `json:"imdbId"`
+	ReleaseDate         string               `json:"releaseDate"`
+	Runtime             int                  `json:"runtime"`
+	Budget              int64                `json:"budget"`
+	Revenue             int64                `json:"revenue"`
+	Rating              float64              `json:"rating"`
+	Genres              []GenreItem          `json:"genres"`
+	ProductionCountries []CountryItem        `json:"productionCountries"`
+	SpokenLanguages     []LanguageItem       `json:"spokenLanguages"`
+	Collection          *CollectionItem      `json:"collection,omitempty"`
+	ExternalIds         ExternalIdsItem      `json:"externalIds"`
+	Certification       string               `json:"certification,omitempty"`
+	ReleaseDates        []ReleaseDateItem    `json:"releaseDates"`
+	WatchProviders      *WatchProvidersItem  `json:"watchProviders,omitempty"`
+	Credits             []CreditItem         `json:"credits"`
+	Recommendations     []RecommendationItem `json:"recommendations"`
+	Videos              []VideoItem          `json:"videos"`
 }
 
 type MovieCreditItem struct {
-	Id         uint64 `json:"id"`
-	Title      string `json:"title"`
-	PosterPath string `json:"posterPath"`
-	Type       string `json:"type"`
+	Id          uint64 `json:"id"`
+	Title       string `json:"title"`
+	PosterPath  string `json:"posterPath"`
+	ReleaseDate string `json:"releaseDate"`
+	Type        string `json:"type"`
 }
 
 type TvCreditItem struct {
 	Id            int    `json:"id"`
 	Title         string `json:"title"`
 	PosterPath    string `json:"posterPath"`
+	Type          string `json:"type"`
 	EpisodesCount int    `json:"episodesCount,omitempty"`
 }
 
+type ImageItem struct {
+	FilePath string `json:"filePath"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
 type PersonResponse struct {
-	Id           int               `json:"id"`
-	ImdbId       string            `json:"imdbId,omitempty"`
-	Name         string            `json:"name"`
-	Birthday     string            `json:"birthday,omitempty"`
-	ProfilePath  string            `json:"profilePath"`
-	Gender       int               `json:"gender"`
-	MovieCredits []MovieCreditItem `json:"movieCredits"`
-	TvCredits    []TvCreditItem    `json:"tvCredits"`
+	Id                 int               `json:"id"`
+	ImdbId             string            `json:"imdbId,omitempty"`
+	Name               string            `json:"name"`
+	Biography          string            `json:"biography"`
+	Birthday           string            `json:"birthday,omitempty"`
+	Deathday           string            `json:"deathday,omitempty"`
+	PlaceOfBirth       string            `json:"placeOfBirth,omitempty"`
+	KnownForDepartment string            `json:"knownForDepartment"`
+	ProfilePath        string            `json:"profilePath"`
+	Gender             int               `json:"gender"`
+	ExternalIds        ExternalIdsItem   `json:"externalIds"`
+	ProfileImages      []ImageItem       `json:"profileImages"`
+	MovieCredits       []MovieCreditItem `json:"movieCredits"`
+	TvCredits          []TvCreditItem    `json:"tvCredits"`
+}
+
+type CollectionPartItem struct {
+	Id          uint64  `json:"id"`
+	Title       string  `json:"title"`
+	PosterPath  string  `json:"posterPath"`
+	ReleaseDate string  `json:"releaseDate"`
+	Rating      float64 `json:"rating"`
+}
+
+type CollectionResponse struct {
+	Id           int                  `json:"id"`
+	Name         string               `json:"name"`
+	Overview     string               `json:"overview"`
+	PosterPath   string               `json:"posterPath"`
+	BackdropPath string               `json:"backdropPath"`
+	Parts        []CollectionPartItem `json:"parts"`
 }
 
 type TvDetails struct {
@@ -205,6 +424,7 @@ type TvDetails struct {
 	Credits         Credits         `json:"credits"`
 	Recommendations Recommendations `json:"recommendations"`
 	Videos          Videos          `json:"videos"`
+	WatchProviders  WatchProviders  `json:"watch/providers"`
 }
 
 type TvResponse struct {
@@ -219,6 +439,7 @@ type TvResponse struct {
 	Credits         []CreditItem         `json:"credits"`
 	Recommendations []RecommendationItem `json:"recommendations"`
 	Videos          []VideoItem          `json:"videos"`
+	WatchProviders  *WatchProvidersItem  `json:"watchProviders,omitempty"`
 }
 
 type SeasonDetails struct {
@@ -257,3 +478,31 @@ type EpisodeDetails struct {
 	VoteAverage    float64 `json:"vote_average"`
 	VoteCount      int     `json:"vote_count"`
 }
+
+type ImagesConfiguration struct {
+	BaseURL       string   `json:"base_url"`
+	SecureBaseURL string   `json:"secure_base_url"`
+	BackdropSizes []string `json:"backdrop_sizes"`
+	LogoSizes     []string `json:"logo_sizes"`
+	PosterSizes   []string `json:"poster_sizes"`
+	ProfileSizes  []string `json:"profile_sizes"`
+	StillSizes    []string `json:"still_sizes"`
+}
+
+type Configuration struct {
+	Images     ImagesConfiguration `json:"images"`
+	ChangeKeys []string            `json:"change_keys"`
+}
+
+// ChangedItem is an id edited, merged or deleted on TMDB in the requested dates
+type ChangedItem struct {
+	Id    uint64 `json:"id"`
+	Adult bool   `json:"adult"`
+}
+
+type Changes struct {
+	Page         int           `json:"page"`
+	TotalPages   int           `json:"total_pages"`
+	TotalResults int           `json:"total_results"`
+	Results      []ChangedItem `json:"results"`
+}
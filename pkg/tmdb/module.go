@@ -4,4 +4,6 @@ import "go.uber.org/fx"
 
 var Module = fx.Options(
 	fx.Provide(NewClient),
+	fx.Provide(NewImages),
+	fx.Provide(NewImageProxy),
 )
@@ -0,0 +1,344 @@
+package tmdb
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+const (
+	DefaultImageProxyPath     = "/images"
+	DefaultImageCacheDir      = "tmp/images"
+	DefaultImageCacheMaxBytes = 512 << 20
+
+	// maxImageBytes caps a single upstream image, TMDB originals stay well below it
+	maxImageBytes = 20 << 20
+)
+
+var (
+	imageSizePattern = regexp.MustCompile(`^(w\d{2,4}|h\d{2,4}|original)$`)
+	imagePathPattern = regexp.MustCompile(`^/[A-Za-z0-9_-]+\.(jpg|png|svg)$`)
+
+	imageContentTypes = map[string]string{
+		".jpg": "image/jpeg",
+		".png": "image/png",
+		".svg": "image/svg+xml",
+	}
+)
+
+// IsValidImage reports whether size and path look like a TMDB image, e.g. "w500" and "/abc.jpg"
+func IsValidImage(size string, path string) bool {
+	return imageSizePattern.MatchString(size) && imagePathPattern.MatchString(path)
+}
+
+// CachedImage is an image served from the local cache
+type CachedImage struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+	ModTime     time.Time
+}
+
+type ImageProxy interface {
+	Fetch(ctx context.Context, size string, path string) (*CachedImage, error)
+}
+
+type cacheEntry struct {
+	name    string
+	bytes   int64
+	etag    string
+	modTime time.Time
+}
+
+type imageProxy struct {
+	baseURL    string
+	dir        string
+	maxBytes   int64
+	httpClient *http.Client
+	flights    singleflight.Group
+	log        *logger.Logger
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	bytes   int64
+}
+
+func NewImageProxy(cfg *config.Config, log *logger.Logger) ImageProxy {
+	dir := cfg.ImageProxyConfig.CacheDir
+	if dir == "" {
+		dir = DefaultImageCacheDir
+	}
+
+	maxBytes := int64(cfg.ImageProxyConfig.CacheMaxBytes)
+	if maxBytes <= 0 {
+		maxBytes = DefaultImageCacheMaxBytes
+	}
+
+	timeout := cfg.TMDBConfig.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	proxy := &imageProxy{
+		baseURL:    strings.TrimRight(cfg.TMDBConfig.BaseImageURL, "/"),
+		dir:        dir,
+		maxBytes:   maxBytes,
+		httpClient: &http.Client{Timeout: timeout},
+		log:        log.WithComponent("TmdbImageProxy"),
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+
+	if err := proxy.load(); err != nil {
+		proxy.log.Warn().Err(err).Str("dir", dir).Msg("Failed to load image cache")
+	}
+
+	return proxy
+}
+
+// Fetch returns the image from disk, downloading it from TMDB on a cache miss. Concurrent misses of
+// an image share one download, detached from the callers and bounded by the client timeout, so one
+// of them going away does not fail the others
+func (p *imageProxy) Fetch(ctx context.Context, size string, path string) (*CachedImage, error) {
+	if !IsValidImage(size, path) {
+		return nil, ErrInvalidImage
+	}
+
+	name := size + "_" + strings.TrimPrefix(path, "/")
+	if image, ok := p.read(name); ok {
+		return image, nil
+	}
+
+	result := p.flights.DoChan(name, func() (interface{}, error) {
+		if image, ok := p.read(name); ok {
+			return image, nil
+		}
+
+		data, err := p.download(context.WithoutCancel(ctx), size, path)
+		if err != nil {
+			return nil, err
+		}
+
+		return p.store(name, data)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		return res.Val.(*CachedImage), nil
+	}
+}
+
+func (p *imageProxy) download(ctx context.Context, size string, path string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/"+size+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		p.log.Error().Err(err).Str("size", size).Str("path", path).Msg("Failed to fetch image")
+		return nil, ErrUpstreamUnavailable
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case response.StatusCode != http.StatusOK:
+		p.log.Error().Int("status", response.StatusCode).Str("size", size).Str("path", path).Msg("Unexpected image response")
+		return nil, ErrUpstreamUnavailable
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, ErrUpstreamUnavailable
+	}
+
+	if len(data) > maxImageBytes {
+		return nil, ErrUnexpectedResponse
+	}
+
+	return data, nil
+}
+
+// read returns a cached image and marks it as recently used
+func (p *imageProxy) read(name string) (*CachedImage, bool) {
+	p.mu.Lock()
+	element, ok := p.entries[name]
+	if ok {
+		p.lru.MoveToFront(element)
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.dir, name))
+	if err != nil {
+		p.mu.Lock()
+		p.remove(element)
+		p.mu.Unlock()
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+
+	p.mu.Lock()
+	if entry.etag == "" {
+		entry.etag = imageETag(data)
+	}
+	etag := entry.etag
+	p.mu.Unlock()
+
+	return &CachedImage{
+		Data:        data,
+		ContentType: imageContentTypes[filepath.Ext(name)],
+		ETag:        etag,
+		ModTime:     entry.modTime,
+	}, true
+}
+
+// store writes the image to disk and evicts the least recently used images over the size cap
+func (p *imageProxy) store(name string, data []byte) (*CachedImage, error) {
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	temp, err := os.CreateTemp(p.dir, ".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return nil, err
+	}
+	if err := temp.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(temp.Name(), filepath.Join(p.dir, name)); err != nil {
+		return nil, err
+	}
+
+	entry := &cacheEntry{
+		name:    name,
+		bytes:   int64(len(data)),
+		etag:    imageETag(data),
+		modTime: time.Now().UTC().Truncate(time.Second),
+	}
+
+	p.mu.Lock()
+	if element, ok := p.entries[name]; ok {
+		p.bytes -= element.Value.(*cacheEntry).bytes
+		p.lru.Remove(element)
+	}
+	p.entries[name] = p.lru.PushFront(entry)
+	p.bytes += entry.bytes
+	p.evict()
+	p.mu.Unlock()
+
+	return &CachedImage{
+		Data:        data,
+		ContentType: imageContentTypes[filepath.Ext(name)],
+		ETag:        entry.etag,
+		ModTime:     entry.modTime,
+	}, nil
+}
+
+// load indexes the images already on disk, most recently modified first
+func (p *imageProxy) load() error {
+	items, err := os.ReadDir(p.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	entries := make([]*cacheEntry, 0, len(items))
+	for _, item := range items {
+		if item.IsDir() || strings.HasPrefix(item.Name(), ".") {
+			continue
+		}
+
+		info, err := item.Info()
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, &cacheEntry{
+			name:    item.Name(),
+			bytes:   info.Size(),
+			modTime: info.ModTime().UTC().Truncate(time.Second),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.After(entries[j].modTime)
+	})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, entry := range entries {
+		p.entries[entry.name] = p.lru.PushBack(entry)
+		p.bytes += entry.bytes
+	}
+	p.evict()
+
+	return nil
+}
+
+// evict must be called with the lock held
+func (p *imageProxy) evict() {
+	for p.bytes > p.maxBytes && p.lru.Len() > 1 {
+		p.remove(p.lru.Back())
+	}
+}
+
+// remove must be called with the lock held
+func (p *imageProxy) remove(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	if current, ok := p.entries[entry.name]; !ok || current != element {
+		return
+	}
+
+	p.lru.Remove(element)
+	delete(p.entries, entry.name)
+	p.bytes -= entry.bytes
+
+	if err := os.Remove(filepath.Join(p.dir, entry.name)); err != nil && !os.IsNotExist(err) {
+		p.log.Warn().Err(err).Str("name", entry.name).Msg("Failed to evict cached image")
+	}
+}
+
+func imageETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:16]))
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/tmdb/proxy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/tmdb/proxy.go -destination=pkg/tmdb/proxy_mock.go -package=tmdb
+//
+
+// Package tmdb is a generated GoMock package.
+package tmdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImageProxy is a mock of ImageProxy interface.
+type MockImageProxy struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageProxyMockRecorder
+	isgomock struct{}
+}
+
+// MockImageProxyMockRecorder is the mock recorder for MockImageProxy.
+type MockImageProxyMockRecorder struct {
+	mock *MockImageProxy
+}
+
+// NewMockImageProxy creates a new mock instance.
+func NewMockImageProxy(ctrl *gomock.Controller) *MockImageProxy {
+	mock := &MockImageProxy{ctrl: ctrl}
+	mock.recorder = &MockImageProxyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageProxy) EXPECT() *MockImageProxyMockRecorder {
+	return m.recorder
+}
+
+// Fetch mocks base method.
+func (m *MockImageProxy) Fetch(ctx context.Context, size, path string) (*CachedImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", ctx, size, path)
+	ret0, _ := ret[0].(*CachedImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockImageProxyMockRecorder) Fetch(ctx, size, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockImageProxy)(nil).Fetch), ctx, size, path)
+}
@@ -0,0 +1,181 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func newTestImageProxy(t *testing.T, maxBytes int) (*imageProxy, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if r.URL.Path == "/w185/missing.jpg" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte("image" + r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+		TMDBConfig: config.TMDBConfig{
+			BaseImageURL: server.URL + "/",
+		},
+		ImageProxyConfig: config.ImageProxyConfig{
+			CacheDir:      t.TempDir(),
+			CacheMaxBytes: maxBytes,
+		},
+	}
+
+	return NewImageProxy(cfg, logger.NewLogger(cfg)).(*imageProxy), &requests
+}
+
+func Test_IsValidImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     string
+		path     string
+		expected bool
+	}{
+		{name: "Poster", size: "w500", path: "/kqjL17yufvn9OVLyXYpvtyrFfak.jpg", expected: true},
+		{name: "Profile height", size: "h632", path: "/abc.png", expected: true},
+		{name: "Original", size: "original", path: "/abc.svg", expected: true},
+		{name: "Unknown size", size: "large", path: "/abc.jpg", expected: false},
+		{name: "Traversal", size: "w500", path: "/../../etc/passwd", expected: false},
+		{name: "Nested path", size: "w500", path: "/a/b.jpg", expected: false},
+		{name: "Unknown extension", size: "w500", path: "/abc.html", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsValidImage(tt.size, tt.path))
+		})
+	}
+}
+
+func Test_ImageProxy_Fetch(t *testing.T) {
+	proxy, requests := newTestImageProxy(t, 1024)
+	ctx := context.Background()
+
+	image, err := proxy.Fetch(ctx, "w185", "/poster.jpg")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("image/w185/poster.jpg"), image.Data)
+	assert.Equal(t, "image/jpeg", image.ContentType)
+	assert.NotEmpty(t, image.ETag)
+	assert.FileExists(t, filepath.Join(proxy.dir, "w185_poster.jpg"))
+
+	cached, err := proxy.Fetch(ctx, "w185", "/poster.jpg")
+	assert.NoError(t, err)
+	assert.Equal(t, image.ETag, cached.ETag)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+
+	_, err = proxy.Fetch(ctx, "w185", "/missing.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = proxy.Fetch(ctx, "w185", "/../secret.jpg")
+	assert.ErrorIs(t, err, ErrInvalidImage)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func Test_ImageProxy_Fetch_CallerGone(t *testing.T) {
+	var requests int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		close(started)
+		<-release
+
+		_, _ = w.Write([]byte("image" + r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: "info",
+		TMDBConfig: config.TMDBConfig{
+			BaseImageURL: server.URL,
+		},
+		ImageProxyConfig: config.ImageProxyConfig{
+			CacheDir: t.TempDir(),
+		},
+	}
+	proxy := NewImageProxy(cfg, logger.NewLogger(cfg))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	gone := make(chan error)
+	go func() {
+		_, err := proxy.Fetch(ctx, "w185", "/poster.jpg")
+		gone <- err
+	}()
+	<-started
+
+	type fetched struct {
+		image *CachedImage
+		err   error
+	}
+	waiting := make(chan fetched)
+	go func() {
+		image, err := proxy.Fetch(context.Background(), "w185", "/poster.jpg")
+		waiting <- fetched{image, err}
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-gone, context.Canceled)
+
+	close(release)
+	result := <-waiting
+	assert.NoError(t, result.err)
+	assert.Equal(t, []byte("image/w185/poster.jpg"), result.image.Data)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func Test_ImageProxy_Evicts(t *testing.T) {
+	// NOTE: each body is 16 bytes, the cap fits two of them
+	proxy, _ := newTestImageProxy(t, 40)
+	ctx := context.Background()
+
+	for _, path := range []string{"/a.jpg", "/b.jpg"} {
+		_, err := proxy.Fetch(ctx, "w185", path)
+		assert.NoError(t, err)
+	}
+
+	// touching a.jpg makes b.jpg the least recently used
+	_, err := proxy.Fetch(ctx, "w185", "/a.jpg")
+	assert.NoError(t, err)
+
+	_, err = proxy.Fetch(ctx, "w185", "/c.jpg")
+	assert.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(proxy.dir, "w185_a.jpg"))
+	assert.NoFileExists(t, filepath.Join(proxy.dir, "w185_b.jpg"))
+	assert.FileExists(t, filepath.Join(proxy.dir, "w185_c.jpg"))
+	assert.Equal(t, int64(32), proxy.bytes)
+}
+
+func Test_ImageProxy_LoadsExistingCache(t *testing.T) {
+	proxy, requests := newTestImageProxy(t, 1024)
+
+	err := os.WriteFile(filepath.Join(proxy.dir, "w185_cached.jpg"), []byte("cached"), 0o644)
+	assert.NoError(t, err)
+	assert.NoError(t, proxy.load())
+
+	image, err := proxy.Fetch(context.Background(), "w185", "/cached.jpg")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("cached"), image.Data)
+	assert.Equal(t, int32(0), atomic.LoadInt32(requests))
+}
@@ -0,0 +1,120 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// query builds the query string of a TMDB API request
+type query struct {
+	values url.Values
+}
+
+func newQuery() *query {
+	return &query{values: url.Values{}}
+}
+
+func (q *query) set(key, value string) *query {
+	if value != "" {
+		q.values.Set(key, value)
+	}
+	return q
+}
+
+// appendToResponse requests additional sub-resources in the same call
+func (q *query) appendToResponse(fields ...string) *query {
+	if existing := q.values.Get("append_to_response"); existing != "" {
+		fields = append(strings.Split(existing, ","), fields...)
+	}
+
+	return q.set("append_to_response", strings.Join(fields, ","))
+}
+
+func (q *query) changes(start time.Time, end time.Time, page int) *query {
+	return q.
+		set("start_date", start.UTC().Format(ChangesDateLayout)).
+		set("end_date", end.UTC().Format(ChangesDateLayout)).
+		set("page", strconv.Itoa(max(page, 1)))
+}
+
+// get fetches path from TMDB API and decodes the response into T
+func get[T any](ctx context.Context, c *client, path string, q *query) (*T, error) {
+	if q == nil {
+		q = newQuery()
+	}
+	q.set("language", c.languageFor(ctx))
+
+	endpoint := c.baseURL + path
+	label := endpointLabel(path)
+
+	c.log.Debug().
+		Str("endpoint", endpoint).
+		Str("query", q.values.Encode()).
+		Msg("Fetching from TMDB API")
+
+	started := time.Now()
+	request := c.apiClient.R().
+		SetContext(ctx).
+		SetQueryParamsFromValues(q.values)
+
+	response, err := c.execute(ctx, request, endpoint)
+	if err != nil {
+		c.metrics.observe(label, time.Since(started), true)
+		c.log.Error().
+			Err(err).
+			Str("endpoint", endpoint).
+			Msg("Failed to fetch from TMDB API")
+		return nil, err
+	}
+
+	c.metrics.observe(label, time.Since(started), response.StatusCode() != http.StatusOK)
+
+	switch response.StatusCode() {
+	case http.StatusOK:
+		var result T
+		if err = json.Unmarshal(response.Body(), &result); err != nil {
+			c.log.Error().
+				Err(err).
+				Str("endpoint", endpoint).
+				Msg("Failed to parse TMDB API response")
+			return nil, err
+		}
+
+		return &result, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		c.log.Error().
+			Int("statusCode", response.StatusCode()).
+			Str("endpoint", endpoint).
+			Msg("Access forbidden to TMDB API")
+		return nil, ErrAccessForbidden
+	case http.StatusNotFound:
+		c.log.Error().
+			Int("statusCode", response.StatusCode()).
+			Str("endpoint", endpoint).
+			Msg("Resource not found in TMDB API")
+		return nil, ErrNotFound
+	default:
+		c.log.Error().
+			Int("statusCode", response.StatusCode()).
+			Str("endpoint", endpoint).
+			Msg("Unexpected response from TMDB API")
+		return nil, ErrUnexpectedResponse
+	}
+}
+
+// endpointLabel replaces ids in a path to group metrics by endpoint, e.g. /movie/{id}
+func endpointLabel(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
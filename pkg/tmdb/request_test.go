@@ -0,0 +1,144 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"biinge-api/internal/config"
+	"biinge-api/internal/config/logger"
+)
+
+func Test_Query_AppendToResponse(t *testing.T) {
+	q := newQuery().
+		appendToResponse("credits", "videos").
+		appendToResponse("external_ids")
+
+	assert.Equal(t, "credits,videos,external_ids", q.values.Get("append_to_response"))
+}
+
+func Test_Query_Changes(t *testing.T) {
+	start := time.Date(2025, 7, 12, 23, 30, 0, 0, time.UTC)
+	q := newQuery().changes(start, start.Add(24*time.Hour), 0)
+
+	assert.Equal(t, "2025-07-12", q.values.Get("start_date"))
+	assert.Equal(t, "2025-07-13", q.values.Get("end_date"))
+	assert.Equal(t, "1", q.values.Get("page"))
+}
+
+func Test_EndpointLabel(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/movie/550", expected: "/movie/{id}"},
+		{path: "/tv/1399/season/1/episode/2", expected: "/tv/{id}/season/{id}/episode/{id}"},
+		{path: "/configuration", expected: "/configuration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, endpointLabel(tt.path))
+		})
+	}
+}
+
+func Test_Get(t *testing.T) {
+	type payload struct {
+		Id int `json:"id"`
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected *payload
+		error    error
+	}{
+		{
+			name:     "Success",
+			status:   http.StatusOK,
+			body:     `{"id": 550}`,
+			expected: &payload{Id: 550},
+		},
+		{
+			name:   "Unauthorized",
+			status: http.StatusUnauthorized,
+			error:  ErrAccessForbidden,
+		},
+		{
+			name:   "Not found",
+			status: http.StatusNotFound,
+			error:  ErrNotFound,
+		},
+		{
+			name:   "Unexpected response",
+			status: http.StatusBadGateway,
+			error:  ErrUnexpectedResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/movie/550", r.URL.Path)
+				assert.Equal(t, "en-US", r.URL.Query().Get("language"))
+				assert.Equal(t, "credits", r.URL.Query().Get("append_to_response"))
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{
+				TMDBConfig: config.TMDBConfig{
+					BaseURL: server.URL,
+					Locale:  "en-US",
+				},
+			}
+			c := NewClient(cfg, logger.NewLogger(cfg)).(*client)
+
+			result, err := get[payload](context.Background(), c, "/movie/550", newQuery().appendToResponse("credits"))
+
+			assert.Equal(t, tt.expected, result)
+			assert.ErrorIs(t, err, tt.error)
+
+			metrics := c.Metrics()
+			assert.Len(t, metrics, 1)
+			assert.Equal(t, "/movie/{id}", metrics[0].Endpoint)
+			assert.Equal(t, uint64(1), metrics[0].Requests)
+		})
+	}
+}
+
+func Test_Get_Locale(t *testing.T) {
+	var languages []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		languages = append(languages, r.URL.Query().Get("language"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		TMDBConfig: config.TMDBConfig{
+			BaseURL: server.URL,
+			Locale:  "en-US",
+		},
+	}
+	c := NewClient(cfg, logger.NewLogger(cfg)).(*client)
+
+	ctx := WithLocale(context.Background(), Locale{Language: "de"})
+
+	_, err := get[struct{}](context.Background(), c, "/movie/550", nil)
+	assert.NoError(t, err)
+	_, err = get[struct{}](ctx, c, "/movie/550", nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"en-US", "de"}, languages)
+}
@@ -14,6 +14,24 @@ const (
 	TMDBTrailerType              = "Trailer"
 )
 
+// Person credit filters, CreditTypeAll keeps acting and directing credits
+const (
+	CreditTypeAll      = ""
+	CreditTypeCast     = "cast"
+	CreditTypeCrew     = "crew"
+	CreditTypeDirector = "director"
+)
+
+// TMDB release types, see https://developer.themoviedb.org/reference/movie-release-dates
+const (
+	TMDBReleasePremiere = iota + 1
+	TMDBReleaseTheatricalLimited
+	TMDBReleaseTheatrical
+	TMDBReleaseDigital
+	TMDBReleasePhysical
+	TMDBReleaseTV
+)
+
 func UniqById[T any](items []T, idFunc func(T) int) []T {
 	if len(items) == 0 {
 		return items
@@ -33,14 +51,96 @@ func UniqById[T any](items []T, idFunc func(T) int) []T {
 	return result
 }
 
-func TransformMovieDetails(movie *MovieDetails) *MovieResponse {
+// TransformReleaseDates returns the release dates of region sorted by date,
+// along with the certification of the theatrical release or the first certified one
+func TransformReleaseDates(releaseDates ReleaseDates, region string) ([]ReleaseDateItem, string) {
+	result := make([]ReleaseDateItem, 0)
+	for _, country := range releaseDates.Results {
+		if country.ISO31661 != region {
+			continue
+		}
+
+		for _, release := range country.ReleaseDates {
+			date := release.ReleaseDate
+			if len(date) > len("2006-01-02") {
+				date = date[:len("2006-01-02")]
+			}
+
+			result = append(result, ReleaseDateItem{
+				Type:          release.Type,
+				Date:          date,
+				Certification: release.Certification,
+				Note:          release.Note,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Date < result[j].Date
+	})
+
+	certification := ""
+	for _, release := range result {
+		if release.Certification == "" {
+			continue
+		}
+
+		if release.Type == TMDBReleaseTheatrical {
+			return result, release.Certification
+		}
+
+		if certification == "" {
+			certification = release.Certification
+		}
+	}
+
+	return result, certification
+}
+
+func transformWatchProviderItems(providers []WatchProvider) []WatchProviderItem {
+	sorted := make([]WatchProvider, len(providers))
+	copy(sorted, providers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].DisplayPriority < sorted[j].DisplayPriority
+	})
+
+	result := make([]WatchProviderItem, 0, len(sorted))
+	for _, provider := range sorted {
+		result = append(result, WatchProviderItem{
+			Id:       provider.ProviderId,
+			Name:     provider.ProviderName,
+			LogoPath: provider.LogoPath,
+		})
+	}
+
+	return result
+}
+
+// TransformWatchProviders returns the flatrate, rent and buy providers of region ordered by display priority,
+// nil when the title is not available there
+func TransformWatchProviders(providers WatchProviders, region string) *WatchProvidersItem {
+	available, ok := providers.Results[region]
+	if !ok {
+		return nil
+	}
+
+	return &WatchProvidersItem{
+		Link:     available.Link,
+		Flatrate: transformWatchProviderItems(available.Flatrate),
+		Rent:     transformWatchProviderItems(available.Rent),
+		Buy:      transformWatchProviderItems(available.Buy),
+	}
+}
+
+// TransformMovieDetails flattens movie details, release dates and certification are picked for region
+func TransformMovieDetails(movie *MovieDetails, region string, filter ContentFilter) *MovieResponse {
 	if movie == nil {
 		return nil
 	}
 
 	directorCredits := make([]CreditItem, 0)
 	for _, crew := range movie.Credits.Crew {
-		if crew.Job == TMDBJobDirector && crew.ProfilePath != "" {
+		if crew.Job == TMDBJobDirector && filter.AllowsArtwork(crew.ProfilePath) {
 			directorCredits = append(directorCredits, CreditItem{
 				Id:          crew.Id,
 				ProfilePath: crew.ProfilePath,
@@ -52,7 +152,7 @@ func TransformMovieDetails(movie *MovieDetails) *MovieResponse {
 
 	castCredits := make([]CreditItem, 0)
 	for _, cast := range movie.Credits.Cast {
-		if cast.ProfilePath != "" {
+		if filter.AllowsArtwork(cast.ProfilePath) {
 			castCredits = append(castCredits, CreditItem{
 				Id:          cast.Id,
 				ProfilePath: cast.ProfilePath,
@@ -64,7 +164,7 @@ func TransformMovieDetails(movie *MovieDetails) *MovieResponse {
 
 	crewCredits := make([]CreditItem, 0)
 	for _, crew := range movie.Credits.Crew {
-		if crew.ProfilePath != "" && (crew.Job == TMDBJobDirectorOfPhotography ||
+		if filter.AllowsArtwork(crew.ProfilePath) && (crew.Job == TMDBJobDirectorOfPhotography ||
 			crew.Job == TMDBJobScreenplay ||
 			crew.Job == TMDBJobWriter) {
 			crewCredits = append(crewCredits, CreditItem{
@@ -78,7 +178,7 @@ func TransformMovieDetails(movie *MovieDetails) *MovieResponse {
 
 	recommendations := make([]RecommendationItem, 0)
 	for _, rec := range movie.Recommendations.Results {
-		if rec.PosterPath != "" {
+		if filter.AllowsTitle(rec.Adult, rec.PosterPath, rec.GenreIds) {
 			title := rec.Title
 			if title == "" {
 				title = rec.Name
@@ -107,16 +207,82 @@ func TransformMovieDetails(movie *MovieDetails) *MovieResponse {
 		return c.Id
 	})
 
+	genres := make([]GenreItem, 0, len(movie.Genres))
+	for _, genre := range movie.Genres {
+		genres = append(genres, GenreItem{
+			Id:   genre.Id,
+			Name: genre.Name,
+		})
+	}
+
+	countries := make([]CountryItem, 0, len(movie.ProductionCountries))
+	for _, country := range movie.ProductionCountries {
+		countries = append(countries, CountryItem{
+			Code: country.ISO31661,
+			Name: country.Name,
+		})
+	}
+
+	languages := make([]LanguageItem, 0, len(movie.SpokenLanguages))
+	for _, language := range movie.SpokenLanguages {
+		name := language.EnglishName
+		if name == "" {
+			name = language.Name
+		}
+
+		languages = append(languages, LanguageItem{
+			Code: language.ISO6391,
+			Name: name,
+		})
+	}
+
+	var collection *CollectionItem
+	if movie.BelongsToCollection != nil {
+		collection = &CollectionItem{
+			Id:           movie.BelongsToCollection.Id,
+			Name:         movie.BelongsToCollection.Name,
+			PosterPath:   movie.BelongsToCollection.PosterPath,
+			BackdropPath: movie.BelongsToCollection.BackdropPath,
+		}
+	}
+
+	imdbId := movie.ImdbId
+	if imdbId == "" {
+		imdbId = movie.ExternalIds.ImdbId
+	}
+
+	releaseDates, certification := TransformReleaseDates(movie.ReleaseDates, region)
+
 	return &MovieResponse{
-		Id:              movie.Id,
-		Title:           movie.Title,
-		Overview:        movie.Overview,
-		PosterPath:      movie.PosterPath,
-		Status:          movie.Status,
-		ImdbId:          movie.ImdbId,
-		ReleaseDate:     movie.ReleaseDate,
-		Runtime:         movie.Runtime,
-		Rating:          movie.VoteAverage,
+		Id:                  movie.Id,
+		Title:               movie.Title,
+		OriginalTitle:       movie.OriginalTitle,
+		OriginalLanguage:    movie.OriginalLanguage,
+		Tagline:             movie.Tagline,
+		Overview:            movie.Overview,
+		PosterPath:          movie.PosterPath,
+		BackdropPath:        movie.BackdropPath,
+		Status:              movie.Status,
+		ImdbId:              imdbId,
+		ReleaseDate:         movie.ReleaseDate,
+		Runtime:             movie.Runtime,
+		Budget:              movie.Budget,
+		Revenue:             movie.Revenue,
+		Rating:              movie.VoteAverage,
+		Genres:              genres,
+		ProductionCountries: countries,
+		SpokenLanguages:     languages,
+		Collection:          collection,
+		ExternalIds: ExternalIdsItem{
+			ImdbId:      imdbId,
+			WikidataId:  movie.ExternalIds.WikidataId,
+			FacebookId:  movie.ExternalIds.FacebookId,
+			InstagramId: movie.ExternalIds.InstagramId,
+			TwitterId:   movie.ExternalIds.TwitterId,
+		},
+		Certification:   certification,
+		ReleaseDates:    releaseDates,
+		WatchProviders:  TransformWatchProviders(movie.WatchProviders, region),
 		Credits:         uniqueCredits,
 		Recommendations: recommendations,
 		Videos:          videos,
@@ -130,10 +296,10 @@ func ParseDate(dateStr string) (time.Time, error) {
 	return time.Parse("2006-01-02", dateStr)
 }
 
-func FilterMovieCredits(credits []MovieCredit) []MovieCreditItem {
+func FilterMovieCredits(credits []MovieCredit, filter ContentFilter) []MovieCreditItem {
 	filtered := make([]MovieCredit, 0)
 	for _, credit := range credits {
-		if !credit.Adult && credit.PosterPath != "" && credit.ReleaseDate != "" {
+		if credit.ReleaseDate != "" && filter.AllowsTitle(credit.Adult, credit.PosterPath, credit.GenreIds) {
 			filtered = append(filtered, credit)
 		}
 	}
@@ -157,36 +323,22 @@ func FilterMovieCredits(credits []MovieCredit) []MovieCreditItem {
 		}
 
 		result[i] = MovieCreditItem{
-			Id:         credit.Id,
-			Title:      credit.Title,
-			PosterPath: credit.PosterPath,
-			Type:       creditType,
+			Id:          credit.Id,
+			Title:       credit.Title,
+			PosterPath:  credit.PosterPath,
+			ReleaseDate: credit.ReleaseDate,
+			Type:        creditType,
 		}
 	}
 
 	return result
 }
 
-func FilterTvCredits(credits []TvCredit, excludedGenreIds []int) []TvCreditItem {
+func FilterTvCredits(credits []TvCredit, filter ContentFilter) []TvCreditItem {
 	filtered := make([]TvCredit, 0)
 	for _, credit := range credits {
-		if !credit.Adult && credit.PosterPath != "" && credit.FirstAirDate != "" && len(credit.GenreIds) > 0 {
-			hasExcludedGenre := false
-			for _, excludedId := range excludedGenreIds {
-				for _, genreId := range credit.GenreIds {
-					if genreId == excludedId {
-						hasExcludedGenre = true
-						break
-					}
-				}
-				if hasExcludedGenre {
-					break
-				}
-			}
-
-			if !hasExcludedGenre {
-				filtered = append(filtered, credit)
-			}
+		if credit.FirstAirDate != "" && len(credit.GenreIds) > 0 && filter.AllowsTitle(credit.Adult, credit.PosterPath, credit.GenreIds) {
+			filtered = append(filtered, credit)
 		}
 	}
 
@@ -203,10 +355,16 @@ func FilterTvCredits(credits []TvCredit, excludedGenreIds []int) []TvCreditItem
 
 	result := make([]TvCreditItem, len(filtered))
 	for i, credit := range filtered {
+		creditType := credit.Character
+		if credit.Job != "" {
+			creditType = credit.Job
+		}
+
 		result[i] = TvCreditItem{
 			Id:            credit.Id,
 			Title:         credit.Name,
 			PosterPath:    credit.PosterPath,
+			Type:          creditType,
 			EpisodesCount: credit.EpisodeCount,
 		}
 	}
@@ -214,63 +372,152 @@ func FilterTvCredits(credits []TvCredit, excludedGenreIds []int) []TvCreditItem
 	return result
 }
 
-func TransformPersonDetails(person *PersonDetails) *PersonResponse {
-	if person == nil {
-		return nil
+func IsValidCreditType(creditType string) bool {
+	switch creditType {
+	case CreditTypeAll, CreditTypeCast, CreditTypeCrew, CreditTypeDirector:
+		return true
 	}
 
-	castCredits := make([]MovieCredit, 0)
-	castCredits = append(castCredits, person.Credits.Cast...)
+	return false
+}
+
+// selectCredits picks the cast and crew credits matching creditType
+func selectCredits[T any](cast []T, crew []T, job func(T) string, creditType string) []T {
+	selected := make([]T, 0)
+
+	if creditType == CreditTypeAll || creditType == CreditTypeCast {
+		selected = append(selected, cast...)
+	}
 
-	directorCredits := make([]MovieCredit, 0)
-	for _, crew := range person.Credits.Crew {
-		if crew.Job == TMDBJobDirector {
-			directorCredits = append(directorCredits, crew)
+	for _, credit := range crew {
+		switch {
+		case creditType == CreditTypeCrew:
+			selected = append(selected, credit)
+		case creditType == CreditTypeAll || creditType == CreditTypeDirector:
+			if job(credit) == TMDBJobDirector {
+				selected = append(selected, credit)
+			}
 		}
 	}
 
-	castCredits = append(castCredits, directorCredits...)
-	movieCredits := FilterMovieCredits(castCredits)
+	return selected
+}
 
-	tvCastCredits := make([]TvCredit, 0)
-	tvCastCredits = append(tvCastCredits, person.TvCredits.Cast...)
+// FilmographyCredits returns the unique movie credits of creditType, newest first
+func FilmographyCredits(person *PersonDetails, creditType string, filter ContentFilter) []MovieCreditItem {
+	credits := FilterMovieCredits(selectCredits(person.Credits.Cast, person.Credits.Crew, func(c MovieCredit) string {
+		return c.Job
+	}, creditType), filter)
 
-	tvDirectorCredits := make([]TvCredit, 0)
-	for _, crew := range person.TvCredits.Crew {
-		if crew.Job == TMDBJobDirector {
-			tvDirectorCredits = append(tvDirectorCredits, crew)
-		}
+	return UniqById(credits, func(c MovieCreditItem) int {
+		return int(c.Id)
+	})
+}
+
+func TransformPersonDetails(person *PersonDetails, creditType string, filter ContentFilter) *PersonResponse {
+	if person == nil {
+		return nil
 	}
 
-	excludedGenreIds := []int{10767, 10763, 10764, 99} // Talk, News, Reality, Documentary
+	uniqueMovieCredits := FilmographyCredits(person, creditType, filter)
 
-	tvCastCredits = append(tvCastCredits, tvDirectorCredits...)
-	tvCredits := FilterTvCredits(tvCastCredits, excludedGenreIds)
+	tvCredits := FilterTvCredits(selectCredits(person.TvCredits.Cast, person.TvCredits.Crew, func(c TvCredit) string {
+		return c.Job
+	}, creditType), filter)
 
 	uniqueTvCredits := UniqById(tvCredits, func(c TvCreditItem) int {
 		return c.Id
 	})
 
+	imdbId := person.ImdbId
+	if imdbId == "" {
+		imdbId = person.ExternalIds.ImdbId
+	}
+
+	profileImages := make([]ImageItem, 0, len(person.Images.Profiles))
+	for _, image := range person.Images.Profiles {
+		profileImages = append(profileImages, ImageItem{
+			FilePath: image.FilePath,
+			Width:    image.Width,
+			Height:   image.Height,
+		})
+	}
+
 	return &PersonResponse{
-		Id:           person.Id,
-		ImdbId:       person.ImdbId,
-		Name:         person.Name,
-		Birthday:     person.Birthday,
-		ProfilePath:  person.ProfilePath,
-		Gender:       person.Gender,
-		MovieCredits: movieCredits,
-		TvCredits:    uniqueTvCredits,
+		Id:                 person.Id,
+		ImdbId:             imdbId,
+		Name:               person.Name,
+		Biography:          person.Biography,
+		Birthday:           person.Birthday,
+		Deathday:           person.Deathday,
+		PlaceOfBirth:       person.PlaceOfBirth,
+		KnownForDepartment: person.KnownForDepartment,
+		ProfilePath:        person.ProfilePath,
+		Gender:             person.Gender,
+		ExternalIds: ExternalIdsItem{
+			ImdbId:      imdbId,
+			WikidataId:  person.ExternalIds.WikidataId,
+			FacebookId:  person.ExternalIds.FacebookId,
+			InstagramId: person.ExternalIds.InstagramId,
+			TwitterId:   person.ExternalIds.TwitterId,
+		},
+		ProfileImages: profileImages,
+		MovieCredits:  uniqueMovieCredits,
+		TvCredits:     uniqueTvCredits,
+	}
+}
+
+// TransformCollectionDetails lists the parts of a collection in release order, unreleased parts without a date last
+func TransformCollectionDetails(collection *CollectionDetails, filter ContentFilter) *CollectionResponse {
+	if collection == nil {
+		return nil
+	}
+
+	filtered := make([]CollectionPart, 0, len(collection.Parts))
+	for _, part := range collection.Parts {
+		if !part.Adult || filter.IncludeAdult {
+			filtered = append(filtered, part)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		dateA, dateB := filtered[i].ReleaseDate, filtered[j].ReleaseDate
+		if dateA == "" || dateB == "" {
+			return dateA != "" && dateB == ""
+		}
+
+		return dateA < dateB
+	})
+
+	parts := make([]CollectionPartItem, len(filtered))
+	for i, part := range filtered {
+		parts[i] = CollectionPartItem{
+			Id:          part.Id,
+			Title:       part.Title,
+			PosterPath:  part.PosterPath,
+			ReleaseDate: part.ReleaseDate,
+			Rating:      part.VoteAverage,
+		}
+	}
+
+	return &CollectionResponse{
+		Id:           collection.Id,
+		Name:         collection.Name,
+		Overview:     collection.Overview,
+		PosterPath:   collection.PosterPath,
+		BackdropPath: collection.BackdropPath,
+		Parts:        parts,
 	}
 }
 
-func TransformTvDetails(tvShow *TvDetails) *TvResponse {
+func TransformTvDetails(tvShow *TvDetails, region string, filter ContentFilter) *TvResponse {
 	if tvShow == nil {
 		return nil
 	}
 
 	directorCredits := make([]CreditItem, 0)
 	for _, crew := range tvShow.Credits.Crew {
-		if crew.Job == TMDBJobDirector && crew.ProfilePath != "" {
+		if crew.Job == TMDBJobDirector && filter.AllowsArtwork(crew.ProfilePath) {
 			directorCredits = append(directorCredits, CreditItem{
 				Id:          crew.Id,
 				ProfilePath: crew.ProfilePath,
@@ -282,7 +529,7 @@ func TransformTvDetails(tvShow *TvDetails) *TvResponse {
 
 	castCredits := make([]CreditItem, 0)
 	for _, cast := range tvShow.Credits.Cast {
-		if cast.ProfilePath != "" {
+		if filter.AllowsArtwork(cast.ProfilePath) {
 			castCredits = append(castCredits, CreditItem{
 				Id:          cast.Id,
 				ProfilePath: cast.ProfilePath,
@@ -294,7 +541,7 @@ func TransformTvDetails(tvShow *TvDetails) *TvResponse {
 
 	crewCredits := make([]CreditItem, 0)
 	for _, crew := range tvShow.Credits.Crew {
-		if crew.ProfilePath != "" && (crew.Job == TMDBJobDirectorOfPhotography ||
+		if filter.AllowsArtwork(crew.ProfilePath) && (crew.Job == TMDBJobDirectorOfPhotography ||
 			crew.Job == TMDBJobScreenplay ||
 			crew.Job == TMDBJobWriter) {
 			crewCredits = append(crewCredits, CreditItem{
@@ -308,7 +555,7 @@ func TransformTvDetails(tvShow *TvDetails) *TvResponse {
 
 	recommendations := make([]RecommendationItem, 0)
 	for _, rec := range tvShow.Recommendations.Results {
-		if rec.PosterPath != "" {
+		if filter.AllowsTitle(rec.Adult, rec.PosterPath, rec.GenreIds) {
 			title := rec.Title
 			if title == "" {
 				title = rec.Name
@@ -349,5 +596,6 @@ func TransformTvDetails(tvShow *TvDetails) *TvResponse {
 		Credits:         uniqueCredits,
 		Recommendations: recommendations,
 		Videos:          videos,
+		WatchProviders:  TransformWatchProviders(tvShow.WatchProviders, region),
 	}
 }
@@ -0,0 +1,226 @@
+package tmdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TransformReleaseDates(t *testing.T) {
+	releaseDates := ReleaseDates{
+		Results: []CountryReleaseDates{
+			{
+				ISO31661: "US",
+				ReleaseDates: []ReleaseDate{
+					{Type: TMDBReleaseDigital, ReleaseDate: "2021-12-10T00:00:00.000Z", Certification: "PG-13"},
+					{Type: TMDBReleaseTheatrical, ReleaseDate: "2021-10-22T00:00:00.000Z", Certification: "PG-13"},
+					{Type: TMDBReleasePremiere, ReleaseDate: "2021-09-03T00:00:00.000Z", Note: "Venice"},
+				},
+			},
+			{
+				ISO31661: "DE",
+				ReleaseDates: []ReleaseDate{
+					{Type: TMDBReleaseDigital, ReleaseDate: "2022-01-20T00:00:00.000Z", Certification: "12"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		region        string
+		expected      []ReleaseDateItem
+		certification string
+	}{
+		{
+			name:   "Theatrical certification",
+			region: "US",
+			expected: []ReleaseDateItem{
+				{Type: TMDBReleasePremiere, Date: "2021-09-03", Note: "Venice"},
+				{Type: TMDBReleaseTheatrical, Date: "2021-10-22", Certification: "PG-13"},
+				{Type: TMDBReleaseDigital, Date: "2021-12-10", Certification: "PG-13"},
+			},
+			certification: "PG-13",
+		},
+		{
+			name:          "First certified release",
+			region:        "DE",
+			expected:      []ReleaseDateItem{{Type: TMDBReleaseDigital, Date: "2022-01-20", Certification: "12"}},
+			certification: "12",
+		},
+		{
+			name:     "Unknown region",
+			region:   "EE",
+			expected: []ReleaseDateItem{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, certification := TransformReleaseDates(releaseDates, tt.region)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.certification, certification)
+		})
+	}
+}
+
+func Test_TransformMovieDetails(t *testing.T) {
+	movie := &MovieDetails{
+		Id:               438631,
+		Title:            "Dune",
+		OriginalTitle:    "Dune",
+		OriginalLanguage: "en",
+		Tagline:          "It begins.",
+		BackdropPath:     "/backdrop.jpg",
+		Budget:           165000000,
+		Revenue:          402027830,
+		Genres:           []Genre{{Id: 878, Name: "Science Fiction"}},
+		ProductionCountries: []ProductionCountry{
+			{ISO31661: "US", Name: "United States of America"},
+		},
+		SpokenLanguages: []SpokenLanguage{
+			{ISO6391: "en", Name: "English", EnglishName: "English"},
+			{ISO6391: "zh", Name: "普通话"},
+		},
+		BelongsToCollection: &CollectionSummary{Id: 726871, Name: "Dune Collection"},
+		ExternalIds:         ExternalIds{ImdbId: "tt1160419", WikidataId: "Q63985561"},
+	}
+
+	result := TransformMovieDetails(movie, "US", DefaultContentFilter())
+
+	assert.Equal(t, "tt1160419", result.ImdbId)
+	assert.Equal(t, "tt1160419", result.ExternalIds.ImdbId)
+	assert.Equal(t, "Q63985561", result.ExternalIds.WikidataId)
+	assert.Equal(t, "It begins.", result.Tagline)
+	assert.Equal(t, "/backdrop.jpg", result.BackdropPath)
+	assert.Equal(t, int64(165000000), result.Budget)
+	assert.Equal(t, int64(402027830), result.Revenue)
+	assert.Equal(t, []GenreItem{{Id: 878, Name: "Science Fiction"}}, result.Genres)
+	assert.Equal(t, []CountryItem{{Code: "US", Name: "United States of America"}}, result.ProductionCountries)
+	assert.Equal(t, []LanguageItem{{Code: "en", Name: "English"}, {Code: "zh", Name: "普通话"}}, result.SpokenLanguages)
+	assert.Equal(t, &CollectionItem{Id: 726871, Name: "Dune Collection"}, result.Collection)
+	assert.Empty(t, result.ReleaseDates)
+
+	assert.Nil(t, TransformMovieDetails(nil, "US", DefaultContentFilter()))
+}
+
+func Test_TransformWatchProviders(t *testing.T) {
+	providers := WatchProviders{
+		Results: map[string]RegionWatchProviders{
+			"US": {
+				Link: "https://www.themoviedb.org/movie/438631/watch?locale=US",
+				Flatrate: []WatchProvider{
+					{ProviderId: 1899, ProviderName: "Max", LogoPath: "/max.jpg", DisplayPriority: 5},
+					{ProviderId: 8, ProviderName: "Netflix", LogoPath: "/netflix.jpg", DisplayPriority: 1},
+				},
+				Buy: []WatchProvider{
+					{ProviderId: 2, ProviderName: "Apple TV", LogoPath: "/apple.jpg", DisplayPriority: 2},
+				},
+			},
+		},
+	}
+
+	result := TransformWatchProviders(providers, "US")
+
+	assert.Equal(t, &WatchProvidersItem{
+		Link: "https://www.themoviedb.org/movie/438631/watch?locale=US",
+		Flatrate: []WatchProviderItem{
+			{Id: 8, Name: "Netflix", LogoPath: "/netflix.jpg"},
+			{Id: 1899, Name: "Max", LogoPath: "/max.jpg"},
+		},
+		Rent: []WatchProviderItem{},
+		Buy:  []WatchProviderItem{{Id: 2, Name: "Apple TV", LogoPath: "/apple.jpg"}},
+	}, result)
+	assert.Nil(t, TransformWatchProviders(providers, "EE"))
+}
+
+func Test_TransformCollectionDetails(t *testing.T) {
+	collection := &CollectionDetails{
+		Id:   1241,
+		Name: "Harry Potter Collection",
+		Parts: []CollectionPart{
+			{Id: 3, Title: "Untitled", ReleaseDate: ""},
+			{Id: 2, Title: "Chamber of Secrets", ReleaseDate: "2002-11-13", VoteAverage: 7.7},
+			{Id: 4, Title: "Adult", ReleaseDate: "2003-01-01", Adult: true},
+			{Id: 1, Title: "Philosopher's Stone", ReleaseDate: "2001-11-16", VoteAverage: 7.9},
+		},
+	}
+
+	result := TransformCollectionDetails(collection, DefaultContentFilter())
+
+	assert.Equal(t, 1241, result.Id)
+	assert.Equal(t, []CollectionPartItem{
+		{Id: 1, Title: "Philosopher's Stone", ReleaseDate: "2001-11-16", Rating: 7.9},
+		{Id: 2, Title: "Chamber of Secrets", ReleaseDate: "2002-11-13", Rating: 7.7},
+		{Id: 3, Title: "Untitled", ReleaseDate: ""},
+	}, result.Parts)
+
+	assert.Nil(t, TransformCollectionDetails(nil, DefaultContentFilter()))
+}
+
+func Test_TransformPersonDetails(t *testing.T) {
+	person := &PersonDetails{
+		Id:                 525,
+		Name:               "Christopher Nolan",
+		Biography:          "British-American filmmaker",
+		PlaceOfBirth:       "London, England, UK",
+		KnownForDepartment: "Directing",
+		ExternalIds:        ExternalIds{ImdbId: "nm0634240", WikidataId: "Q25191"},
+		Images: PersonImages{
+			Profiles: []Image{{FilePath: "/profile.jpg", Width: 800, Height: 1200}},
+		},
+		Credits: PersonMovieCredits{
+			Cast: []MovieCredit{
+				{Id: 1, Title: "Doodlebug", PosterPath: "/1.jpg", ReleaseDate: "1997-01-01", Character: "Man"},
+			},
+			Crew: []MovieCredit{
+				{Id: 2, Title: "Inception", PosterPath: "/2.jpg", ReleaseDate: "2010-07-15", Job: "Director"},
+				{Id: 2, Title: "Inception", PosterPath: "/2.jpg", ReleaseDate: "2010-07-15", Job: "Writer"},
+				{Id: 3, Title: "Man of Steel", PosterPath: "/3.jpg", ReleaseDate: "2013-06-12", Job: "Producer"},
+			},
+		},
+		TvCredits: PersonTvCredits{
+			Crew: []TvCredit{
+				{Id: 4, Name: "Westworld", PosterPath: "/4.jpg", FirstAirDate: "2016-10-02", Job: "Executive Producer", GenreIds: []int{10765}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		creditType string
+		movieIds   []uint64
+		tvIds      []int
+	}{
+		{name: "Acting and directing by default", creditType: CreditTypeAll, movieIds: []uint64{2, 1}, tvIds: []int{}},
+		{name: "Cast", creditType: CreditTypeCast, movieIds: []uint64{1}, tvIds: []int{}},
+		{name: "Crew", creditType: CreditTypeCrew, movieIds: []uint64{3, 2}, tvIds: []int{4}},
+		{name: "Director", creditType: CreditTypeDirector, movieIds: []uint64{2}, tvIds: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TransformPersonDetails(person, tt.creditType, DefaultContentFilter())
+
+			movieIds := make([]uint64, 0, len(result.MovieCredits))
+			for _, credit := range result.MovieCredits {
+				movieIds = append(movieIds, credit.Id)
+			}
+
+			tvIds := make([]int, 0, len(result.TvCredits))
+			for _, credit := range result.TvCredits {
+				tvIds = append(tvIds, credit.Id)
+			}
+
+			assert.Equal(t, tt.movieIds, movieIds)
+			assert.Equal(t, tt.tvIds, tvIds)
+			assert.Equal(t, "nm0634240", result.ImdbId)
+			assert.Equal(t, "Directing", result.KnownForDepartment)
+			assert.Equal(t, []ImageItem{{FilePath: "/profile.jpg", Width: 800, Height: 1200}}, result.ProfileImages)
+		})
+	}
+
+	assert.True(t, IsValidCreditType("director"))
+	assert.False(t, IsValidCreditType("writer"))
+	assert.Nil(t, TransformPersonDetails(nil, CreditTypeAll, DefaultContentFilter()))
+}